@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createDoctorCmd() *cobra.Command {
+	var fix bool
+	var jsonOutput bool
+
+	doctorCmd := &cobra.Command{
+		Use:   "doctor [--fix] [--json]",
+		Short: "Find and fix drift between the status file and git worktrees",
+		Long: `Check that the status file matches the git worktrees of every repository and the workspaces.
+
+Problems found:
+  missing-path             Worktree in the status file whose directory is gone
+  unknown-worktree         Git worktree that is not in the status file
+  stale-admin-dir          .git/worktrees entry whose directory is gone (git worktree prune)
+  dangling-workspace-ref   Workspace reference to a worktree that does not exist
+
+Without flags, each fix is confirmed interactively. Use --fix to fix everything without asking,
+and --json for a machine-readable output (without prompts).
+
+Examples:
+  cm doctor
+  cm doctor --fix
+  cm doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDoctorCommand(fix, jsonOutput)
+		},
+	}
+
+	doctorCmd.Flags().BoolVar(&fix, "fix", false, "Fix every fixable problem without asking")
+	doctorCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the problems as JSON")
+
+	return doctorCmd
+}
+
+// runDoctorCommand executes the doctor command logic.
+func runDoctorCommand(fix, jsonOutput bool) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	issues, err := cmManager.Doctor(cm.DoctorOpts{
+		Fix:         fix,
+		Interactive: !fix && !jsonOutput,
+	})
+	if err != nil && !errors.Is(err, cm.ErrDoctorFixIncomplete) {
+		return err
+	}
+
+	if jsonOutput {
+		if printErr := printDoctorIssuesJSON(issues); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+
+	fmt.Printf("Found %d problems:\n", len(issues))
+	for _, issue := range issues {
+		displayDoctorIssue(issue)
+	}
+	return err
+}
+
+// displayDoctorIssue prints a problem with its fix and whether it was applied.
+func displayDoctorIssue(issue cm.DoctorIssue) {
+	fmt.Printf("  [%s] %s\n", issue.Kind, issue.Description)
+	switch {
+	case issue.Fixed:
+		fmt.Printf("      fixed: %s\n", issue.Fix)
+	case issue.Error != "":
+		fmt.Printf("      fix failed: %s\n", issue.Error)
+	case issue.Fix != "":
+		fmt.Printf("      fix: %s\n", issue.Fix)
+	default:
+		fmt.Println("      fix: by hand")
+	}
+}
+
+// printDoctorIssuesJSON prints the problems as an indented JSON array.
+func printDoctorIssuesJSON(issues []cm.DoctorIssue) error {
+	if issues == nil {
+		issues = []cm.DoctorIssue{}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode problems: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createExecCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+	var all bool
+	var jobs int
+	var jsonOutput bool
+
+	execCmd := &cobra.Command{
+		Use:   "exec [--workspace <workspace-name>|--repository <repository-name>|--all] [-j <jobs>] [--json] -- <command>",
+		Short: "Run a command in every worktree",
+		Long: `Run a command in the directory of every worktree of a workspace, a repository, or all repositories.
+
+Commands run in parallel, at most --jobs at the same time, and each line of their output is prefixed
+with the repository and branch of the worktree. Once all of them finished, a summary of the passed
+and failed commands is printed, and cm exits with an error if any of them failed. Use --json to
+print the results, with the output of each command, as JSON instead.
+
+Examples:
+  cm exec -- git pull                                # Interactive selection of workspace/repository
+  cm exec --repository my-repo -- go test ./...
+  cm exec --workspace my-workspace -j 8 -- make lint
+  cm exec --all --json -- git status --short`,
+		Args: func(_ *cobra.Command, args []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			if all && (workspaceName != "" || repositoryName != "") {
+				return fmt.Errorf("cannot specify --all with --workspace or --repository flags")
+			}
+			if jobs < 1 {
+				return fmt.Errorf("--jobs must be at least 1")
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("a command is required, e.g. cm exec -- git pull")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runExecCommand(args, cm.ExecWorktreesOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+				All:            all,
+				Jobs:           jobs,
+			}, jsonOutput)
+		},
+	}
+
+	// Flags after the command belong to it
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Run the command in the worktrees of the specified workspace across all its repositories")
+	execCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Run the command in the worktrees of the specified repository (name from status.yaml or path)")
+	execCmd.Flags().BoolVarP(&all, "all", "a", false, "Run the command in the worktrees of every repository")
+	execCmd.Flags().IntVarP(&jobs, "jobs", "j", cm.DefaultExecJobs, "Number of commands run at the same time")
+	execCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the results and the output of the commands as JSON")
+
+	return execCmd
+}
+
+// runExecCommand executes the exec command logic.
+func runExecCommand(command []string, opts cm.ExecWorktreesOpts, jsonOutput bool) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	// The output is streamed unless it is part of the JSON results
+	if !jsonOutput {
+		opts.Output = os.Stdout
+	}
+
+	results, err := cmManager.ExecWorktrees(command, opts)
+	if err != nil && !errors.Is(err, cm.ErrExecIncomplete) {
+		return err
+	}
+
+	if jsonOutput {
+		if printErr := printExecResultsJSON(results); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No worktrees found.")
+		return nil
+	}
+
+	displayExecSummary(results)
+	return err
+}
+
+// displayExecSummary prints the outcome of the command in each worktree and the number of passed and failed runs.
+func displayExecSummary(results []cm.WorktreeExecResult) {
+	passed := 0
+	fmt.Println()
+	for _, result := range results {
+		switch {
+		case result.State == cm.WorktreeExecPassed:
+			passed++
+			fmt.Printf("  passed  %s (%s)\n", result.Branch, result.RepoURL)
+		case result.Message != "":
+			fmt.Printf("  failed  %s (%s): %s\n", result.Branch, result.RepoURL, result.Message)
+		default:
+			fmt.Printf("  failed  %s (%s): exit code %d\n", result.Branch, result.RepoURL, result.ExitCode)
+		}
+	}
+	fmt.Printf("%d passed, %d failed\n", passed, len(results)-passed)
+}
+
+// printExecResultsJSON prints the results as an indented JSON array.
+func printExecResultsJSON(results []cm.WorktreeExecResult) error {
+	if results == nil {
+		results = []cm.WorktreeExecResult{}
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createGCCmd() *cobra.Command {
+	var dryRun bool
+	var jsonOutput bool
+
+	gcCmd := &cobra.Command{
+		Use:   "gc [--dry-run] [--json]",
+		Short: "Delete expired ephemeral worktrees",
+		Long: `Delete the ephemeral worktrees (created with --ephemeral) whose TTL expired, in every repository.
+
+Expired worktrees with uncommitted changes are kept and reported, so that no work is lost:
+commit or discard the changes, then run gc again. Use --dry-run to only list the expired worktrees.
+
+Examples:
+  cm gc
+  cm gc --dry-run
+  cm gc --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runGCCommand(dryRun, jsonOutput)
+		},
+	}
+
+	gcCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the expired worktrees")
+	gcCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the expired worktrees as JSON")
+
+	return gcCmd
+}
+
+// runGCCommand executes the gc command logic.
+func runGCCommand(dryRun, jsonOutput bool) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	results, err := cmManager.GC(cm.GCOpts{DryRun: dryRun})
+	if err != nil && !errors.Is(err, cm.ErrGCIncomplete) {
+		return err
+	}
+
+	if jsonOutput {
+		if printErr := printGCResultsJSON(results); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No expired worktrees")
+		return nil
+	}
+
+	for _, result := range results {
+		displayGCResult(result)
+	}
+	return err
+}
+
+// displayGCResult prints what happened to an expired worktree.
+func displayGCResult(result cm.GCResult) {
+	switch result.State {
+	case cm.GCDeleted:
+		fmt.Printf("  deleted   %s (%s)\n", result.Branch, result.RepoURL)
+	case cm.GCExpired:
+		fmt.Printf("  expired   %s (%s), expired at %s\n", result.Branch, result.RepoURL,
+			result.ExpiredAt.Format("2006-01-02 15:04"))
+	case cm.GCDirty:
+		fmt.Printf("  warning   %s (%s) expired but has uncommitted changes, kept at %s\n",
+			result.Branch, result.RepoURL, result.Path)
+	default:
+		fmt.Printf("  failed    %s (%s): %s\n", result.Branch, result.RepoURL, result.Message)
+	}
+}
+
+// printGCResultsJSON prints the expired worktrees as an indented JSON array.
+func printGCResultsJSON(results []cm.GCResult) error {
+	if results == nil {
+		results = []cm.GCResult{}
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,22 @@
+// Package issue provides forge issue commands for the CM CLI.
+package issue
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// CreateIssueCmd creates the issue command with all its subcommands.
+func CreateIssueCmd() *cobra.Command {
+	issueCmd := &cobra.Command{
+		Use:     "issue",
+		Aliases: []string{"issues"},
+		Short:   "Forge issue commands",
+		Long:    `Commands for browsing the issues of a repository on its forge.`,
+	}
+
+	// Add issue subcommands
+	listCmd := createListCmd()
+	issueCmd.AddCommand(listCmd)
+
+	return issueCmd
+}
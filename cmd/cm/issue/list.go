@@ -0,0 +1,101 @@
+package issue
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createListCmd() *cobra.Command {
+	var opts cm.ListIssuesOpts
+	var jsonOutput bool
+
+	listCmd := &cobra.Command{
+		Use:     "list [--repository <repository-name>] [--mine] [--label <label>] [--milestone <milestone>] [--json]",
+		Aliases: []string{"ls", "l"},
+		Short:   "List open issues of a repository",
+		Long: `List the open issues of a repository on its forge, most recently updated first.
+
+Use --json for a machine-readable output suitable for scripts.
+
+Examples:
+  cm issue list
+  cm issue list --mine
+  cm issue list --label bug --label frontend --milestone v1.2
+  cm issue list --repository my-repo --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return listIssues(opts, jsonOutput)
+		},
+	}
+
+	listCmd.Flags().StringVarP(&opts.RepositoryName, "repository", "r", "",
+		"List issues of the specified repository (name from status.yaml or path)")
+	listCmd.Flags().BoolVar(&opts.AssignedToMe, "mine", false, "Only list issues assigned to you")
+	listCmd.Flags().StringSliceVar(&opts.Labels, "label", nil, "Only list issues with this label (can be repeated)")
+	listCmd.Flags().StringVar(&opts.Milestone, "milestone", "", "Only list issues in this milestone")
+	listCmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum number of issues to list (default 50)")
+	listCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output issues as JSON")
+
+	return listCmd
+}
+
+// listIssues handles the logic for listing the open issues of a repository.
+func listIssues(opts cm.ListIssuesOpts, jsonOutput bool) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	issues, err := cmManager.ListIssues(opts)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printIssuesJSON(issues)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No open issues found")
+		return nil
+	}
+
+	for _, info := range issues {
+		line := fmt.Sprintf("  #%d %s", info.Number, info.Title)
+		if len(info.Labels) > 0 {
+			line += fmt.Sprintf(" [%s]", strings.Join(info.Labels, ", "))
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// printIssuesJSON prints the issues as an indented JSON array.
+func printIssuesJSON(issues []issue.Info) error {
+	if issues == nil {
+		issues = []issue.Info{}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	"github.com/lerenn/code-manager/cmd/cm/issue"
 	"github.com/lerenn/code-manager/cmd/cm/repository"
 	"github.com/lerenn/code-manager/cmd/cm/workspace"
 	"github.com/lerenn/code-manager/cmd/cm/worktree"
@@ -28,13 +29,17 @@ func main() {
 	repositoryCmd := repository.CreateRepositoryCmd()
 	worktreeCmd := worktree.CreateWorktreeCmd()
 	workspaceCmd := workspace.CreateWorkspaceCmd()
+	issueCmd := issue.CreateIssueCmd()
 	initCmd := createInitCmd()
+	doctorCmd := createDoctorCmd()
+	gcCmd := createGCCmd()
+	execCmd := createExecCmd()
 
 	// Add initialization check to all commands except init
 	// Note: Individual subcommands will handle their own initialization checks
 
 	// Add subcommands
-	rootCmd.AddCommand(repositoryCmd, worktreeCmd, workspaceCmd, initCmd)
+	rootCmd.AddCommand(repositoryCmd, worktreeCmd, workspaceCmd, issueCmd, initCmd, doctorCmd, gcCmd, execCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,92 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createAnnotateCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+	var labels []string
+	var removeLabels []string
+	var note string
+
+	annotateCmd := &cobra.Command{
+		Use: "annotate [branch] [--label <label>] [--remove-label <label>] [--note <note>] " +
+			"[--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Set free-form labels and a note on a worktree",
+		Long: `Set free-form labels and a note on a worktree, stored in the status file, to remember what
+each worktree is for.
+
+Labels are added to the existing ones and compared ignoring case. The note replaces the current
+one, an empty note removes it. Labels are shown by "cm worktree list" and the interactive worktree
+selection, and "cm worktree list --label/--grep" filters on them and on the note.
+
+Examples:
+  cm worktree annotate feature-branch --label review --note "Waiting for the design review"
+  cm wt annotate feature-branch --label wip --label ui
+  cm worktree annotate feature-branch --remove-label wip
+  cm worktree annotate feature-branch --note ""      # Remove the note
+  cm worktree annotate                               # Interactive selection of repository/worktree`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			branchName := ""
+			if len(args) > 0 {
+				branchName = args[0]
+			}
+			opts := cm.AnnotateWorktreeOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+				AddLabels:      labels,
+				RemoveLabels:   removeLabels,
+			}
+			// An empty note removes the current one, so only a given note is set
+			if cmd.Flags().Changed("note") {
+				opts.Note = &note
+			}
+			return annotateWorktree(branchName, opts)
+		},
+	}
+
+	annotateCmd.Flags().StringSliceVar(&labels, "label", nil, "Add a label to the worktree (can be repeated)")
+	annotateCmd.Flags().StringSliceVar(&removeLabels, "remove-label", nil,
+		"Remove a label from the worktree (can be repeated)")
+	annotateCmd.Flags().StringVar(&note, "note", "", "Set the note of the worktree (empty to remove it)")
+	annotateCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Annotate the worktrees of the specified workspace across all its repositories")
+	annotateCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Annotate the worktree of the specified repository (name from status.yaml or path)")
+
+	return annotateCmd
+}
+
+// annotateWorktree handles the logic for annotating a worktree.
+func annotateWorktree(branchName string, opts cm.AnnotateWorktreeOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	if err := cmManager.AnnotateWorktree(branchName, opts); err != nil {
+		return fmt.Errorf("failed to annotate worktree: %w", err)
+	}
+
+	return nil
+}
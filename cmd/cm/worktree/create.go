@@ -3,6 +3,7 @@ package worktree
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
 	cm "github.com/lerenn/code-manager/pkg/code-manager"
@@ -15,19 +16,51 @@ func createCreateCmd() *cobra.Command {
 	var ideName string
 	var force bool
 	var fromIssue string
+	var fromPR string
 	var workspaceName string
 	var repositoryName string
+	var pickIssue bool
+	var allowClosed bool
+	var issueFilters cm.IssueFilters
+	var baseRef string
+	var carryChanges bool
+	var ephemeral bool
+	var ttl time.Duration
+	var sparse []string
+	var detach string
 
 	createCmd := &cobra.Command{
-		Use: "create [branch] [--from-issue <issue-reference>] [--ide <ide-name>] " +
-			"[--workspace <workspace-name>] [--repository <repository-name>]",
-		Short: "Create a worktree for the specified branch or from a GitHub issue",
+		Use: "create [branch] [--from-issue <issue-reference> [--allow-closed]] [--from-pr <pr-reference>] " +
+			"[--from <ref>] [--detach <ref>] [--carry-changes] [--ephemeral [--ttl <duration>]] [--sparse <dir>,...] " +
+			"[--ide <ide-name>] [--workspace <workspace-name>] [--repository <repository-name>] " +
+			"[--pick-issue [--mine] [--label <label>] [--milestone <milestone>]]",
+		Short: "Create a worktree for the specified branch or from a forge issue or pull request",
 		Long:  getCreateCommandLongDescription(),
-		Args:  createCreateCmdArgsValidator(&fromIssue, &workspaceName, &repositoryName),
+		Args: createCreateCmdArgsValidator(createCreateCmdArgsValidatorParams{
+			FromIssue:      &fromIssue,
+			FromPR:         &fromPR,
+			BaseRef:        &baseRef,
+			CarryChanges:   &carryChanges,
+			Detach:         &detach,
+			Ephemeral:      &ephemeral,
+			PickIssue:      &pickIssue,
+			WorkspaceName:  &workspaceName,
+			RepositoryName: &repositoryName,
+		}),
 		RunE: createCreateCmdRunE(createCreateCmdRunEParams{
 			IDEName:        &ideName,
 			Force:          &force,
 			FromIssue:      &fromIssue,
+			FromPR:         &fromPR,
+			PickIssue:      &pickIssue,
+			AllowClosed:    &allowClosed,
+			IssueFilters:   &issueFilters,
+			BaseRef:        &baseRef,
+			CarryChanges:   &carryChanges,
+			Ephemeral:      &ephemeral,
+			TTL:            &ttl,
+			Sparse:         &sparse,
+			Detach:         &detach,
 			WorkspaceName:  &workspaceName,
 			RepositoryName: &repositoryName,
 		}),
@@ -37,7 +70,31 @@ func createCreateCmd() *cobra.Command {
 	createCmd.Flags().StringVarP(&ideName, "ide", "i", "", "Open in specified IDE after creation")
 	createCmd.Flags().BoolVarP(&force, "force", "f", false, "Force creation without prompts")
 	createCmd.Flags().StringVar(&fromIssue, "from-issue", "",
-		"Create worktree from GitHub issue (URL, number, or owner/repo#issue format)")
+		"Create worktree from forge issue (URL, number, or owner/repo#issue format) or issue tracker key (PROJ-123)")
+	createCmd.Flags().BoolVar(&allowClosed, "allow-closed", false,
+		"Allow creating the worktree from a closed issue (with --from-issue)")
+	createCmd.Flags().StringVar(&fromPR, "from-pr", "",
+		"Create worktree from a pull/merge request head branch (URL, number, or owner/repo#number format)")
+	createCmd.Flags().StringVar(&baseRef, "from", "",
+		"Create the new branch from this ref (branch, tag, remote branch or commit SHA) instead of the default branch")
+	createCmd.Flags().StringVar(&detach, "detach", "",
+		"Check out this tag or commit on a detached HEAD instead of a branch")
+	createCmd.Flags().BoolVar(&carryChanges, "carry-changes", false,
+		"Move the staged, unstaged and untracked changes of the repository into the new worktree")
+	createCmd.Flags().BoolVar(&ephemeral, "ephemeral", false,
+		"Create a temporary worktree, deleted by 'cm gc' once its TTL expired")
+	createCmd.Flags().DurationVar(&ttl, "ttl", cm.DefaultEphemeralTTL,
+		"Lifetime of the ephemeral worktree (with --ephemeral)")
+	createCmd.Flags().StringSliceVar(&sparse, "sparse", nil,
+		"Only check out these directories (sparse-checkout), defaults to the repository sparse patterns in config")
+	createCmd.Flags().BoolVar(&pickIssue, "pick-issue", false,
+		"Interactively pick an open issue from the forge to create the worktree from")
+	createCmd.Flags().BoolVar(&issueFilters.AssignedToMe, "mine", false,
+		"Only offer issues assigned to you (with --pick-issue)")
+	createCmd.Flags().StringSliceVar(&issueFilters.Labels, "label", nil,
+		"Only offer issues with this label, can be repeated (with --pick-issue)")
+	createCmd.Flags().StringVar(&issueFilters.Milestone, "milestone", "",
+		"Only offer issues in this milestone (with --pick-issue)")
 	createCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
 		"Create worktrees from workspace definition in status.yaml (interactive selection if not provided)")
 	createCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
@@ -50,13 +107,50 @@ func createCreateCmd() *cobra.Command {
 func getCreateCommandLongDescription() string {
 	return `Create a worktree for the specified branch in the current repository or workspace.
 When using --from-issue, the branch name becomes optional and will be inferred from the issue title.
+Only open issues are accepted unless --allow-closed is given. Issues are cached on disk, so that
+a cached issue is used (and reported as stale) when the forge cannot be reached.
 When using --workspace, worktrees will be created in all repositories defined in the workspace.
 When using --repository, worktrees will be created in the specified repository.
 
 Issue Reference Formats:
   - GitHub issue URL: https://github.com/owner/repo/issues/123
-  - Issue number (requires remote origin to be GitHub): 123
-  - Owner/repo#issue format: owner/repo#123
+  - GitLab issue URL: https://gitlab.com/group/subgroup/project/-/issues/123
+  - Gitea/Forgejo issue URL: https://gitea.example.com/owner/repo/issues/123
+  - Issue number (requires remote origin to be a supported forge): 123
+  - Owner/repo#issue format: owner/repo#123 or group/subgroup/project#123
+  - Issue tracker key or browse URL (requires an issue_trackers configuration): PROJ-123
+
+Self-hosted GitLab, Gitea and Forgejo instances are supported when declared in the forges section of the configuration.
+
+When using --from-pr, the branch name is taken from the pull request head. Pull requests opened from
+a fork add a remote named after the fork owner, and the pull request number, URL and base branch
+are recorded with the worktree.
+
+When using --from, the new branch is created from the given branch, tag, remote branch or commit SHA
+instead of the default branch, and the base ref is recorded with the worktree. Branches only known by
+the remote (e.g. release/1.4) are created from their remote-tracking branch. The branch must not exist yet.
+
+When using --detach, the given tag or commit is checked out on a detached HEAD, without creating
+a branch. The worktree is named after the tag, or the short SHA of the commit, and is created under
+'detached/' in the repository directory. Detached worktrees are skipped by 'cm worktree sync' and
+'cm worktree prune'. Not supported with --workspace, nor with the options creating a branch.
+
+When using --carry-changes, the staged, unstaged and untracked changes of the repository are stashed
+and applied in the new worktree, leaving the repository clean. If they cannot be applied, the new
+worktree is deleted, along with the branch when it was created for it, and the changes are restored
+in the repository; the stash is only dropped once applied. Not supported with --workspace or --from-pr.
+
+When using --ephemeral, the worktree is recorded with its creation time and a TTL (--ttl, 48h by
+default). Once expired, 'cm gc' deletes it unless it has uncommitted changes. Not supported with
+--workspace.
+
+When using --sparse, only the given directories (and the files at the root of the repository) are
+checked out, with git sparse-checkout in cone mode. Repositories can define default directories with
+'sparse' in their section of the repositories configuration. The directories are recorded with the
+worktree and restored after 'cm worktree sync'.
+
+When using --pick-issue, the open issues of the repository are listed in a selector and the worktree
+is created from the chosen one. Use --mine, --label and --milestone to narrow down the list.
 
 Examples:
   cm worktree create feature-branch                    # Interactive selection of workspace/repository
@@ -65,32 +159,97 @@ Examples:
   cm worktree create --from-issue https://github.com/owner/repo/issues/123
   cm worktree create custom-branch --from-issue 456
   cm worktree create --from-issue owner/repo#789 --ide cursor
+  cm worktree create --from-issue PROJ-123
+  cm worktree create --from-issue 456 --allow-closed
   cm worktree create feature-branch --workspace my-workspace
   cm worktree create feature-branch --workspace my-workspace --ide cursor
   cm worktree create --from-issue 123 --workspace my-workspace
   cm worktree create feature-branch --repository my-repo
   cm worktree create feature-branch --repository /path/to/repo --ide cursor
-  cm worktree create --from-issue 123 --repository my-repo`
+  cm worktree create --from-issue 123 --repository my-repo
+  cm worktree create --from-pr https://github.com/owner/repo/pull/42
+  cm worktree create --from-pr 42 --repository my-repo
+  cm worktree create hotfix/login --from release/1.4
+  cm worktree create --from-issue 123 --from v1.4.2
+  cm worktree create --detach v2.3.1
+  cm worktree create --detach 1a2b3c4 --repository my-repo
+  cm worktree create feature-branch --carry-changes
+  cm worktree create --from-pr 42 --ephemeral
+  cm worktree create experiment --ephemeral --ttl 4h
+  cm worktree create feature-branch --sparse services/api,libs/common
+  cm worktree create --pick-issue --mine
+  cm worktree create --pick-issue --label bug --milestone v1.2 --repository my-repo`
+}
+
+// createCreateCmdArgsValidatorParams contains parameters for createCreateCmdArgsValidator.
+type createCreateCmdArgsValidatorParams struct {
+	FromIssue      *string
+	FromPR         *string
+	BaseRef        *string
+	CarryChanges   *bool
+	Detach         *string
+	Ephemeral      *bool
+	PickIssue      *bool
+	WorkspaceName  *string
+	RepositoryName *string
 }
 
 // createCreateCmdArgsValidator creates the argument validator for the create command.
-func createCreateCmdArgsValidator(
-	fromIssue *string,
-	workspaceName *string,
-	repositoryName *string,
-) func(*cobra.Command, []string) error {
+func createCreateCmdArgsValidator(params createCreateCmdArgsValidatorParams) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		// Validate that workspace and repository are not both specified
-		if *workspaceName != "" && *repositoryName != "" {
+		if *params.WorkspaceName != "" && *params.RepositoryName != "" {
 			return fmt.Errorf("cannot specify both --workspace and --repository flags")
 		}
+		if *params.CarryChanges && *params.WorkspaceName != "" {
+			return fmt.Errorf("cannot specify both --workspace and --carry-changes flags")
+		}
+		if *params.Ephemeral && *params.WorkspaceName != "" {
+			return fmt.Errorf("cannot specify both --workspace and --ephemeral flags")
+		}
+		if cmd.Flags().Changed("ttl") && !*params.Ephemeral {
+			return fmt.Errorf("--ttl can only be used with --ephemeral")
+		}
 
+		// If --detach is provided, there is no branch
+		if *params.Detach != "" {
+			if err := validateDetachFlags(cmd, params); err != nil {
+				return err
+			}
+			return cobra.NoArgs(cmd, args)
+		}
+		// If --from-pr is provided, the branch comes from the pull request
+		if *params.FromPR != "" {
+			if *params.FromIssue != "" {
+				return fmt.Errorf("cannot specify both --from-issue and --from-pr flags")
+			}
+			if *params.WorkspaceName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --from-pr flags")
+			}
+			if *params.BaseRef != "" {
+				return fmt.Errorf("cannot specify both --from and --from-pr flags")
+			}
+			if *params.CarryChanges {
+				return fmt.Errorf("cannot specify both --carry-changes and --from-pr flags")
+			}
+			return cobra.NoArgs(cmd, args)
+		}
+		// If --pick-issue is provided, the branch comes from the picked issue
+		if *params.PickIssue {
+			if *params.FromIssue != "" {
+				return fmt.Errorf("cannot specify both --from-issue and --pick-issue flags")
+			}
+			if *params.WorkspaceName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --pick-issue flags")
+			}
+			return cobra.NoArgs(cmd, args)
+		}
 		// If --from-issue is provided, branch name is optional
-		if *fromIssue != "" {
+		if *params.FromIssue != "" {
 			return cobra.MaximumNArgs(1)(cmd, args)
 		}
 		// If --workspace or --repository is provided, branch name is required
-		if *workspaceName != "" || *repositoryName != "" {
+		if *params.WorkspaceName != "" || *params.RepositoryName != "" {
 			return cobra.ExactArgs(1)(cmd, args)
 		}
 		// Otherwise, branch name is optional (interactive selection will handle target selection)
@@ -98,11 +257,40 @@ func createCreateCmdArgsValidator(
 	}
 }
 
+// validateDetachFlags checks that --detach is not combined with flags creating a branch.
+func validateDetachFlags(cmd *cobra.Command, params createCreateCmdArgsValidatorParams) error {
+	conflicting := map[string]bool{
+		"from-issue":    *params.FromIssue != "",
+		"from-pr":       *params.FromPR != "",
+		"pick-issue":    *params.PickIssue,
+		"from":          *params.BaseRef != "",
+		"carry-changes": *params.CarryChanges,
+		"sparse":        cmd.Flags().Changed("sparse"),
+		"workspace":     *params.WorkspaceName != "",
+	}
+	for _, flag := range []string{"from-issue", "from-pr", "pick-issue", "from", "carry-changes", "sparse", "workspace"} {
+		if conflicting[flag] {
+			return fmt.Errorf("cannot specify both --detach and --%s flags", flag)
+		}
+	}
+	return nil
+}
+
 // createCreateCmdRunEParams contains parameters for createCreateCmdRunE.
 type createCreateCmdRunEParams struct {
 	IDEName        *string
 	Force          *bool
 	FromIssue      *string
+	FromPR         *string
+	PickIssue      *bool
+	AllowClosed    *bool
+	IssueFilters   *cm.IssueFilters
+	BaseRef        *string
+	CarryChanges   *bool
+	Ephemeral      *bool
+	TTL            *time.Duration
+	Sparse         *[]string
+	Detach         *string
 	WorkspaceName  *string
 	RepositoryName *string
 }
@@ -135,12 +323,28 @@ func createCreateCmdRunE(params createCreateCmdRunEParams) func(*cobra.Command,
 		if *params.FromIssue != "" {
 			opts.IssueRef = *params.FromIssue
 		}
+		opts.AllowClosed = *params.AllowClosed
+		if *params.FromPR != "" {
+			opts.PullRequestRef = *params.FromPR
+		}
+		if *params.PickIssue {
+			opts.PickIssue = true
+			opts.IssueFilters = *params.IssueFilters
+		}
 		if *params.WorkspaceName != "" {
 			opts.WorkspaceName = *params.WorkspaceName
 		}
 		if *params.RepositoryName != "" {
 			opts.RepositoryName = *params.RepositoryName
 		}
+		opts.BaseRef = *params.BaseRef
+		opts.CarryChanges = *params.CarryChanges
+		if *params.Ephemeral {
+			opts.Ephemeral = true
+			opts.TTL = *params.TTL
+		}
+		opts.Sparse = *params.Sparse
+		opts.Detach = *params.Detach
 		opts.Force = *params.Force
 
 		return cmManager.CreateWorkTree(branchName, opts)
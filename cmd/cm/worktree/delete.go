@@ -36,6 +36,9 @@ or delete all worktrees using the --all flag. If no branch is specified,
 interactive selection will prompt you to choose a repository/workspace first,
 then select a specific worktree from that target.
 
+Locked worktrees (see "cm worktree lock") are kept by --all and can only be
+deleted one by one with --force.
+
 Examples:
   cm worktree delete                              # Two-step: select repository/workspace, then worktree
   cm worktree delete feature-branch               # One-step: select repository/workspace only
@@ -102,7 +105,7 @@ func createDeleteCmdRunE(
 }
 
 func addDeleteCmdFlags(cmd *cobra.Command, force *bool, workspaceName *string, repositoryName *string, all *bool) {
-	cmd.Flags().BoolVarP(force, "force", "f", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVarP(force, "force", "f", false, "Skip confirmation prompts and delete locked worktrees")
 	cmd.Flags().StringVarP(workspaceName, "workspace", "w", "",
 		"Name of the workspace to delete worktree from (interactive selection if not provided)")
 	cmd.Flags().StringVarP(repositoryName, "repository", "r", "",
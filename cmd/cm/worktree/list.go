@@ -2,10 +2,13 @@ package worktree
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
 	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
 	"github.com/spf13/cobra"
 )
@@ -15,13 +18,24 @@ const defaultRemote = "origin"
 func createListCmd() *cobra.Command {
 	var workspaceName string
 	var repositoryName string
+	var refresh bool
+	var labels []string
+	var milestone string
+	var grep string
 
 	listCmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [--label <label>] [--milestone <milestone>] [--grep <pattern>]",
 		Short: "List all worktrees for a workspace or repository",
 		Long:  getListCmdLongDescription(),
 		Args:  cobra.NoArgs,
-		RunE:  createListCmdRunE(&workspaceName, &repositoryName),
+		RunE: createListCmdRunE(createListCmdRunEParams{
+			WorkspaceName:  &workspaceName,
+			RepositoryName: &repositoryName,
+			Refresh:        &refresh,
+			Labels:         &labels,
+			Milestone:      &milestone,
+			Grep:           &grep,
+		}),
 	}
 
 	// Add workspace and repository flags to list command (optional)
@@ -29,6 +43,13 @@ func createListCmd() *cobra.Command {
 		"Name of the workspace to list worktrees for (interactive selection if not provided)")
 	listCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
 		"Name of the repository to list worktrees for (interactive selection if not provided)")
+	listCmd.Flags().BoolVar(&refresh, "refresh", false,
+		"Re-query the forge for the state of linked issues and pull requests")
+	listCmd.Flags().StringSliceVar(&labels, "label", nil,
+		"Only list worktrees with this label, set with annotate or on their issue (can be repeated)")
+	listCmd.Flags().StringVar(&milestone, "milestone", "", "Only list worktrees whose issue is in this milestone")
+	listCmd.Flags().StringVar(&grep, "grep", "",
+		"Only list worktrees whose branch, labels, note or issue title match this regular expression")
 
 	return listCmd
 }
@@ -36,28 +57,56 @@ func createListCmd() *cobra.Command {
 func getListCmdLongDescription() string {
 	return `List all worktrees for a specific workspace, repository, or current repository.
 
+Worktrees whose linked issue is closed or whose pull request is merged or closed are marked.
+Use --refresh to re-query the forge and update the state stored in the status file.
+Use --label to only list the worktrees with a label, set with "cm worktree annotate" or on their
+linked issue, and --milestone to only list the worktrees whose linked issue is in a milestone.
+Use --grep to only list the worktrees whose branch, labels, note or issue title match a regular
+expression (ignoring case).
+
 Examples:
   cm worktree list                    # Interactive selection of workspace/repository
   cm worktree list --workspace my-workspace  # List worktrees for specific workspace
   cm worktree list --repository my-repo      # List worktrees for specific repository
   cm wt list -w my-workspace
   cm w list
-  cm wt list -r /path/to/repo`
+  cm wt list -r /path/to/repo
+  cm worktree list --refresh                 # Update and show the state of linked issues and pull requests
+  cm worktree list --label bug --milestone v1.2  # Worktrees of bugs planned for v1.2
+  cm worktree list --label review --grep design  # Worktrees in review whose note mentions the design`
+}
+
+// createListCmdRunEParams contains parameters for createListCmdRunE.
+type createListCmdRunEParams struct {
+	WorkspaceName  *string
+	RepositoryName *string
+	Refresh        *bool
+	Labels         *[]string
+	Milestone      *string
+	Grep           *string
 }
 
-func createListCmdRunE(workspaceName, repositoryName *string) func(*cobra.Command, []string) error {
+func createListCmdRunE(params createListCmdRunEParams) func(*cobra.Command, []string) error {
 	return func(_ *cobra.Command, _ []string) error {
 		cmManager, err := initializeCMForList()
 		if err != nil {
 			return err
 		}
 
+		workspaceName, repositoryName := *params.WorkspaceName, *params.RepositoryName
+
 		// Validate that workspace and repository are not both specified
-		if *workspaceName != "" && *repositoryName != "" {
+		if workspaceName != "" && repositoryName != "" {
 			return fmt.Errorf("cannot specify both --workspace and --repository flags")
 		}
 
-		opts := buildListWorktreesOptions(*workspaceName, *repositoryName)
+		opts := buildListWorktreesOptions(workspaceName, repositoryName)
+		opts = append(opts, cm.ListWorktreesOpts{
+			Refresh:   *params.Refresh,
+			Labels:    *params.Labels,
+			Milestone: *params.Milestone,
+			Grep:      *params.Grep,
+		})
 
 		// List worktrees (interactive selection handled in code-manager)
 		worktrees, err := cmManager.ListWorktrees(opts...)
@@ -65,7 +114,7 @@ func createListCmdRunE(workspaceName, repositoryName *string) func(*cobra.Comman
 			return fmt.Errorf("failed to list worktrees: %w", err)
 		}
 
-		displayWorktrees(worktrees, *workspaceName, *repositoryName)
+		displayWorktrees(worktrees, workspaceName, repositoryName)
 		return nil
 	}
 }
@@ -121,11 +170,7 @@ func displayRepositoryWorktrees(worktrees []status.WorktreeInfo) {
 	fmt.Printf("Worktrees:\n")
 
 	for _, worktree := range worktrees {
-		remote := worktree.Remote
-		if remote == "" {
-			remote = defaultRemote
-		}
-		fmt.Printf("  [%s] %s\n", remote, worktree.Branch)
+		displayWorktree(worktree)
 	}
 }
 
@@ -140,11 +185,7 @@ func displayRepositoryWorktreesWithName(worktrees []status.WorktreeInfo, reposit
 
 	// Display worktrees in the format [remote] branch-name
 	for _, worktree := range worktrees {
-		remote := worktree.Remote
-		if remote == "" {
-			remote = defaultRemote
-		}
-		fmt.Printf("  [%s] %s\n", remote, worktree.Branch)
+		displayWorktree(worktree)
 	}
 }
 
@@ -159,10 +200,68 @@ func displayWorkspaceWorktrees(worktrees []status.WorktreeInfo, workspaceName st
 
 	// Display worktrees in the format [remote] branch-name
 	for _, worktree := range worktrees {
-		remote := worktree.Remote
-		if remote == "" {
-			remote = defaultRemote
+		displayWorktree(worktree)
+	}
+}
+
+// displayWorktree displays a worktree in the format [remote] branch-name [labels], followed by its lock and
+// forge state, and by its note on the next line.
+func displayWorktree(worktree status.WorktreeInfo) {
+	remote := worktree.Remote
+	if remote == "" {
+		remote = defaultRemote
+	}
+
+	var markers []string
+	if worktree.DetachedHead && !strings.HasPrefix(worktree.Commit, worktree.Branch) {
+		// Worktrees on a tag show the commit it pointed to when created
+		markers = append(markers, "at "+shortCommit(worktree.Commit))
+	}
+	switch {
+	case worktree.Locked && worktree.LockReason != "":
+		markers = append(markers, "locked: "+worktree.LockReason)
+	case worktree.Locked:
+		markers = append(markers, "locked")
+	}
+	markers = append(markers, forgeStateMarkers(worktree)...)
+
+	line := fmt.Sprintf("  [%s] %s", remote, worktree.Branch)
+	if len(worktree.Labels) > 0 {
+		line += fmt.Sprintf(" [%s]", strings.Join(worktree.Labels, ", "))
+	}
+	if len(markers) > 0 {
+		line += fmt.Sprintf(" (%s)", strings.Join(markers, ", "))
+	}
+	fmt.Println(line)
+	if worktree.Note != "" {
+		fmt.Printf("      %s\n", worktree.Note)
+	}
+}
+
+// shortCommit returns the abbreviated form of a commit SHA.
+func shortCommit(commit string) string {
+	const shortLength = 7
+	if len(commit) > shortLength {
+		return commit[:shortLength]
+	}
+	return commit
+}
+
+// forgeStateMarkers returns markers for linked issues and pull requests that are no longer open,
+// and for issues whose state could only be read from the issue cache.
+func forgeStateMarkers(worktree status.WorktreeInfo) []string {
+	var markers []string
+	if worktree.Issue != nil && worktree.Issue.State == issue.StateClosed {
+		markers = append(markers, fmt.Sprintf("issue %s closed", worktree.Issue.DisplayID()))
+	}
+	if worktree.Issue != nil && worktree.Issue.Stale {
+		markers = append(markers, fmt.Sprintf("issue %s stale", worktree.Issue.DisplayID()))
+	}
+	if worktree.PullRequest != nil {
+		switch worktree.PullRequest.State {
+		case pullrequest.StateMerged, pullrequest.StateClosed:
+			markers = append(markers, fmt.Sprintf("PR #%d %s", worktree.PullRequest.Number, worktree.PullRequest.State))
 		}
-		fmt.Printf("  [%s] %s\n", remote, worktree.Branch)
 	}
+	return markers
 }
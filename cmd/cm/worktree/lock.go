@@ -0,0 +1,79 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createLockCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+	var reason string
+
+	lockCmd := &cobra.Command{
+		Use:   "lock [branch] [--reason <reason>] [--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Lock a worktree to protect it from bulk deletions",
+		Long: `Lock a worktree, in git (git worktree lock) and in the status file.
+
+Locked worktrees are kept by "cm worktree delete --all", "cm worktree prune" and "cm gc".
+Deleting a locked worktree directly needs --force. Locking an already locked worktree
+updates its reason. When using --workspace, the worktrees of the branch are locked in all
+the repositories of the workspace.
+
+Examples:
+  cm worktree lock                                   # Interactive selection of repository/worktree
+  cm wt lock release-1.4 --reason "waiting for QA"
+  cm worktree lock feature-branch --workspace my-workspace
+  cm worktree lock feature-branch --repository my-repo`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			branchName := ""
+			if len(args) > 0 {
+				branchName = args[0]
+			}
+			return lockWorktree(branchName, cm.LockWorktreeOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+				Reason:         reason,
+			})
+		},
+	}
+
+	lockCmd.Flags().StringVar(&reason, "reason", "", "Why the worktree is locked")
+	lockCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Lock the worktrees of the specified workspace across all its repositories")
+	lockCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Lock the worktree of the specified repository (name from status.yaml or path)")
+
+	return lockCmd
+}
+
+// lockWorktree handles the logic for locking a worktree.
+func lockWorktree(branchName string, opts cm.LockWorktreeOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	if err := cmManager.LockWorktree(branchName, opts); err != nil {
+		return fmt.Errorf("failed to lock worktree: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createPRCmd() *cobra.Command {
+	prCmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Pull request commands for worktrees",
+		Long:  `Commands for managing the pull requests of worktrees.`,
+	}
+
+	prCmd.AddCommand(createPRCreateCmd())
+
+	return prCmd
+}
+
+func createPRCreateCmd() *cobra.Command {
+	var repositoryName string
+	var title string
+	var body string
+	var baseBranch string
+
+	createCmd := &cobra.Command{
+		Use:   "create [branch] [--repository <repository-name>] [--title <title>] [--body <body>] [--base <branch>]",
+		Short: "Push a worktree branch and open a pull request",
+		Long: `Push the worktree branch with upstream tracking and open a pull request on its forge.
+
+The title and body default from the issue linked to the worktree ("Closes #<number>",
+or "Related to <key> (<url>)" for issues of a tracker), and the pull request URL is stored in the status file.
+
+Examples:
+  cm worktree pr create                              # Interactive selection of repository/worktree
+  cm wt pr create 123-fix-login-bug
+  cm worktree pr create feature-branch --repository my-repo
+  cm worktree pr create feature-branch --title "Add feature" --base develop`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			branchName := ""
+			if len(args) > 0 {
+				branchName = args[0]
+			}
+			return createPullRequest(branchName, cm.CreatePullRequestOpts{
+				RepositoryName: repositoryName,
+				Title:          title,
+				Body:           body,
+				BaseBranch:     baseBranch,
+			})
+		},
+	}
+
+	createCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Open the pull request for the specified repository (name from status.yaml or path)")
+	createCmd.Flags().StringVar(&title, "title", "", "Pull request title (defaults to the linked issue title)")
+	createCmd.Flags().StringVar(&body, "body", "", "Pull request body (defaults to a reference to the linked issue)")
+	createCmd.Flags().StringVar(&baseBranch, "base", "", "Branch to merge into (defaults to the default branch)")
+
+	return createCmd
+}
+
+// createPullRequest handles the logic for opening a pull request from a worktree.
+func createPullRequest(branchName string, opts cm.CreatePullRequestOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	prInfo, err := cmManager.CreatePullRequest(branchName, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	fmt.Println(prInfo.URL)
+	return nil
+}
@@ -0,0 +1,107 @@
+package worktree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createPruneCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+	var idleAfter time.Duration
+	var force bool
+	var dryRun bool
+
+	pruneCmd := &cobra.Command{
+		Use: "prune [--idle <duration>] [--force] [--dry-run] " +
+			"[--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Delete merged, orphaned or idle worktrees",
+		Long: `Find the worktrees that are no longer needed and delete them:
+  - their branch is merged into its base (the ref it was created from, or the default branch),
+    including squash merges and pull requests merged on the forge
+  - their upstream branch was deleted from the remote
+  - they have had no commit for longer than --idle (disabled by default)
+
+Worktrees with uncommitted changes are never pruned, and the worktrees of a workspace are only
+pruned when their branch can be pruned in all its repositories. The plan is printed and confirmed before
+the worktrees are deleted, unless --force is given. Use --dry-run to only print the plan.
+
+Examples:
+  cm worktree prune                           # Interactive selection of workspace/repository
+  cm worktree prune --repository my-repo --dry-run
+  cm wt prune -w my-workspace --idle 720h
+  cm worktree prune -r my-repo --force`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			return pruneWorktrees(cm.PruneWorktreesOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+				IdleAfter:      idleAfter,
+			}, force, dryRun)
+		},
+	}
+
+	pruneCmd.Flags().DurationVar(&idleAfter, "idle", 0,
+		"Also prune worktrees without commits for longer than this duration (e.g. 720h)")
+	pruneCmd.Flags().BoolVarP(&force, "force", "f", false, "Delete the worktrees without confirmation")
+	pruneCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the worktrees that would be deleted")
+	pruneCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Prune the worktrees of the specified workspace (interactive selection if not provided)")
+	pruneCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Prune the worktrees of the specified repository (interactive selection if not provided)")
+
+	return pruneCmd
+}
+
+// pruneWorktrees handles the logic for printing the prune plan and deleting the planned worktrees.
+func pruneWorktrees(opts cm.PruneWorktreesOpts, force, dryRun bool) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	candidates, err := cmManager.PlanPruneWorktrees(opts)
+	if err != nil {
+		return fmt.Errorf("failed to find worktrees to prune: %w", err)
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No worktrees to prune.")
+		return nil
+	}
+
+	fmt.Println("Worktrees to prune:")
+	for _, candidate := range candidates {
+		remote := candidate.Worktree.Remote
+		if remote == "" {
+			remote = defaultRemote
+		}
+		fmt.Printf("  [%s] %s (%s): %s\n", remote, candidate.Worktree.Branch, candidate.RepoURL,
+			strings.Join(candidate.Reasons, ", "))
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := cmManager.PruneWorktrees(candidates, force); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+
+	fmt.Printf("Pruned %d worktrees.\n", len(candidates))
+	return nil
+}
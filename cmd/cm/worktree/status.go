@@ -0,0 +1,161 @@
+package worktree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createStatusCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+
+	statusCmd := &cobra.Command{
+		Use:   "status [--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Show the git state of the worktrees of a workspace or repository",
+		Long: `Show the git state of each worktree of a workspace or repository: its staged, modified and
+untracked files, how far it is ahead of and behind its upstream and its base (the ref it was created
+from, or the default branch of the remote), and its last commit. Worktrees whose directory is
+missing are flagged. Worktrees are queried concurrently.
+
+Examples:
+  cm worktree status                          # Interactive selection of workspace/repository
+  cm worktree status --workspace my-workspace
+  cm wt status -r my-repo`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			return showWorktreesStatus(cm.GetWorktreesStatusOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+			})
+		},
+	}
+
+	statusCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Name of the workspace to show worktrees for (interactive selection if not provided)")
+	statusCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Name of the repository to show worktrees for (interactive selection if not provided)")
+
+	return statusCmd
+}
+
+// showWorktreesStatus handles the logic for showing the git state of worktrees.
+func showWorktreesStatus(opts cm.GetWorktreesStatusOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	statuses, err := cmManager.GetWorktreesStatus(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get worktrees status: %w", err)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("No worktrees found.")
+		return nil
+	}
+
+	repoURL := ""
+	for _, worktreeStatus := range statuses {
+		if worktreeStatus.RepoURL != repoURL {
+			repoURL = worktreeStatus.RepoURL
+			fmt.Printf("Worktrees for repository '%s':\n", repoURL)
+		}
+		displayWorktreeStatus(worktreeStatus, time.Now())
+	}
+	return nil
+}
+
+// displayWorktreeStatus displays a worktree in the format [remote] branch-name, followed by its git state.
+func displayWorktreeStatus(worktreeStatus cm.WorktreeStatus, now time.Time) {
+	remote := worktreeStatus.Worktree.Remote
+	if remote == "" {
+		remote = defaultRemote
+	}
+
+	fmt.Printf("  [%s] %s\n", remote, worktreeStatus.Worktree.Branch)
+	if worktreeStatus.Missing {
+		fmt.Printf("      directory missing: %s\n", worktreeStatus.Path)
+		return
+	}
+
+	if changes := worktreeStatus.Changes; changes != nil {
+		fmt.Printf("      changes: %s\n", formatChanges(changes.Staged, changes.Modified, changes.Untracked))
+		if changes.Upstream != "" {
+			fmt.Printf("      upstream: %s (%d ahead, %d behind)\n", changes.Upstream, changes.Ahead, changes.Behind)
+		} else {
+			fmt.Println("      upstream: none")
+		}
+	}
+	if worktreeStatus.Base != "" {
+		fmt.Printf("      base: %s (%d ahead, %d behind)\n",
+			worktreeStatus.Base, worktreeStatus.AheadBase, worktreeStatus.BehindBase)
+	}
+	if commit := worktreeStatus.LastCommit; commit != nil {
+		fmt.Printf("      last commit: %s (%s)\n", commit.Subject, formatAge(now.Sub(commit.Date)))
+	}
+	for _, queryErr := range worktreeStatus.Errors {
+		fmt.Printf("      error: %s\n", queryErr)
+	}
+}
+
+// formatChanges formats the file counts of a worktree, or "clean" when it has no changes.
+func formatChanges(staged, modified, untracked int) string {
+	var parts []string
+	if staged > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", staged))
+	}
+	if modified > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", modified))
+	}
+	if untracked > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", untracked))
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatAge formats a duration as a rounded age, such as "3 hours ago".
+func formatAge(age time.Duration) string {
+	const day = 24 * time.Hour
+
+	var count int
+	var unit string
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		count, unit = int(age/time.Minute), "minute"
+	case age < day:
+		count, unit = int(age/time.Hour), "hour"
+	case age < 30*day:
+		count, unit = int(age/day), "day"
+	case age < 365*day:
+		count, unit = int(age/(30*day)), "month"
+	default:
+		count, unit = int(age/(365*day)), "year"
+	}
+
+	if count > 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", count, unit)
+}
@@ -0,0 +1,102 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createSyncCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+	var all bool
+	var merge bool
+
+	syncCmd := &cobra.Command{
+		Use:   "sync [branch|--all] [--merge] [--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Fetch the remote and rebase worktrees onto their base",
+		Long: `Fetch the remote and rebase each worktree onto its base: the ref it was created from (--from),
+or the default branch of the remote. Use --merge to merge the base instead of rebasing.
+
+Worktrees with uncommitted changes are skipped. A rebase or merge that conflicts is aborted,
+leaving the worktree as it was, and reported. When using --workspace, the worktrees of all the
+repositories of the workspace are synced.
+
+Examples:
+  cm worktree sync                                   # Interactive selection of repository/worktree
+  cm wt sync feature-branch
+  cm worktree sync --all
+  cm worktree sync --all --workspace my-workspace
+  cm worktree sync feature-branch --repository my-repo --merge`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			if all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			branchName := ""
+			if len(args) > 0 {
+				branchName = args[0]
+			}
+			return syncWorktrees(branchName, cm.SyncWorktreesOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+				All:            all,
+				Merge:          merge,
+			})
+		},
+	}
+
+	syncCmd.Flags().BoolVarP(&all, "all", "a", false, "Sync all worktrees of the repository or workspace")
+	syncCmd.Flags().BoolVar(&merge, "merge", false, "Merge the base into the worktrees instead of rebasing them")
+	syncCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Sync the worktrees of the specified workspace across all its repositories")
+	syncCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Sync the worktrees of the specified repository (name from status.yaml or path)")
+
+	return syncCmd
+}
+
+// syncWorktrees handles the logic for syncing worktrees and reports the outcome of each of them.
+func syncWorktrees(branchName string, opts cm.SyncWorktreesOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	results, err := cmManager.SyncWorktrees(branchName, opts)
+	for _, result := range results {
+		displaySyncResult(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sync worktrees: %w", err)
+	}
+
+	return nil
+}
+
+// displaySyncResult displays the outcome of a worktree sync in the format [state] repository branch.
+func displaySyncResult(result cm.WorktreeSyncResult) {
+	line := fmt.Sprintf("  [%s] %s %s", result.State, result.RepoURL, result.Branch)
+	if result.Base != "" {
+		line += fmt.Sprintf(" (onto %s)", result.Base)
+	}
+	if result.Message != "" {
+		line += ": " + result.Message
+	}
+	fmt.Println(line)
+}
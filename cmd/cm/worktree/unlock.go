@@ -0,0 +1,72 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/cmd/cm/internal/cli"
+	cm "github.com/lerenn/code-manager/pkg/code-manager"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func createUnlockCmd() *cobra.Command {
+	var workspaceName string
+	var repositoryName string
+
+	unlockCmd := &cobra.Command{
+		Use:   "unlock [branch] [--workspace <workspace-name>] [--repository <repository-name>]",
+		Short: "Unlock a worktree locked with cm worktree lock",
+		Long: `Unlock a worktree, in git (git worktree unlock) and in the status file, so that
+bulk deletions handle it again.
+
+Examples:
+  cm worktree unlock                                 # Interactive selection of repository/worktree
+  cm wt unlock release-1.4
+  cm worktree unlock feature-branch --workspace my-workspace
+  cm worktree unlock feature-branch --repository my-repo`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if workspaceName != "" && repositoryName != "" {
+				return fmt.Errorf("cannot specify both --workspace and --repository flags")
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			branchName := ""
+			if len(args) > 0 {
+				branchName = args[0]
+			}
+			return unlockWorktree(branchName, cm.UnlockWorktreeOpts{
+				WorkspaceName:  workspaceName,
+				RepositoryName: repositoryName,
+			})
+		},
+	}
+
+	unlockCmd.Flags().StringVarP(&workspaceName, "workspace", "w", "",
+		"Unlock the worktrees of the specified workspace across all its repositories")
+	unlockCmd.Flags().StringVarP(&repositoryName, "repository", "r", "",
+		"Unlock the worktree of the specified repository (name from status.yaml or path)")
+
+	return unlockCmd
+}
+
+// unlockWorktree handles the logic for unlocking a worktree.
+func unlockWorktree(branchName string, opts cm.UnlockWorktreeOpts) error {
+	if err := cli.CheckInitialization(); err != nil {
+		return err
+	}
+
+	cmManager, err := cli.NewCodeManager()
+	if err != nil {
+		return err
+	}
+	if cli.Verbose {
+		cmManager.SetLogger(logger.NewVerboseLogger())
+	}
+
+	if err := cmManager.UnlockWorktree(branchName, opts); err != nil {
+		return fmt.Errorf("failed to unlock worktree: %w", err)
+	}
+
+	return nil
+}
@@ -19,8 +19,16 @@ func CreateWorktreeCmd() *cobra.Command {
 	deleteCmd := createDeleteCmd()
 	listCmd := createListCmd()
 	loadCmd := createLoadCmd()
+	prCmd := createPRCmd()
+	syncCmd := createSyncCmd()
+	statusCmd := createStatusCmd()
+	pruneCmd := createPruneCmd()
+	lockCmd := createLockCmd()
+	unlockCmd := createUnlockCmd()
+	annotateCmd := createAnnotateCmd()
 
-	worktreeCmd.AddCommand(createCmd, openCmd, deleteCmd, listCmd, loadCmd)
+	worktreeCmd.AddCommand(createCmd, openCmd, deleteCmd, listCmd, loadCmd, prCmd, syncCmd, statusCmd, pruneCmd,
+		lockCmd, unlockCmd, annotateCmd)
 
 	return worktreeCmd
 }
@@ -0,0 +1,35 @@
+package branch
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// nameTemplateFuncs are the functions available in branch name templates.
+var nameTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseNameTemplate parses a branch name template (Go text/template syntax).
+func ParseNameTemplate(text string) (*template.Template, error) {
+	return template.New("branch_name").Funcs(nameTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// RenderNameTemplate renders a branch name template with data and sanitizes the result
+// according to Git's branch naming rules.
+func RenderNameTemplate(text string, data interface{}) (string, error) {
+	tmpl, err := ParseNameTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render branch name template: %w", err)
+	}
+
+	return SanitizeBranchName(strings.TrimSpace(buf.String()))
+}
@@ -0,0 +1,30 @@
+//go:build unit
+
+package branch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderNameTemplate(t *testing.T) {
+	data := struct {
+		Number int
+		Owner  string
+	}{Number: 42, Owner: "Octocat"}
+
+	name, err := RenderNameTemplate("{{lower .Owner}}/{{.Number}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "octocat/42", name)
+
+	_, err = RenderNameTemplate("{{.Number", data)
+	assert.Error(t, err)
+
+	_, err = RenderNameTemplate("{{.Missing}}", data)
+	assert.Error(t, err)
+
+	_, err = RenderNameTemplate("   ", data)
+	assert.ErrorIs(t, err, ErrBranchNameEmpty)
+}
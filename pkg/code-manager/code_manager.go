@@ -7,11 +7,15 @@ import (
 
 	"github.com/lerenn/code-manager/pkg/config"
 	"github.com/lerenn/code-manager/pkg/dependencies"
+	"github.com/lerenn/code-manager/pkg/forge"
 	"github.com/lerenn/code-manager/pkg/hooks"
+	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
 	"github.com/lerenn/code-manager/pkg/mode"
 	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/tracker"
 )
 
 // CodeManager interface provides Git repository detection functionality.
@@ -24,10 +28,30 @@ type CodeManager interface {
 	DeleteWorkTrees(branches []string, force bool) error
 	// DeleteAllWorktrees deletes all worktrees for the current repository or workspace.
 	DeleteAllWorktrees(force bool, opts ...DeleteAllWorktreesOpts) error
+	// PlanPruneWorktrees finds the merged, orphaned or idle worktrees of a workspace or repository.
+	PlanPruneWorktrees(opts ...PruneWorktreesOpts) ([]PruneCandidate, error)
+	// PruneWorktrees deletes the planned worktrees, after confirmation unless forced.
+	PruneWorktrees(candidates []PruneCandidate, force bool) error
+	// LockWorktree locks the worktrees of a branch so that bulk deletions keep them.
+	LockWorktree(branch string, opts ...LockWorktreeOpts) error
+	// UnlockWorktree unlocks the worktrees of a branch.
+	UnlockWorktree(branch string, opts ...UnlockWorktreeOpts) error
+	// AnnotateWorktree sets the free-form labels and note of the worktrees of a branch.
+	AnnotateWorktree(branch string, opts ...AnnotateWorktreeOpts) error
 	// OpenWorktree opens an existing worktree in the specified IDE.
 	OpenWorktree(worktreeName, ideName string, opts ...OpenWorktreeOpts) error
 	// ListWorktrees lists worktrees for a workspace or repository.
 	ListWorktrees(opts ...ListWorktreesOpts) ([]status.WorktreeInfo, error)
+	// GetWorktreesStatus asks git for the state of the worktrees of a workspace or repository.
+	GetWorktreesStatus(opts ...GetWorktreesStatusOpts) ([]WorktreeStatus, error)
+	// CreatePullRequest pushes a worktree branch and opens a pull request for it.
+	CreatePullRequest(branch string, opts ...CreatePullRequestOpts) (*pullrequest.Info, error)
+	// SyncWorktrees fetches the remote and rebases (or merges) worktrees onto their base.
+	SyncWorktrees(branch string, opts ...SyncWorktreesOpts) ([]WorktreeSyncResult, error)
+	// ExecWorktrees runs a command in the worktrees of a workspace, a repository, or all repositories.
+	ExecWorktrees(command []string, opts ...ExecWorktreesOpts) ([]WorktreeExecResult, error)
+	// ListIssues lists the open issues of a repository on its forge.
+	ListIssues(opts ...ListIssuesOpts) ([]issue.Info, error)
 	// LoadWorktree loads a branch from a remote source and creates a worktree.
 	LoadWorktree(branchArg string, opts ...LoadWorktreeOpts) error
 	// Init initializes CM configuration.
@@ -48,6 +72,10 @@ type CodeManager interface {
 	AddRepositoryToWorkspace(params *AddRepositoryToWorkspaceParams) error
 	// RemoveRepositoryFromWorkspace removes a repository from an existing workspace.
 	RemoveRepositoryFromWorkspace(params *RemoveRepositoryFromWorkspaceParams) error
+	// Doctor finds drift between the status file and git worktrees, and optionally fixes it.
+	Doctor(opts ...DoctorOpts) ([]DoctorIssue, error)
+	// GC deletes the expired ephemeral worktrees that have no uncommitted changes.
+	GC(opts ...GCOpts) ([]GCResult, error)
 	// SetLogger sets the logger for this CM instance.
 	SetLogger(logger logger.Logger)
 }
@@ -94,6 +122,30 @@ func (c *realCodeManager) getConfig() (config.Config, error) {
 	return c.deps.Config.GetConfigWithFallback()
 }
 
+// newForgeManager creates a forge manager configured with the forges declared in the configuration.
+func (c *realCodeManager) newForgeManager() (forge.ManagerInterface, error) {
+	cfg, err := c.getConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	if c.deps.ForgeProvider == nil {
+		return forge.NewManager(c.deps.Logger, c.deps.StatusManager, cfg), nil
+	}
+	return c.deps.ForgeProvider(c.deps.Logger, c.deps.StatusManager, cfg), nil
+}
+
+// newTrackerManager creates an issue tracker manager configured with the issue trackers declared in the configuration.
+func (c *realCodeManager) newTrackerManager() (tracker.ManagerInterface, error) {
+	cfg, err := c.getConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	if c.deps.TrackerProvider == nil {
+		return tracker.NewManager(c.deps.Logger, cfg), nil
+	}
+	return c.deps.TrackerProvider(c.deps.Logger, cfg), nil
+}
+
 // BuildWorktreePath constructs a worktree path from repository URL, remote name, and branch.
 func (c *realCodeManager) BuildWorktreePath(repoURL, remoteName, branch string) string {
 	// Get config from ConfigManager
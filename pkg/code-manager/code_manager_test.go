@@ -7,11 +7,76 @@ import (
 
 	"github.com/lerenn/code-manager/pkg/config"
 	"github.com/lerenn/code-manager/pkg/dependencies"
+	"github.com/lerenn/code-manager/pkg/forge"
+	forgemocks "github.com/lerenn/code-manager/pkg/forge/mocks"
+	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	hooksMocks "github.com/lerenn/code-manager/pkg/hooks/mocks"
+	"github.com/lerenn/code-manager/pkg/logger"
 	"github.com/lerenn/code-manager/pkg/mode/repository"
+	repositoryMocks "github.com/lerenn/code-manager/pkg/mode/repository/mocks"
 	"github.com/lerenn/code-manager/pkg/mode/workspace"
+	promptMocks "github.com/lerenn/code-manager/pkg/prompt/mocks"
+	"github.com/lerenn/code-manager/pkg/status"
+	statusMocks "github.com/lerenn/code-manager/pkg/status/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
+// testMocks holds the mocked dependencies of a code manager created with newTestCodeManager.
+type testMocks struct {
+	repository   *repositoryMocks.MockRepository
+	git          *gitmocks.MockGit
+	fs           *fsmocks.MockFS
+	status       *statusMocks.MockManager
+	prompt       *promptMocks.MockPrompter
+	hookManager  *hooksMocks.MockHookManagerInterface
+	forgeManager *forgemocks.MockManagerInterface
+	forge        *forgemocks.MockForge
+}
+
+// newTestCodeManager creates a code manager whose dependencies are mocks without any expectation.
+func newTestCodeManager(t *testing.T) (*realCodeManager, testMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := testMocks{
+		repository:   repositoryMocks.NewMockRepository(ctrl),
+		git:          gitmocks.NewMockGit(ctrl),
+		fs:           fsmocks.NewMockFS(ctrl),
+		status:       statusMocks.NewMockManager(ctrl),
+		prompt:       promptMocks.NewMockPrompter(ctrl),
+		hookManager:  hooksMocks.NewMockHookManagerInterface(ctrl),
+		forgeManager: forgemocks.NewMockManagerInterface(ctrl),
+		forge:        forgemocks.NewMockForge(ctrl),
+	}
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithRepositoryProvider(func(_ repository.NewRepositoryParams) repository.Repository {
+				return mocks.repository
+			}).
+			WithForgeProvider(func(_ logger.Logger, _ status.Manager, _ config.Config) forge.ManagerInterface {
+				return mocks.forgeManager
+			}).
+			WithHookManager(mocks.hookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithGit(mocks.git).
+			WithFS(mocks.fs).
+			WithStatusManager(mocks.status).
+			WithPrompt(mocks.prompt),
+	})
+	require.NoError(t, err)
+
+	return cm.(*realCodeManager), mocks
+}
+
+// expectHooks lets the pre, post and error hooks of the operation run any number of times.
+func expectHooks(mocks testMocks, operation string) {
+	mocks.hookManager.EXPECT().ExecutePreHooks(operation, gomock.Any()).Return(nil).AnyTimes()
+	mocks.hookManager.EXPECT().ExecutePostHooks(operation, gomock.Any()).Return(nil).AnyTimes()
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(operation, gomock.Any()).Return(nil).AnyTimes()
+}
+
 func TestNewCodeManager_WithProviders(t *testing.T) {
 	// Create test config manager
 	configManager := config.NewConfigManager("/test/config.yaml")
@@ -10,6 +10,17 @@ const (
 	LoadWorktree       = "LoadWorktree"
 	ListWorktrees      = "ListWorktrees"
 	OpenWorktree       = "OpenWorktree"
+	CreatePullRequest  = "CreatePullRequest"
+	SyncWorktrees      = "SyncWorktrees"
+	GetWorktreesStatus = "GetWorktreesStatus"
+	PruneWorktrees     = "PruneWorktrees"
+	LockWorktree       = "LockWorktree"
+	UnlockWorktree     = "UnlockWorktree"
+	AnnotateWorktree   = "AnnotateWorktree"
+	ExecWorktrees      = "ExecWorktrees"
+
+	// Issue operations.
+	ListIssues = "ListIssues"
 
 	// Repository operations.
 	CloneRepository  = "CloneRepository"
@@ -27,4 +38,8 @@ const (
 
 	// Initialization operations.
 	Init = "Init"
+
+	// Maintenance operations.
+	Doctor = "Doctor"
+	GC     = "GC"
 )
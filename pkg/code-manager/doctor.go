@@ -0,0 +1,329 @@
+package codemanager
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// DoctorIssueKind identifies a kind of drift between the status file and git.
+type DoctorIssueKind string
+
+const (
+	// DoctorMissingPath is a status entry whose worktree directory is gone.
+	DoctorMissingPath DoctorIssueKind = "missing-path"
+	// DoctorUnknownWorktree is a git worktree that is not in the status file.
+	DoctorUnknownWorktree DoctorIssueKind = "unknown-worktree"
+	// DoctorStaleAdminDir is a .git/worktrees entry whose worktree directory is gone.
+	DoctorStaleAdminDir DoctorIssueKind = "stale-admin-dir"
+	// DoctorDanglingWorkspaceRef is a workspace worktree reference without a matching worktree.
+	DoctorDanglingWorkspaceRef DoctorIssueKind = "dangling-workspace-ref"
+)
+
+// DoctorIssue is a problem found by Doctor.
+type DoctorIssue struct {
+	Kind        DoctorIssueKind `json:"kind"`
+	RepoURL     string          `json:"repository,omitempty"`
+	Workspace   string          `json:"workspace,omitempty"`
+	Branch      string          `json:"branch,omitempty"`
+	Path        string          `json:"path,omitempty"`
+	Description string          `json:"description"`
+	Fix         string          `json:"fix,omitempty"` // How the problem can be fixed, empty when it must be fixed by hand
+	Fixed       bool            `json:"fixed"`
+	Error       string          `json:"error,omitempty"` // Why the fix failed
+
+	remote   string
+	repoPath string
+}
+
+// DoctorOpts contains optional parameters for Doctor.
+type DoctorOpts struct {
+	Fix         bool // Fix every fixable problem without asking
+	Interactive bool // Ask before fixing each problem (ignored when Fix is set)
+}
+
+// Doctor compares the status file with `git worktree list` in every repository and with the workspaces.
+// It reports status entries whose directory is gone, git worktrees unknown to the status file,
+// stale worktree administrative files and workspace references to worktrees that do not exist.
+// Problems are fixed when Fix is set, or after confirmation in interactive mode.
+func (c *realCodeManager) Doctor(opts ...DoctorOpts) ([]DoctorIssue, error) {
+	// Parse options
+	options := c.extractDoctorOptions(opts)
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"fix":         options.Fix,
+		"interactive": options.Interactive,
+	}
+
+	// Execute with hooks
+	var issues []DoctorIssue
+	err := c.executeWithHooks(consts.Doctor, params, func() error {
+		var err error
+		issues, err = c.diagnose()
+		if err != nil {
+			return err
+		}
+		if !options.Fix && !options.Interactive {
+			return nil
+		}
+		return c.fixDoctorIssues(issues, options)
+	})
+	return issues, err
+}
+
+// diagnose finds the problems of all repositories and workspaces of the status file.
+func (c *realCodeManager) diagnose() ([]DoctorIssue, error) {
+	repositories, err := c.deps.StatusManager.ListRepositories()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToLoadRepositories, err)
+	}
+	workspaces, err := c.deps.StatusManager.ListWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var issues []DoctorIssue
+	for _, repoURL := range sortedKeys(repositories) {
+		issues = append(issues, c.diagnoseRepository(repoURL, repositories[repoURL])...)
+	}
+	for _, workspaceName := range sortedKeys(workspaces) {
+		issues = append(issues, diagnoseWorkspace(workspaceName, workspaces[workspaceName], repositories)...)
+	}
+
+	return issues, nil
+}
+
+// diagnoseRepository compares the worktrees of the status file with the ones known to git.
+func (c *realCodeManager) diagnoseRepository(repoURL string, repository status.Repository) []DoctorIssue {
+	var issues []DoctorIssue
+
+	knownPaths := make(map[string]bool)
+	for _, key := range sortedKeys(repository.Worktrees) {
+		worktree := repository.Worktrees[key]
+		worktreePath := c.BuildWorktreePath(repoURL, worktree.Remote, worktree.Branch)
+		knownPaths[normalizePath(worktreePath)] = true
+
+		if exists, err := c.deps.FS.Exists(worktreePath); err != nil || exists {
+			continue
+		}
+		issues = append(issues, DoctorIssue{
+			Kind:    DoctorMissingPath,
+			RepoURL: repoURL,
+			Branch:  worktree.Branch,
+			Path:    worktreePath,
+			Description: fmt.Sprintf("worktree %s of %s is in the status file but its directory is gone",
+				worktree.Branch, repoURL),
+			Fix: "remove the worktree from the status file",
+		})
+	}
+
+	if exists, err := c.deps.FS.Exists(repository.Path); err != nil || !exists {
+		c.VerbosePrint("Warning: skipping git checks of repository %s: directory not found: %s", repoURL, repository.Path)
+		return issues
+	}
+	entries, err := c.deps.Git.ListWorktrees(repository.Path)
+	if err != nil {
+		c.VerbosePrint("Warning: failed to list git worktrees of repository %s: %v", repoURL, err)
+		return issues
+	}
+
+	for _, entry := range entries {
+		if entry.Bare || normalizePath(entry.Path) == normalizePath(repository.Path) {
+			continue
+		}
+		if entry.Prunable {
+			issues = append(issues, DoctorIssue{
+				Kind:    DoctorStaleAdminDir,
+				RepoURL: repoURL,
+				Branch:  entry.Branch,
+				Path:    entry.Path,
+				Description: fmt.Sprintf("git still tracks the worktree %s of %s but its directory is gone",
+					entry.Path, repoURL),
+				Fix:      "run git worktree prune",
+				repoPath: repository.Path,
+			})
+			continue
+		}
+		if !knownPaths[normalizePath(entry.Path)] {
+			issues = append(issues, c.unknownWorktreeIssue(repoURL, repository.Path, entry))
+		}
+	}
+
+	return issues
+}
+
+// unknownWorktreeIssue reports a git worktree unknown to the status file. It can be added to
+// the status file when it has a branch and lives where CM would have created it.
+func (c *realCodeManager) unknownWorktreeIssue(repoURL, repoPath string, entry git.WorktreeEntry) DoctorIssue {
+	issue := DoctorIssue{
+		Kind:        DoctorUnknownWorktree,
+		RepoURL:     repoURL,
+		Branch:      entry.Branch,
+		Path:        entry.Path,
+		Description: fmt.Sprintf("git worktree %s of %s is not in the status file", entry.Path, repoURL),
+		repoPath:    repoPath,
+	}
+	if entry.Branch == "" {
+		return issue
+	}
+
+	// Worktrees are created in <repositories dir>/<repository URL>/<remote>/<branch>
+	worktreePath := normalizePath(entry.Path)
+	remotePath := strings.TrimSuffix(worktreePath, string(filepath.Separator)+filepath.FromSlash(entry.Branch))
+	remote := filepath.Base(remotePath)
+	if remotePath == worktreePath || normalizePath(c.BuildWorktreePath(repoURL, remote, entry.Branch)) != worktreePath {
+		return issue
+	}
+
+	issue.Fix = fmt.Sprintf("add the worktree to the status file with remote %s", remote)
+	issue.remote = remote
+	return issue
+}
+
+// diagnoseWorkspace reports the worktree references of the workspace that match no worktree
+// of its repositories.
+func diagnoseWorkspace(
+	workspaceName string, workspace status.Workspace, repositories map[string]status.Repository,
+) []DoctorIssue {
+	branches := make(map[string]bool)
+	for _, repoURL := range workspace.Repositories {
+		for _, worktree := range repositories[repoURL].Worktrees {
+			branches[worktree.Branch] = true
+		}
+	}
+
+	var issues []DoctorIssue
+	for _, branch := range workspace.Worktrees {
+		if branches[branch] {
+			continue
+		}
+		issues = append(issues, DoctorIssue{
+			Kind:      DoctorDanglingWorkspaceRef,
+			Workspace: workspaceName,
+			Branch:    branch,
+			Description: fmt.Sprintf("workspace %s references worktree %s but none of its repositories has it",
+				workspaceName, branch),
+			Fix: "remove the reference from the workspace",
+		})
+	}
+	return issues
+}
+
+// fixDoctorIssues fixes the fixable issues, asking for each of them in interactive mode.
+func (c *realCodeManager) fixDoctorIssues(issues []DoctorIssue, options DoctorOpts) error {
+	prunedRepositories := make(map[string]bool)
+	failed := false
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Fix == "" {
+			continue
+		}
+
+		// A single prune removes the administrative files of all the worktrees of a repository
+		if issue.Kind == DoctorStaleAdminDir && prunedRepositories[issue.repoPath] {
+			issue.Fixed = true
+			continue
+		}
+
+		if !options.Fix {
+			confirmed, err := c.deps.Prompt.PromptForConfirmation(
+				fmt.Sprintf("%s. Fix: %s?", issue.Description, issue.Fix), false)
+			if err != nil {
+				return fmt.Errorf("failed to get confirmation: %w", err)
+			}
+			if !confirmed {
+				continue
+			}
+		}
+
+		if err := c.fixDoctorIssue(*issue); err != nil {
+			c.VerbosePrint("Warning: failed to fix %s: %v", issue.Kind, err)
+			issue.Error = err.Error()
+			failed = true
+			continue
+		}
+		issue.Fixed = true
+		if issue.Kind == DoctorStaleAdminDir {
+			prunedRepositories[issue.repoPath] = true
+		}
+	}
+
+	if failed {
+		return ErrDoctorFixIncomplete
+	}
+	return nil
+}
+
+// fixDoctorIssue applies the fix of a single issue.
+func (c *realCodeManager) fixDoctorIssue(issue DoctorIssue) error {
+	switch issue.Kind {
+	case DoctorMissingPath:
+		return c.deps.StatusManager.RemoveWorktree(issue.RepoURL, issue.Branch)
+	case DoctorUnknownWorktree:
+		return c.deps.StatusManager.AddWorktree(status.AddWorktreeParams{
+			RepoURL:      issue.RepoURL,
+			Branch:       issue.Branch,
+			WorktreePath: issue.Path,
+			Remote:       issue.remote,
+		})
+	case DoctorStaleAdminDir:
+		return c.deps.Git.PruneWorktreeInfo(issue.repoPath)
+	case DoctorDanglingWorkspaceRef:
+		workspace, err := c.deps.StatusManager.GetWorkspace(issue.Workspace)
+		if err != nil {
+			return fmt.Errorf("failed to get workspace %s: %w", issue.Workspace, err)
+		}
+		var worktrees []string
+		for _, branch := range workspace.Worktrees {
+			if branch != issue.Branch {
+				worktrees = append(worktrees, branch)
+			}
+		}
+		workspace.Worktrees = worktrees
+		return c.deps.StatusManager.UpdateWorkspace(issue.Workspace, *workspace)
+	default:
+		return fmt.Errorf("no fix for %s", issue.Kind)
+	}
+}
+
+// normalizePath cleans the path and resolves its symlinks when it exists, so that paths
+// reported by git can be compared with the ones built by CM.
+func normalizePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// extractDoctorOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractDoctorOptions(opts []DoctorOpts) DoctorOpts {
+	var result DoctorOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.Fix {
+			result.Fix = true
+		}
+		if opt.Interactive {
+			result.Interactive = true
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,145 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_Doctor_Fix(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.Doctor)
+	repoURL := "github.com/o/r"
+	gonePath := cm.BuildWorktreePath(repoURL, "origin", "gone")
+	presentPath := cm.BuildWorktreePath(repoURL, "origin", "present")
+	adoptedPath := cm.BuildWorktreePath(repoURL, "upstream", "feature/adopted")
+
+	mocks.status.EXPECT().ListRepositories().Return(map[string]status.Repository{
+		repoURL: {
+			Path: "/test/repo",
+			Worktrees: map[string]status.WorktreeInfo{
+				"origin:gone":    {Remote: "origin", Branch: "gone"},
+				"origin:present": {Remote: "origin", Branch: "present"},
+			},
+		},
+	}, nil)
+	mocks.status.EXPECT().ListWorkspaces().Return(map[string]status.Workspace{
+		"my-workspace": {Repositories: []string{repoURL}, Worktrees: []string{"present", "vanished"}},
+	}, nil)
+
+	mocks.fs.EXPECT().Exists(gonePath).Return(false, nil)
+	mocks.fs.EXPECT().Exists(presentPath).Return(true, nil)
+	mocks.fs.EXPECT().Exists("/test/repo").Return(true, nil)
+	mocks.git.EXPECT().ListWorktrees("/test/repo").Return([]git.WorktreeEntry{
+		{Path: "/test/repo", Branch: "main"},
+		{Path: presentPath, Branch: "present"},
+		{Path: gonePath, Branch: "gone", Prunable: true},
+		{Path: adoptedPath, Branch: "feature/adopted"},
+		{Path: "/elsewhere/hack", Branch: "hack"},
+	}, nil)
+
+	// Every fixable problem is fixed without asking
+	mocks.status.EXPECT().RemoveWorktree(repoURL, "gone").Return(nil)
+	mocks.git.EXPECT().PruneWorktreeInfo("/test/repo").Return(nil)
+	mocks.status.EXPECT().AddWorktree(status.AddWorktreeParams{
+		RepoURL:      repoURL,
+		Branch:       "feature/adopted",
+		WorktreePath: adoptedPath,
+		Remote:       "upstream",
+	}).Return(nil)
+	mocks.status.EXPECT().GetWorkspace("my-workspace").Return(&status.Workspace{
+		Repositories: []string{repoURL},
+		Worktrees:    []string{"present", "vanished"},
+	}, nil)
+	mocks.status.EXPECT().UpdateWorkspace("my-workspace", status.Workspace{
+		Repositories: []string{repoURL},
+		Worktrees:    []string{"present"},
+	}).Return(nil)
+	mocks.prompt.EXPECT().PromptForConfirmation(gomock.Any(), gomock.Any()).Times(0)
+
+	issues, err := cm.Doctor(DoctorOpts{Fix: true})
+	require.NoError(t, err)
+
+	type summary struct {
+		Kind   DoctorIssueKind
+		Branch string
+		Fixed  bool
+	}
+	var summaries []summary
+	for _, issue := range issues {
+		summaries = append(summaries, summary{issue.Kind, issue.Branch, issue.Fixed})
+	}
+	assert.Equal(t, []summary{
+		{DoctorMissingPath, "gone", true},
+		{DoctorStaleAdminDir, "gone", true},
+		{DoctorUnknownWorktree, "feature/adopted", true},
+		{DoctorUnknownWorktree, "hack", false}, // Not where CM creates worktrees, left to the user
+		{DoctorDanglingWorkspaceRef, "vanished", true},
+	}, summaries)
+	assert.Empty(t, issues[3].Fix)
+}
+
+func TestCM_Doctor_Interactive(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.Doctor)
+	repoURL := "github.com/o/r"
+
+	mocks.status.EXPECT().ListRepositories().Return(map[string]status.Repository{
+		repoURL: {
+			Path:      "/test/repo",
+			Worktrees: map[string]status.WorktreeInfo{"origin:gone": {Remote: "origin", Branch: "gone"}},
+		},
+	}, nil)
+	mocks.status.EXPECT().ListWorkspaces().Return(map[string]status.Workspace{
+		"my-workspace": {Repositories: []string{repoURL}, Worktrees: []string{"vanished"}},
+	}, nil)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(false, nil).Times(2)
+
+	// The user accepts the first fix, which fails, and declines the second one
+	isAbout := func(subject string) gomock.Matcher {
+		return gomock.Cond(func(msg any) bool { return strings.Contains(msg.(string), subject) })
+	}
+	mocks.prompt.EXPECT().PromptForConfirmation(isAbout("gone"), false).Return(true, nil)
+	mocks.status.EXPECT().RemoveWorktree(repoURL, "gone").Return(errors.New("status locked"))
+	mocks.prompt.EXPECT().PromptForConfirmation(isAbout("vanished"), false).Return(false, nil)
+	mocks.status.EXPECT().UpdateWorkspace(gomock.Any(), gomock.Any()).Times(0)
+
+	issues, err := cm.Doctor(DoctorOpts{Interactive: true})
+	assert.ErrorIs(t, err, ErrDoctorFixIncomplete)
+	require.Len(t, issues, 2)
+	assert.False(t, issues[0].Fixed)
+	assert.Equal(t, "status locked", issues[0].Error)
+	assert.False(t, issues[1].Fixed)
+	assert.Empty(t, issues[1].Error)
+}
+
+func TestCM_Doctor_ReportOnly(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.Doctor)
+
+	mocks.status.EXPECT().ListRepositories().Return(map[string]status.Repository{
+		"github.com/o/r": {Path: "/test/repo"},
+	}, nil)
+	mocks.status.EXPECT().ListWorkspaces().Return(nil, nil)
+	mocks.fs.EXPECT().Exists("/test/repo").Return(true, nil)
+	mocks.git.EXPECT().ListWorktrees("/test/repo").Return([]git.WorktreeEntry{
+		{Path: "/test/repo", Branch: "main"},
+		{Path: "/test/stale", Branch: "stale", Prunable: true},
+	}, nil)
+	mocks.git.EXPECT().PruneWorktreeInfo(gomock.Any()).Times(0)
+
+	issues, err := cm.Doctor()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, DoctorStaleAdminDir, issues[0].Kind)
+	assert.False(t, issues[0].Fixed)
+}
@@ -19,9 +19,56 @@ var (
 	ErrRepositoryNotClean = errors.New("repository is not in a clean state")
 	ErrDirectoryExists    = errors.New("worktree directory already exists")
 
+	// Pull request errors.
+	ErrIssueAndPullRequestExclusive     = errors.New("cannot create a worktree from both an issue and a pull request")
+	ErrBranchWithPullRequest            = errors.New("branch name cannot be specified with a pull request")
+	ErrPullRequestWorkspaceNotSupported = errors.New("workspace mode not supported for pull requests")
+	ErrBaseRefWithPullRequest           = errors.New("base ref cannot be specified with a pull request")
+	ErrPullRequestAlreadyExists         = errors.New("worktree already has a pull request")
+
+	// Issue selection errors.
+	ErrPickIssueWithIssueOrPullRequest = errors.New("cannot pick an issue when an issue or pull request is given")
+	ErrBranchWithPickIssue             = errors.New("branch name cannot be specified when picking an issue")
+	ErrPickIssueWorkspaceNotSupported  = errors.New("workspace mode not supported when picking an issue")
+
+	// Carried changes errors.
+	ErrCarryChangesWithPullRequest       = errors.New("changes cannot be carried into a pull request worktree")
+	ErrCarryChangesWorkspaceNotSupported = errors.New("workspace mode not supported when carrying changes")
+
+	// Detached worktree errors.
+	ErrBranchWithDetach             = errors.New("branch name cannot be specified with a detached worktree")
+	ErrDetachWithIssueOrPullRequest = errors.New("cannot create a detached worktree from an issue or pull request")
+	ErrDetachWithBranchOptions      = errors.New("base ref, carried changes and sparse directories need a branch")
+	ErrDetachWorkspaceNotSupported  = errors.New("workspace mode not supported for detached worktrees")
+	ErrRefNotFound                  = errors.New("ref not found")
+
+	// Ephemeral worktree errors.
+	ErrTTLWithoutEphemeral            = errors.New("a TTL can only be given to ephemeral worktrees")
+	ErrInvalidTTL                     = errors.New("invalid TTL")
+	ErrEphemeralWorkspaceNotSupported = errors.New("workspace mode not supported for ephemeral worktrees")
+	ErrGCIncomplete                   = errors.New("some expired worktrees could not be deleted")
+
 	// Worktree deletion errors.
 	ErrWorktreeNotInStatus = errors.New("worktree not found in status file")
 	ErrDeletionCancelled   = errors.New("deletion cancelled by user")
+	ErrWorktreeLocked      = errors.New("worktree is locked, use force to delete it")
+
+	// Worktree annotation errors.
+	ErrNothingToAnnotate  = errors.New("a label or a note is required to annotate a worktree")
+	ErrInvalidGrepPattern = errors.New("invalid grep pattern")
+
+	// Worktree sync errors.
+	ErrSyncBranchWithAll      = errors.New("branch name cannot be specified when syncing all worktrees")
+	ErrWorktreeSyncIncomplete = errors.New("some worktrees could not be synced")
+
+	// Worktree command errors.
+	ErrExecNoCommand     = errors.New("a command is required")
+	ErrExecAllWithTarget = errors.New("cannot run in all worktrees and in a workspace or repository")
+	ErrInvalidExecJobs   = errors.New("invalid number of jobs")
+	ErrExecIncomplete    = errors.New("the command failed in some worktrees")
+
+	// Doctor errors.
+	ErrDoctorFixIncomplete = errors.New("some problems could not be fixed")
 
 	// Load branch errors.
 	ErrBranchNameContainsColon  = errors.New("branch name contains invalid character ':'")
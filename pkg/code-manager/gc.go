@@ -0,0 +1,147 @@
+package codemanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// GCState is the outcome of garbage collecting an expired ephemeral worktree.
+type GCState string
+
+// Garbage collection outcomes.
+const (
+	GCDeleted GCState = "deleted"
+	GCExpired GCState = "expired" // Would be deleted, on a dry run
+	GCDirty   GCState = "dirty"   // Kept because of uncommitted changes
+	GCFailed  GCState = "failed"
+)
+
+// GCResult reports what happened to an expired ephemeral worktree.
+type GCResult struct {
+	RepoURL   string    `json:"repository"`
+	Branch    string    `json:"branch"`
+	Path      string    `json:"path"`
+	ExpiredAt time.Time `json:"expired_at"`
+	State     GCState   `json:"state"`
+	Message   string    `json:"message,omitempty"` // Reason of a kept or failed deletion
+}
+
+// GCOpts contains optional parameters for GC.
+type GCOpts struct {
+	DryRun bool // Only report the expired worktrees
+}
+
+// GC deletes the expired ephemeral worktrees of every repository through DeleteWorkTree, skipping locked ones.
+// Expired worktrees with uncommitted changes are kept and reported as dirty. The results of all
+// expired worktrees are returned, along with ErrGCIncomplete when some of them could not be deleted.
+func (c *realCodeManager) GC(opts ...GCOpts) ([]GCResult, error) {
+	// Parse options
+	options := c.extractGCOptions(opts)
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"dry_run": options.DryRun,
+	}
+
+	// Execute with hooks
+	var results []GCResult
+	err := c.executeWithHooks(consts.GC, params, func() error {
+		repositories, err := c.deps.StatusManager.ListRepositories()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailedToLoadRepositories, err)
+		}
+
+		now := time.Now()
+		for _, repoURL := range sortedKeys(repositories) {
+			repository := repositories[repoURL]
+			for _, key := range sortedKeys(repository.Worktrees) {
+				worktree := repository.Worktrees[key]
+				if worktree.Ephemeral == nil || now.Before(worktree.Ephemeral.ExpiresAt()) {
+					continue
+				}
+				if worktree.Locked {
+					c.VerbosePrint("Skipping expired worktree %s of %s: locked", worktree.Branch, repoURL)
+					continue
+				}
+				results = append(results, c.collectWorktree(repoURL, repository, worktree, options.DryRun))
+			}
+		}
+
+		return gcResultsError(results)
+	})
+	return results, err
+}
+
+// collectWorktree deletes an expired ephemeral worktree unless it has uncommitted changes.
+func (c *realCodeManager) collectWorktree(
+	repoURL string, repository status.Repository, worktree status.WorktreeInfo, dryRun bool,
+) GCResult {
+	result := GCResult{
+		RepoURL:   repoURL,
+		Branch:    worktree.Branch,
+		Path:      c.BuildWorktreePath(repoURL, worktree.Remote, worktree.Branch),
+		ExpiredAt: worktree.Ephemeral.ExpiresAt(),
+	}
+
+	// Worktrees whose directory is gone have nothing to lose
+	if exists, err := c.deps.FS.Exists(result.Path); err == nil && exists {
+		changes, err := c.deps.Git.GetWorkingTreeStatus(result.Path)
+		if err != nil {
+			return result.withState(GCFailed, fmt.Sprintf("failed to get working tree status: %v", err))
+		}
+		if changes.HasChanges() {
+			c.VerbosePrint("Warning: keeping expired worktree %s of %s: uncommitted changes", worktree.Branch, repoURL)
+			return result.withState(GCDirty, "uncommitted changes")
+		}
+	}
+
+	if dryRun {
+		return result.withState(GCExpired, "")
+	}
+
+	c.VerbosePrint("Deleting expired worktree %s of repository %s", worktree.Branch, repoURL)
+	// The worktree is clean and was created to be deleted once expired
+	if err := c.DeleteWorkTree(worktree.Branch, true,
+		DeleteWorktreeOpts{RepositoryName: repository.Path}); err != nil {
+		return result.withState(GCFailed, err.Error())
+	}
+	return result.withState(GCDeleted, "")
+}
+
+// withState sets the state and message of the result and returns it.
+func (r GCResult) withState(state GCState, message string) GCResult {
+	r.State = state
+	r.Message = message
+	return r
+}
+
+// gcResultsError returns ErrGCIncomplete when some expired worktrees failed to be deleted.
+func gcResultsError(results []GCResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.State == GCFailed {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d worktrees", ErrGCIncomplete, failed, len(results))
+}
+
+// extractGCOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractGCOptions(opts []GCOpts) GCOpts {
+	var result GCOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.DryRun {
+			result.DryRun = opt.DryRun
+		}
+	}
+
+	return result
+}
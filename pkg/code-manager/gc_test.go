@@ -0,0 +1,104 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func gcTestRepositories() map[string]status.Repository {
+	expired := &status.Ephemeral{CreatedAt: time.Now().Add(-72 * time.Hour), TTL: 48 * time.Hour}
+	return map[string]status.Repository{
+		"github.com/o/r": {
+			Path: "/test/repo",
+			Worktrees: map[string]status.WorktreeInfo{
+				"origin:clean":      {Remote: "origin", Branch: "clean", Ephemeral: expired},
+				"origin:dirty":      {Remote: "origin", Branch: "dirty", Ephemeral: expired},
+				"origin:locked":     {Remote: "origin", Branch: "locked", Ephemeral: expired, Locked: true},
+				"origin:persistent": {Remote: "origin", Branch: "persistent"},
+				"origin:fresh": {Remote: "origin", Branch: "fresh", Ephemeral: &status.Ephemeral{
+					CreatedAt: time.Now().Add(-time.Hour), TTL: 48 * time.Hour,
+				}},
+			},
+		},
+	}
+}
+
+func TestCM_GC(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	cleanPath := cm.BuildWorktreePath("github.com/o/r", "origin", "clean")
+	dirtyPath := cm.BuildWorktreePath("github.com/o/r", "origin", "dirty")
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.status.EXPECT().ListRepositories().Return(gcTestRepositories(), nil)
+
+	// Only the expired worktrees that are not locked are checked, the dirty one is kept
+	mocks.fs.EXPECT().Exists(cleanPath).Return(true, nil)
+	mocks.fs.EXPECT().Exists(dirtyPath).Return(true, nil)
+	mocks.git.EXPECT().GetWorkingTreeStatus(cleanPath).Return(&git.WorkingTreeStatus{}, nil)
+	mocks.git.EXPECT().GetWorkingTreeStatus(dirtyPath).Return(&git.WorkingTreeStatus{Modified: 1}, nil)
+
+	// The clean one goes through the deletion with its own hooks
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil)
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().DeleteWorktree("clean", true).Return(nil)
+
+	results, err := cm.GC()
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "clean", results[0].Branch)
+	assert.Equal(t, GCDeleted, results[0].State)
+	assert.Equal(t, "dirty", results[1].Branch)
+	assert.Equal(t, GCDirty, results[1].State)
+}
+
+func TestCM_GC_DryRun(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.status.EXPECT().ListRepositories().Return(gcTestRepositories(), nil)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).Times(2)
+	mocks.git.EXPECT().GetWorkingTreeStatus(gomock.Any()).Return(&git.WorkingTreeStatus{}, nil).Times(2)
+	mocks.repository.EXPECT().DeleteWorktree(gomock.Any(), gomock.Any()).Times(0)
+
+	results, err := cm.GC(GCOpts{DryRun: true})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, GCExpired, result.State)
+	}
+}
+
+func TestCM_GC_DeletionFailure(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(consts.GC, gomock.Any()).Return(nil)
+	mocks.status.EXPECT().ListRepositories().Return(gcTestRepositories(), nil)
+
+	// Worktrees whose directory is gone are deleted without checking them
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(false, nil).Times(2)
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil).Times(2)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil)
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil).Times(2)
+	mocks.repository.EXPECT().DeleteWorktree("clean", true).Return(nil)
+	mocks.repository.EXPECT().DeleteWorktree("dirty", true).Return(errors.New("boom"))
+
+	results, err := cm.GC()
+	assert.ErrorIs(t, err, ErrGCIncomplete)
+	assert.Len(t, results, 2)
+	assert.Equal(t, GCDeleted, results[0].State)
+	assert.Equal(t, GCFailed, results[1].State)
+}
@@ -0,0 +1,115 @@
+package codemanager
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/issue"
+	repo "github.com/lerenn/code-manager/pkg/mode/repository"
+)
+
+// IssueFilters restricts the open issues returned by a forge.
+type IssueFilters struct {
+	AssignedToMe bool     // Only list issues assigned to the authenticated user
+	Labels       []string // Only list issues carrying all of these labels
+	Milestone    string   // Only list issues in this milestone
+	Limit        int      // Maximum number of issues (defaults to forge.DefaultIssueListLimit)
+}
+
+// ListIssuesOpts contains optional parameters for ListIssues.
+type ListIssuesOpts struct {
+	RepositoryName string // Name of the repository to list issues for (defaults to the current directory)
+	IssueFilters
+}
+
+// ListIssues lists the open issues of a repository on its forge.
+func (c *realCodeManager) ListIssues(opts ...ListIssuesOpts) ([]issue.Info, error) {
+	// Parse options
+	var options ListIssuesOpts
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"repository_name": options.RepositoryName,
+		"assigned_to_me":  options.AssignedToMe,
+		"labels":          options.Labels,
+		"milestone":       options.Milestone,
+	}
+
+	// Execute with hooks
+	var issues []issue.Info
+	err := c.executeWithHooks(consts.ListIssues, params, func() error {
+		var err error
+		issues, err = c.listIssues(options.RepositoryName, options.IssueFilters)
+		return err
+	})
+	return issues, err
+}
+
+// listIssues queries the forge of the repository for open issues matching the filters.
+func (c *realCodeManager) listIssues(repositoryName string, filters IssueFilters) ([]issue.Info, error) {
+	c.VerbosePrint("Listing open issues for repository: %s", repositoryName)
+
+	repoInstance := c.deps.RepositoryProvider(repo.NewRepositoryParams{
+		Dependencies:   c.deps,
+		RepositoryName: repositoryName,
+	})
+
+	// Validate repository and get its path
+	validationResult, err := repoInstance.ValidateRepository(repo.ValidationParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate repository: %w", err)
+	}
+
+	// Get the appropriate forge for the repository
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return nil, err
+	}
+	selectedForge, err := forgeManager.GetForgeForRepository(validationResult.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forge for repository: %w", err)
+	}
+
+	issues, err := selectedForge.ListIssues(forge.ListIssuesParams{
+		RepoPath:     validationResult.RepoPath,
+		AssignedToMe: filters.AssignedToMe,
+		Labels:       filters.Labels,
+		Milestone:    filters.Milestone,
+		Limit:        filters.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// pickIssue lets the user choose one of the open issues of a repository.
+func (c *realCodeManager) pickIssue(repositoryName string, filters IssueFilters) (issue.Info, error) {
+	issues, err := c.listIssues(repositoryName, filters)
+	if err != nil {
+		return issue.Info{}, err
+	}
+
+	selected, err := c.deps.Prompt.PromptSelectIssue(issues)
+	if err != nil {
+		return issue.Info{}, fmt.Errorf("failed to select issue: %w", err)
+	}
+
+	return selected, nil
+}
+
+// issueReference returns a reference that the forges and issue trackers can resolve back to the issue.
+func issueReference(info issue.Info) string {
+	if info.URL != "" {
+		return info.URL
+	}
+	if info.Key != "" {
+		return info.Key
+	}
+	return fmt.Sprintf("%s/%s#%d", info.Owner, info.Repository, info.Number)
+}
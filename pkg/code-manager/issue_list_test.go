@@ -0,0 +1,126 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// expectHelloWorldForge expects the forge of the Hello-World repository to be looked up.
+func expectHelloWorldForge(mocks testMocks) {
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  "github.com/octocat/Hello-World",
+		RepoPath: "/test/repo",
+	}, nil).AnyTimes()
+	mocks.forgeManager.EXPECT().GetForgeForRepository("/test/repo").Return(mocks.forge, nil).AnyTimes()
+}
+
+func TestCM_ListIssues(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHelloWorldForge(mocks)
+
+	expected := []issue.Info{{Number: 12, Title: "Fix login", Labels: []string{"bug"}}}
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.ListIssues, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.ListIssues, gomock.Any()).Return(nil)
+	mocks.forge.EXPECT().ListIssues(forge.ListIssuesParams{
+		RepoPath:     "/test/repo",
+		AssignedToMe: true,
+		Labels:       []string{"bug"},
+		Milestone:    "v1.2",
+	}).Return(expected, nil)
+
+	issues, err := cm.ListIssues(ListIssuesOpts{
+		RepositoryName: "Hello-World",
+		IssueFilters:   IssueFilters{AssignedToMe: true, Labels: []string{"bug"}, Milestone: "v1.2"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, issues)
+}
+
+func TestCM_ListIssues_ForgeError(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHelloWorldForge(mocks)
+
+	forgeErr := errors.New("rate limited")
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.ListIssues, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(consts.ListIssues, gomock.Any()).Return(nil)
+	mocks.forge.EXPECT().ListIssues(gomock.Any()).Return(nil, forgeErr)
+
+	_, err := cm.ListIssues()
+	assert.ErrorIs(t, err, forgeErr)
+}
+
+func TestCM_HandlePickIssue(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHelloWorldForge(mocks)
+
+	issues := []issue.Info{
+		{Number: 12, Title: "Fix login", URL: "https://github.com/octocat/Hello-World/issues/12"},
+		{Number: 13, Title: "Add dark mode", Owner: "octocat", Repository: "Hello-World"},
+	}
+	mocks.forge.EXPECT().ListIssues(gomock.Any()).Return(issues, nil).Times(2)
+	mocks.prompt.EXPECT().PromptSelectIssue(issues).Return(issues[0], nil)
+	mocks.prompt.EXPECT().PromptSelectIssue(issues).Return(issues[1], nil)
+
+	options := CreateWorkTreeOpts{RepositoryName: "Hello-World", PickIssue: true}
+	assert.NoError(t, cm.handlePickIssue(&options))
+	assert.Equal(t, "https://github.com/octocat/Hello-World/issues/12", options.IssueRef)
+
+	options = CreateWorkTreeOpts{RepositoryName: "Hello-World", PickIssue: true}
+	assert.NoError(t, cm.handlePickIssue(&options))
+	assert.Equal(t, "octocat/Hello-World#13", options.IssueRef)
+}
+
+func TestCM_HandlePickIssue_WorkspaceNotSupported(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	options := CreateWorkTreeOpts{WorkspaceName: "my-workspace", PickIssue: true}
+	assert.ErrorIs(t, cm.handlePickIssue(&options), ErrPickIssueWorkspaceNotSupported)
+}
+
+func TestCM_ValidateCreateTargets_PickIssue(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	tests := []struct {
+		name     string
+		branch   string
+		options  CreateWorkTreeOpts
+		expected error
+	}{
+		{
+			name:     "with issue reference",
+			options:  CreateWorkTreeOpts{PickIssue: true, IssueRef: "123"},
+			expected: ErrPickIssueWithIssueOrPullRequest,
+		},
+		{
+			name:     "with pull request reference",
+			options:  CreateWorkTreeOpts{PickIssue: true, PullRequestRef: "42"},
+			expected: ErrPickIssueWithIssueOrPullRequest,
+		},
+		{
+			name:     "with branch",
+			branch:   "feature",
+			options:  CreateWorkTreeOpts{PickIssue: true},
+			expected: ErrBranchWithPickIssue,
+		},
+		{
+			name:     "with workspace",
+			options:  CreateWorkTreeOpts{PickIssue: true, WorkspaceName: "my-workspace"},
+			expected: ErrPickIssueWorkspaceNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, cm.validateCreateTargets(tt.branch, tt.options), tt.expected)
+		})
+	}
+}
@@ -71,8 +71,9 @@ func (c *realCodeManager) buildWorktreeChoices(targetType, targetName string) ([
 
 		for _, worktree := range worktrees {
 			choices = append(choices, prompt.TargetChoice{
-				Type: prompt.TargetRepository, // Keep same type for consistency
-				Name: worktree.Branch,
+				Type:   prompt.TargetRepository, // Keep same type for consistency
+				Name:   worktree.Branch,
+				Labels: worktree.Labels,
 			})
 		}
 	case prompt.TargetWorkspace:
@@ -90,8 +91,9 @@ func (c *realCodeManager) buildWorktreeChoices(targetType, targetName string) ([
 			if !seenBranches[worktree.Branch] {
 				seenBranches[worktree.Branch] = true
 				choices = append(choices, prompt.TargetChoice{
-					Type: prompt.TargetWorkspace, // Keep same type for consistency
-					Name: worktree.Branch,
+					Type:   prompt.TargetWorkspace, // Keep same type for consistency
+					Name:   worktree.Branch,
+					Labels: worktree.Labels,
 				})
 			}
 		}
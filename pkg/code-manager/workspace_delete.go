@@ -299,6 +299,30 @@ func (c *realCodeManager) deleteSingleWorkspaceWorktree(
 	return nil
 }
 
+// deleteWorkspaceWorktree deletes the worktrees of a branch from every repository of a workspace,
+// along with its worktree-specific workspace file and its entry in the workspace status.
+func (c *realCodeManager) deleteWorkspaceWorktree(
+	workspaceName string, worktree status.WorktreeInfo, force bool,
+) error {
+	workspace, err := c.deps.StatusManager.GetWorkspace(workspaceName)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if err := c.deleteSingleWorkspaceWorktree(workspace, worktree, force); err != nil {
+		return err
+	}
+
+	worktreeWorkspaceFile := c.getWorktreeWorkspaceFilePath(workspaceName, worktree.Branch)
+	if exists, err := c.deps.FS.Exists(worktreeWorkspaceFile); err == nil && exists {
+		if err := c.deleteWorkspaceFile(worktreeWorkspaceFile); err != nil {
+			return fmt.Errorf("failed to delete worktree workspace file: %w", err)
+		}
+	}
+
+	return c.removeWorktreesFromWorkspaceStatus(workspaceName, []status.WorktreeInfo{worktree})
+}
+
 // removeWorktreeFromGit removes a worktree from Git.
 func (c *realCodeManager) removeWorktreeFromGit(
 	repoPath, worktreePath string, worktree status.WorktreeInfo, force bool) error {
@@ -0,0 +1,133 @@
+package codemanager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+)
+
+// AnnotateWorktreeOpts contains optional parameters for AnnotateWorktree.
+type AnnotateWorktreeOpts struct {
+	WorkspaceName  string   // Name of the workspace holding the worktree (optional)
+	RepositoryName string   // Name of the repository holding the worktree (optional)
+	AddLabels      []string // Labels added to the worktree
+	RemoveLabels   []string // Labels removed from the worktree
+	Note           *string  // Note replacing the current one, an empty note removes it (unchanged when nil)
+}
+
+// AnnotateWorktree sets the free-form labels and note of the worktrees of a branch in the status file.
+func (c *realCodeManager) AnnotateWorktree(branch string, opts ...AnnotateWorktreeOpts) error {
+	// Parse options
+	options := c.extractAnnotateWorktreeOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+	if len(options.AddLabels) == 0 && len(options.RemoveLabels) == 0 && options.Note == nil {
+		return ErrNothingToAnnotate
+	}
+
+	// Handle interactive selection if neither workspace nor repository is specified
+	if options.WorkspaceName == "" && options.RepositoryName == "" {
+		selectedBranch, err := c.handleInteractiveSelectionForWorktree(branch, &options.WorkspaceName,
+			&options.RepositoryName)
+		if err != nil {
+			return err
+		}
+		branch = selectedBranch
+	}
+	if branch == "" {
+		return fmt.Errorf("%w: branch", ErrArgumentEmpty)
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"branch":          branch,
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+		"add_labels":      options.AddLabels,
+		"remove_labels":   options.RemoveLabels,
+	}
+	if options.Note != nil {
+		params["note"] = *options.Note
+	}
+
+	// Execute with hooks
+	return c.executeWithHooks(consts.AnnotateWorktree, params, func() error {
+		return c.annotateWorktrees(branch, options)
+	})
+}
+
+// annotateWorktrees annotates the worktrees of the branch in every repository of the target.
+func (c *realCodeManager) annotateWorktrees(branch string, options AnnotateWorktreeOpts) error {
+	targets, err := c.targetRepositories(options.WorkspaceName, options.RepositoryName, branch)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("%w: %s", ErrWorktreeNotInStatus, branch)
+	}
+
+	for _, target := range targets {
+		for _, worktree := range target.Worktrees {
+			worktree.Labels = annotatedLabels(worktree.Labels, options.AddLabels, options.RemoveLabels)
+			if options.Note != nil {
+				worktree.Note = *options.Note
+			}
+
+			if err := c.deps.StatusManager.UpdateWorktree(target.URL, worktree.Branch, worktree); err != nil {
+				return fmt.Errorf("%w: %w", ErrStatusUpdate, err)
+			}
+			c.VerbosePrint("Annotated worktree %s of repository %s", worktree.Branch, target.URL)
+		}
+	}
+
+	return nil
+}
+
+// annotatedLabels returns the labels with the added ones appended and the removed ones dropped.
+// Labels are compared ignoring case, so that a label is only kept once.
+func annotatedLabels(labels, added, removed []string) []string {
+	hasLabel := func(list []string, label string) bool {
+		return slices.ContainsFunc(list, func(l string) bool { return strings.EqualFold(l, label) })
+	}
+
+	var result []string
+	for _, label := range append(slices.Clone(labels), added...) {
+		label = strings.TrimSpace(label)
+		if label == "" || hasLabel(removed, label) || hasLabel(result, label) {
+			continue
+		}
+		result = append(result, label)
+	}
+	return result
+}
+
+// extractAnnotateWorktreeOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractAnnotateWorktreeOptions(opts []AnnotateWorktreeOpts) AnnotateWorktreeOpts {
+	var result AnnotateWorktreeOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if len(opt.AddLabels) > 0 {
+			result.AddLabels = opt.AddLabels
+		}
+		if len(opt.RemoveLabels) > 0 {
+			result.RemoveLabels = opt.RemoveLabels
+		}
+		if opt.Note != nil {
+			result.Note = opt.Note
+		}
+	}
+
+	return result
+}
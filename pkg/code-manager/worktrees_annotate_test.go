@@ -0,0 +1,62 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_AnnotateWorktree(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+	note := "Waiting for the design review"
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.AnnotateWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.AnnotateWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Labels: []string{"wip", "ui"}, Note: "old",
+	})
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "feature", status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Labels: []string{"ui", "review"}, Note: note,
+	}).Return(nil)
+
+	err := cm.AnnotateWorktree("feature", AnnotateWorktreeOpts{
+		RepositoryName: "Hello-World",
+		AddLabels:      []string{"review", "UI"},
+		RemoveLabels:   []string{"WIP"},
+		Note:           &note,
+	})
+	assert.NoError(t, err)
+}
+
+func TestCM_AnnotateWorktree_KeepsNote(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.AnnotateWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.AnnotateWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Note: "kept",
+	})
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "feature", status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Labels: []string{"review"}, Note: "kept",
+	}).Return(nil)
+
+	err := cm.AnnotateWorktree("feature", AnnotateWorktreeOpts{
+		RepositoryName: "Hello-World",
+		AddLabels:      []string{"review"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestCM_AnnotateWorktree_NothingToAnnotate(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	err := cm.AnnotateWorktree("feature", AnnotateWorktreeOpts{RepositoryName: "Hello-World"})
+	assert.ErrorIs(t, err, ErrNothingToAnnotate)
+}
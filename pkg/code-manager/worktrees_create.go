@@ -3,6 +3,7 @@ package codemanager
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	branchpkg "github.com/lerenn/code-manager/pkg/branch"
 	"github.com/lerenn/code-manager/pkg/code-manager/consts"
@@ -12,16 +13,32 @@ import (
 	repo "github.com/lerenn/code-manager/pkg/mode/repository"
 	ws "github.com/lerenn/code-manager/pkg/mode/workspace"
 	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/tracker"
+	"github.com/lerenn/code-manager/pkg/worktree"
 )
 
+// DefaultEphemeralTTL is the lifetime of ephemeral worktrees created without a TTL.
+const DefaultEphemeralTTL = 48 * time.Hour
+
 // CreateWorkTreeOpts contains optional parameters for CreateWorkTree.
 type CreateWorkTreeOpts struct {
 	IDEName        string
 	IssueRef       string
+	PullRequestRef string // Pull request reference; the branch and remote are resolved from the forge
 	WorkspaceName  string
 	RepositoryName string
 	Force          bool
-	Remote         string // Remote name to use (defaults to "origin" if empty)
+	Remote         string        // Remote name to use (defaults to "origin" if empty)
+	PickIssue      bool          // Interactively pick an open issue from the forge to create the worktree from
+	IssueFilters   IssueFilters  // Filters applied to the issues offered when picking an issue
+	AllowClosed    bool          // Allow creating the worktree from a closed issue
+	BaseRef        string        // Ref the new branch is created from (branch, tag, remote branch or commit SHA)
+	CarryChanges   bool          // Move the uncommitted changes of the repository into the new worktree
+	Ephemeral      bool          // Delete the worktree with GC once its TTL expired
+	TTL            time.Duration // Lifetime of an ephemeral worktree (defaults to DefaultEphemeralTTL)
+	Sparse         []string      // Directories checked out (defaults to the repository sparse patterns in config)
+	Detach         string        // Tag or commit checked out on a detached HEAD, instead of a branch
 }
 
 // CreateWorkTree executes the main application logic.
@@ -30,7 +47,7 @@ func (c *realCodeManager) CreateWorkTree(branch string, opts ...CreateWorkTreeOp
 	options := c.extractCreateWorkTreeOptions(opts)
 
 	// Validate target exclusivity
-	if err := c.validateCreateTargets(options); err != nil {
+	if err := c.validateCreateTargets(branch, options); err != nil {
 		return err
 	}
 
@@ -48,12 +65,60 @@ func (c *realCodeManager) CreateWorkTree(branch string, opts ...CreateWorkTreeOp
 	})
 }
 
-// validateCreateTargets ensures only one of WorkspaceName or RepositoryName is provided.
-func (c *realCodeManager) validateCreateTargets(options CreateWorkTreeOpts) error {
+// validateCreateTargets ensures only one of WorkspaceName or RepositoryName is provided
+// and that the issue and pull request options are consistent.
+func (c *realCodeManager) validateCreateTargets(branch string, options CreateWorkTreeOpts) error {
 	if options.WorkspaceName != "" && options.RepositoryName != "" {
 		return fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
 	}
 
+	// Validate the lifetime of ephemeral worktrees
+	if options.TTL != 0 && !options.Ephemeral {
+		return ErrTTLWithoutEphemeral
+	}
+	if options.TTL < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidTTL, options.TTL)
+	}
+
+	// Validate detached worktrees, which are checked out on a tag or commit instead of a branch
+	if options.Detach != "" {
+		if err := validateDetachTargets(branch, options); err != nil {
+			return err
+		}
+	}
+
+	// Validate issue picking if requested
+	if options.PickIssue {
+		if options.IssueRef != "" || options.PullRequestRef != "" {
+			return ErrPickIssueWithIssueOrPullRequest
+		}
+		if branch != "" {
+			return ErrBranchWithPickIssue
+		}
+		if options.WorkspaceName != "" {
+			return ErrPickIssueWorkspaceNotSupported
+		}
+	}
+
+	// Validate pull request reference if provided
+	if options.PullRequestRef != "" {
+		if options.IssueRef != "" {
+			return ErrIssueAndPullRequestExclusive
+		}
+		if branch != "" {
+			return ErrBranchWithPullRequest
+		}
+		if options.BaseRef != "" {
+			return ErrBaseRefWithPullRequest
+		}
+		if options.CarryChanges {
+			return ErrCarryChangesWithPullRequest
+		}
+		if options.WorkspaceName != "" {
+			return ErrPullRequestWorkspaceNotSupported
+		}
+	}
+
 	// Validate issue reference if provided
 	if options.IssueRef != "" {
 		if err := c.validateIssueReference(options.IssueRef); err != nil {
@@ -64,13 +129,44 @@ func (c *realCodeManager) validateCreateTargets(options CreateWorkTreeOpts) erro
 	return nil
 }
 
+// validateDetachTargets ensures that no branch related option is given with a detached worktree.
+func validateDetachTargets(branch string, options CreateWorkTreeOpts) error {
+	if branch != "" {
+		return ErrBranchWithDetach
+	}
+	if options.IssueRef != "" || options.PullRequestRef != "" || options.PickIssue {
+		return ErrDetachWithIssueOrPullRequest
+	}
+	if options.BaseRef != "" || options.CarryChanges || len(options.Sparse) > 0 {
+		return ErrDetachWithBranchOptions
+	}
+	if options.WorkspaceName != "" {
+		return ErrDetachWorkspaceNotSupported
+	}
+	return nil
+}
+
 // validateIssueReference validates that the issue reference format is valid.
 func (c *realCodeManager) validateIssueReference(issueRef string) error {
-	// Create a temporary forge instance to validate the issue reference
-	forgeInstance := forge.NewGitHub()
+	// Issue tracker keys (e.g. PROJ-123) are handled by the configured issue trackers
+	trackerManager, err := c.newTrackerManager()
+	if err != nil {
+		return err
+	}
+	if _, err := trackerManager.GetTrackerForIssue(issueRef); err == nil {
+		return nil
+	} else if !errors.Is(err, tracker.ErrNoTrackerForIssue) {
+		return err
+	}
+
+	// Create a forge manager to validate the issue reference against every supported forge
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return err
+	}
 
 	// Try to parse the issue reference
-	_, err := forgeInstance.ParseIssueReference(issueRef)
+	_, err = forgeManager.ParseIssueReference(issueRef)
 	if err != nil {
 		// Check if it's a context-required error (issue number only)
 		if errors.Is(err, issue.ErrIssueNumberRequiresContext) {
@@ -91,7 +187,10 @@ func (c *realCodeManager) resolveCreateSelection(branch *string, options *Create
 			return err
 		}
 	}
-	if err := c.handleBranchNameInput(branch, options.IssueRef); err != nil {
+	if err := c.handlePickIssue(options); err != nil {
+		return err
+	}
+	if err := c.handleBranchNameInput(branch, *options); err != nil {
 		return err
 	}
 	return nil
@@ -105,7 +204,7 @@ func (c *realCodeManager) performCreate(
 	params map[string]interface{},
 ) error {
 	// Sanitize branch name
-	sanitizedBranch, err := c.sanitizeBranchNameForCreation(branch, options.IssueRef)
+	sanitizedBranch, err := c.sanitizeBranchNameForCreation(branch, options)
 	if err != nil {
 		return err
 	}
@@ -151,6 +250,9 @@ func (c *realCodeManager) extractCreateWorkTreeOptions(opts []CreateWorkTreeOpts
 		if opt.IssueRef != "" {
 			result.IssueRef = opt.IssueRef
 		}
+		if opt.PullRequestRef != "" {
+			result.PullRequestRef = opt.PullRequestRef
+		}
 		if opt.IDEName != "" {
 			result.IDEName = opt.IDEName
 		}
@@ -163,17 +265,43 @@ func (c *realCodeManager) extractCreateWorkTreeOptions(opts []CreateWorkTreeOpts
 		if opt.Force {
 			result.Force = opt.Force
 		}
+		if opt.PickIssue {
+			result.PickIssue = opt.PickIssue
+			result.IssueFilters = opt.IssueFilters
+		}
+		if opt.AllowClosed {
+			result.AllowClosed = opt.AllowClosed
+		}
+		if opt.BaseRef != "" {
+			result.BaseRef = opt.BaseRef
+		}
+		if opt.CarryChanges {
+			result.CarryChanges = opt.CarryChanges
+		}
+		if opt.Ephemeral {
+			result.Ephemeral = opt.Ephemeral
+		}
+		if opt.TTL != 0 {
+			result.TTL = opt.TTL
+		}
+		if len(opt.Sparse) > 0 {
+			result.Sparse = opt.Sparse
+		}
+		if opt.Detach != "" {
+			result.Detach = opt.Detach
+		}
 	}
 
 	return result
 }
 
 // sanitizeBranchNameForCreation sanitizes the branch name for worktree creation.
-// It skips sanitization when using --from-issue with an empty branch name.
-func (c *realCodeManager) sanitizeBranchNameForCreation(branch, issueRef string) (string, error) {
-	if issueRef != "" && branch == "" {
-		// When using --from-issue with empty branch, skip sanitization
-		// The branch name will be generated from the issue
+// It skips sanitization when using --from-issue, --from-pr or --detach with an empty branch name.
+func (c *realCodeManager) sanitizeBranchNameForCreation(branch string, options CreateWorkTreeOpts) (string, error) {
+	if (options.IssueRef != "" || options.PullRequestRef != "" || options.Detach != "") && branch == "" {
+		// When using --from-issue or --from-pr with empty branch, skip sanitization
+		// The branch name will be generated from the issue or taken from the pull request,
+		// and detached worktrees have no branch
 		return branch, nil
 	}
 	return branchpkg.SanitizeBranchName(branch)
@@ -192,15 +320,44 @@ type handleWorktreeCreationParams struct {
 
 // handleWorktreeCreation handles worktree creation based on project type and flags.
 func (c *realCodeManager) handleWorktreeCreation(params handleWorktreeCreationParams) (string, error) {
+	ephemeral := newEphemeral(params.Options)
 	switch params.ProjectType {
 	case mode.ModeWorkspace:
+		if params.Options.PullRequestRef != "" {
+			return "", ErrPullRequestWorkspaceNotSupported
+		}
+		if params.Options.Detach != "" {
+			return "", ErrDetachWorkspaceNotSupported
+		}
+		if params.Options.CarryChanges {
+			return "", ErrCarryChangesWorkspaceNotSupported
+		}
+		if params.Options.Ephemeral {
+			// GC deletes worktrees one repository at a time, which would leave the workspace references behind
+			return "", ErrEphemeralWorkspaceNotSupported
+		}
 		if params.IssueRef != "" {
 			// Workspace mode with issue-based creation
-			return c.createWorkTreeFromIssueForWorkspace(&params.SanitizedBranch, params.IssueRef, params.RepositoryName)
+			return c.createWorkTreeFromIssueForWorkspace(createWorkTreeFromIssueForWorkspaceParams{
+				BranchName:     &params.SanitizedBranch,
+				IssueRef:       params.IssueRef,
+				RepositoryName: params.RepositoryName,
+				AllowClosed:    params.Options.AllowClosed,
+			})
 		}
 		// Workspace mode with specific workspace name
 		return c.createWorkTreeFromWorkspace(params.WorkspaceName, params.SanitizedBranch, params.Opts...)
 	case mode.ModeSingleRepo:
+		if params.Options.Detach != "" {
+			// Repository mode with a detached HEAD
+			return c.createDetachedWorkTree(params.Options.Detach, params.RepositoryName,
+				repo.CreateDetachedWorktreeOpts{Ephemeral: ephemeral})
+		}
+		if params.Options.PullRequestRef != "" {
+			// Repository mode with pull request based creation
+			return c.createWorkTreeFromPullRequest(params.Options.PullRequestRef, params.RepositoryName,
+				repo.LoadWorktreeOpts{Ephemeral: ephemeral, Sparse: params.Options.Sparse})
+		}
 		if params.IssueRef != "" {
 			// Repository mode with issue-based creation
 			return c.createWorkTreeFromIssueForSingleRepo(createWorkTreeFromIssueForSingleRepoParams{
@@ -208,10 +365,21 @@ func (c *realCodeManager) handleWorktreeCreation(params handleWorktreeCreationPa
 				IssueRef:       params.IssueRef,
 				RepositoryName: params.RepositoryName,
 				Remote:         params.Options.Remote,
+				AllowClosed:    params.Options.AllowClosed,
+				BaseRef:        params.Options.BaseRef,
+				CarryChanges:   params.Options.CarryChanges,
+				Ephemeral:      ephemeral,
+				Sparse:         params.Options.Sparse,
 			})
 		}
 		// Repository mode with regular creation
-		return c.handleRepositoryMode(params.SanitizedBranch, params.RepositoryName, params.Options.Remote)
+		return c.handleRepositoryMode(params.SanitizedBranch, params.RepositoryName, repo.CreateWorktreeOpts{
+			Remote:       params.Options.Remote,
+			BaseRef:      params.Options.BaseRef,
+			CarryChanges: params.Options.CarryChanges,
+			Ephemeral:    ephemeral,
+			Sparse:       params.Options.Sparse,
+		})
 	case mode.ModeNone:
 		return "", ErrNoGitRepositoryOrWorkspaceFound
 	default:
@@ -219,6 +387,18 @@ func (c *realCodeManager) handleWorktreeCreation(params handleWorktreeCreationPa
 	}
 }
 
+// newEphemeral returns the lifetime of the worktree to create, or nil when it is not ephemeral.
+func newEphemeral(options CreateWorkTreeOpts) *status.Ephemeral {
+	if !options.Ephemeral {
+		return nil
+	}
+	ttl := options.TTL
+	if ttl == 0 {
+		ttl = DefaultEphemeralTTL
+	}
+	return &status.Ephemeral{CreatedAt: time.Now(), TTL: ttl}
+}
+
 // createWorkTreeFromWorkspace creates worktrees from workspace definition in status.yaml.
 func (c *realCodeManager) createWorkTreeFromWorkspace(
 	workspaceName, branch string, opts ...CreateWorkTreeOpts) (string, error) {
@@ -237,6 +417,8 @@ func (c *realCodeManager) createWorkTreeFromWorkspace(
 			IDEName:       opts[0].IDEName,
 			IssueInfo:     nil, // TODO: Handle issue info if needed
 			WorkspaceName: workspaceName,
+			BaseRef:       opts[0].BaseRef,
+			Sparse:        opts[0].Sparse,
 		})
 	} else {
 		workspaceOpts = append(workspaceOpts, ws.CreateWorktreeOpts{
@@ -249,7 +431,9 @@ func (c *realCodeManager) createWorkTreeFromWorkspace(
 }
 
 // handleRepositoryMode handles repository mode: validation and worktree creation.
-func (c *realCodeManager) handleRepositoryMode(branch, repositoryName, remote string) (string, error) {
+func (c *realCodeManager) handleRepositoryMode(
+	branch, repositoryName string, createOpts repo.CreateWorktreeOpts,
+) (string, error) {
 	c.VerbosePrint("Handling repository mode")
 
 	// Create repository instance - let repositoryProvider handle repository name resolution
@@ -265,7 +449,7 @@ func (c *realCodeManager) handleRepositoryMode(branch, repositoryName, remote st
 	}
 
 	// 2. Create worktree for single repository
-	worktreePath, err := repoInstance.CreateWorktree(branch, repo.CreateWorktreeOpts{Remote: remote})
+	worktreePath, err := repoInstance.CreateWorktree(branch, createOpts)
 	if err != nil {
 		return "", c.translateRepositoryError(err)
 	}
@@ -275,6 +459,31 @@ func (c *realCodeManager) handleRepositoryMode(branch, repositoryName, remote st
 	return worktreePath, nil
 }
 
+// createDetachedWorkTree creates a worktree with its HEAD detached at a tag or commit.
+func (c *realCodeManager) createDetachedWorkTree(
+	ref, repositoryName string, opts repo.CreateDetachedWorktreeOpts,
+) (string, error) {
+	c.VerbosePrint("Creating detached worktree at %s", ref)
+
+	repoProvider := c.deps.RepositoryProvider
+	repoInstance := repoProvider(repo.NewRepositoryParams{
+		Dependencies:   c.deps,
+		RepositoryName: repositoryName,
+	})
+
+	if err := repoInstance.Validate(); err != nil {
+		return "", c.translateRepositoryError(err)
+	}
+	worktreePath, err := repoInstance.CreateDetachedWorktree(ref, opts)
+	if errors.Is(err, repo.ErrRefNotFound) {
+		return "", fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+	}
+	if err != nil {
+		return "", c.translateRepositoryError(err)
+	}
+	return worktreePath, nil
+}
+
 // translateRepositoryError translates repository package errors to CM package errors.
 func (c *realCodeManager) translateRepositoryError(err error) error {
 	if err == nil {
@@ -297,6 +506,9 @@ func (c *realCodeManager) translateRepositoryError(err error) error {
 	if errors.Is(err, repo.ErrGitRepositoryNotFound) {
 		return ErrGitRepositoryNotFound
 	}
+	if errors.Is(err, worktree.ErrWorktreeLocked) {
+		return ErrWorktreeLocked
+	}
 
 	// Return the original error if no translation is needed
 	return err
@@ -308,6 +520,11 @@ type createWorkTreeFromIssueForSingleRepoParams struct {
 	IssueRef       string
 	RepositoryName string
 	Remote         string
+	AllowClosed    bool
+	BaseRef        string
+	CarryChanges   bool
+	Ephemeral      *status.Ephemeral
+	Sparse         []string
 }
 
 // createWorkTreeFromIssueForSingleRepo creates a worktree from issue for single repository.
@@ -316,24 +533,24 @@ func (c *realCodeManager) createWorkTreeFromIssueForSingleRepo(
 ) (string, error) {
 	c.VerbosePrint("Creating worktree from issue for single repository mode")
 
-	// Create forge manager
-	forgeManager := forge.NewManager(c.deps.Logger, c.deps.StatusManager)
-
-	// Get the appropriate forge for the repository
-	selectedForge, err := forgeManager.GetForgeForRepository(params.RepositoryName)
+	// Get the issue tracker or forge the issue lives on
+	source, err := c.getIssueSource(params.RepositoryName, params.IssueRef)
 	if err != nil {
-		return "", fmt.Errorf("failed to get forge for repository: %w", err)
+		return "", err
 	}
 
 	// Get issue information
-	issueInfo, err := selectedForge.GetIssueInfo(params.IssueRef)
+	issueInfo, err := c.getIssueInfo(source, params.IssueRef, params.AllowClosed)
 	if err != nil {
-		return "", c.translateIssueError(err)
+		return "", err
 	}
 
 	// Generate branch name if not provided
 	if params.BranchName == nil || *params.BranchName == "" {
-		generatedBranchName := selectedForge.GenerateBranchName(issueInfo)
+		generatedBranchName, err := c.generateBranchNameFromIssue(source, params.RepositoryName, issueInfo)
+		if err != nil {
+			return "", err
+		}
 		params.BranchName = &generatedBranchName
 	}
 
@@ -343,40 +560,138 @@ func (c *realCodeManager) createWorkTreeFromIssueForSingleRepo(
 		Dependencies:   c.deps,
 		RepositoryName: params.RepositoryName,
 	})
-	worktreePath, err := repoInstance.CreateWorktree(
-		*params.BranchName, repo.CreateWorktreeOpts{IssueInfo: issueInfo, Remote: params.Remote})
+	worktreePath, err := repoInstance.CreateWorktree(*params.BranchName, repo.CreateWorktreeOpts{
+		IssueInfo:    issueInfo,
+		Remote:       params.Remote,
+		BaseRef:      params.BaseRef,
+		CarryChanges: params.CarryChanges,
+		Ephemeral:    params.Ephemeral,
+		Sparse:       params.Sparse,
+	})
 	if err != nil {
 		return "", err
 	}
 	return worktreePath, nil
 }
 
-// createWorkTreeFromIssueForWorkspace creates worktrees from issue for workspace.
-func (c *realCodeManager) createWorkTreeFromIssueForWorkspace(
-	branchName *string,
-	issueRef, repositoryName string,
-) (string, error) {
-	c.VerbosePrint("Creating worktree from issue for workspace mode")
+// issueSource is where issues are fetched from: the forge hosting the repository, or an issue tracker.
+type issueSource interface {
+	GetIssueInfo(issueRef string, opts ...forge.GetIssueInfoOpts) (*issue.Info, error)
+	GenerateBranchName(issueInfo *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error)
+}
+
+// getIssueSource returns the issue tracker handling the issue reference,
+// falling back to the forge of the repository when no configured tracker handles it.
+func (c *realCodeManager) getIssueSource(repositoryName, issueRef string) (issueSource, error) {
+	trackerManager, err := c.newTrackerManager()
+	if err != nil {
+		return nil, err
+	}
+
+	selectedTracker, err := trackerManager.GetTrackerForIssue(issueRef)
+	if err == nil {
+		c.VerbosePrint("Using issue tracker %s for issue %s", selectedTracker.Name(), issueRef)
+		return selectedTracker, nil
+	}
+	if !errors.Is(err, tracker.ErrNoTrackerForIssue) {
+		return nil, err
+	}
 
 	// Create forge manager
-	forgeManager := forge.NewManager(c.deps.Logger, c.deps.StatusManager)
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the appropriate forge for the repository
 	selectedForge, err := forgeManager.GetForgeForRepository(repositoryName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get forge for repository: %w", err)
+		return nil, fmt.Errorf("failed to get forge for repository: %w", err)
+	}
+	return selectedForge, nil
+}
+
+// generateBranchNameFromIssue generates a branch name from the issue with the configured template,
+// using the repository-specific template when one is configured.
+func (c *realCodeManager) generateBranchNameFromIssue(
+	source issueSource, repositoryName string, issueInfo *issue.Info,
+) (string, error) {
+	cfg, err := c.getConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// Repository overrides are keyed by repository URL, only resolve it when some are configured
+	repoURL := ""
+	if len(cfg.Repositories) > 0 {
+		repoURL = c.resolveRepositoryURL(repositoryName)
+	}
+
+	branchNameConfig := cfg.BranchNameFor(repoURL)
+	branchName, err := source.GenerateBranchName(issueInfo, forge.GenerateBranchNameOpts{
+		Template: branchNameConfig.Template,
+		Types:    branchNameConfig.Types,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate branch name from issue %s: %w", issueInfo.DisplayID(), err)
+	}
+
+	c.VerbosePrint("Generated branch name from issue %s: %s", issueInfo.DisplayID(), branchName)
+	return branchName, nil
+}
+
+// resolveRepositoryURL resolves a repository name (from status.yaml, a path, or empty for the
+// current directory) to its repository URL, returning an empty string when it cannot be resolved.
+func (c *realCodeManager) resolveRepositoryURL(repositoryName string) string {
+	if repositoryName == "" {
+		repositoryName = "."
+	} else if existingRepo, err := c.deps.StatusManager.GetRepository(repositoryName); err == nil && existingRepo != nil {
+		// Repositories in status.yaml are keyed by their URL
+		return repositoryName
+	}
+
+	repoURL, err := c.deps.Git.GetRepositoryName(repositoryName)
+	if err != nil {
+		c.VerbosePrint("Could not resolve repository URL for %s: %v", repositoryName, err)
+		return ""
+	}
+	return repoURL
+}
+
+// createWorkTreeFromIssueForWorkspaceParams contains parameters for createWorkTreeFromIssueForWorkspace.
+type createWorkTreeFromIssueForWorkspaceParams struct {
+	BranchName     *string
+	IssueRef       string
+	RepositoryName string
+	AllowClosed    bool
+}
+
+// createWorkTreeFromIssueForWorkspace creates worktrees from issue for workspace.
+func (c *realCodeManager) createWorkTreeFromIssueForWorkspace(
+	params createWorkTreeFromIssueForWorkspaceParams,
+) (string, error) {
+
+	c.VerbosePrint("Creating worktree from issue for workspace mode")
+
+	// Get the issue tracker or forge the issue lives on
+	source, err := c.getIssueSource(params.RepositoryName, params.IssueRef)
+	if err != nil {
+		return "", err
 	}
 
 	// Get issue information
-	issueInfo, err := selectedForge.GetIssueInfo(issueRef)
+	issueInfo, err := c.getIssueInfo(source, params.IssueRef, params.AllowClosed)
 	if err != nil {
-		return "", c.translateIssueError(err)
+		return "", err
 	}
 
 	// Generate branch name if not provided
-	if branchName == nil || *branchName == "" {
-		generatedBranchName := selectedForge.GenerateBranchName(issueInfo)
-		branchName = &generatedBranchName
+	if params.BranchName == nil || *params.BranchName == "" {
+		generatedBranchName, err := c.generateBranchNameFromIssue(source, params.RepositoryName, issueInfo)
+		if err != nil {
+			return "", err
+		}
+		params.BranchName = &generatedBranchName
 	}
 
 	// Create workspace instance
@@ -384,13 +699,81 @@ func (c *realCodeManager) createWorkTreeFromIssueForWorkspace(
 	workspaceInstance := workspaceProvider(ws.NewWorkspaceParams{
 		Dependencies: c.deps,
 	})
-	worktreePath, err := workspaceInstance.CreateWorktree(*branchName)
+	worktreePath, err := workspaceInstance.CreateWorktree(*params.BranchName)
 	if err != nil {
 		return "", err
 	}
 	return worktreePath, nil
 }
 
+// createWorkTreeFromPullRequest creates a worktree on the head branch of a pull request.
+// The head repository is added as a remote named after its owner when the pull request comes from a fork,
+// with the clone URLs given by the forge so that forks with another name or namespace are fetched.
+func (c *realCodeManager) createWorkTreeFromPullRequest(
+	prRef, repositoryName string, loadOpts repo.LoadWorktreeOpts,
+) (string, error) {
+	c.VerbosePrint("Creating worktree from pull request for single repository mode")
+
+	// Create forge manager
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return "", err
+	}
+
+	// Get the appropriate forge for the repository
+	selectedForge, err := forgeManager.GetForgeForRepository(repositoryName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get forge for repository: %w", err)
+	}
+
+	// Get pull request information
+	prInfo, err := selectedForge.GetPullRequestInfo(prRef)
+	if err != nil {
+		return "", err
+	}
+
+	// Pull requests from the repository itself are loaded from origin, forks from their owner remote
+	remoteSource := repo.DefaultRemote
+	if prInfo.IsFromFork() {
+		remoteSource = prInfo.HeadOwner
+	}
+	c.VerbosePrint("Pull request #%d: %s:%s -> %s", prInfo.Number, remoteSource, prInfo.HeadBranch, prInfo.BaseBranch)
+
+	repoProvider := c.deps.RepositoryProvider
+	repoInstance := repoProvider(repo.NewRepositoryParams{
+		Dependencies:   c.deps,
+		RepositoryName: repositoryName,
+	})
+
+	// Add the fork remote if needed, then load the head branch from it
+	if err := repoInstance.HandleRemoteManagement(remoteSource, repo.HandleRemoteManagementOpts{
+		CloneURL: prInfo.HeadCloneURL,
+		SSHURL:   prInfo.HeadSSHURL,
+	}); err != nil {
+		return "", c.translateRepositoryError(err)
+	}
+	loadOpts.PullRequest = prInfo
+	worktreePath, err := repoInstance.LoadWorktree(remoteSource, prInfo.HeadBranch, loadOpts)
+	if err != nil {
+		return "", c.translateRepositoryError(err)
+	}
+	return worktreePath, nil
+}
+
+// getIssueInfo fetches the issue to create a worktree from, warning when it comes from the offline cache.
+func (c *realCodeManager) getIssueInfo(
+	source issueSource, issueRef string, allowClosed bool,
+) (*issue.Info, error) {
+	issueInfo, err := source.GetIssueInfo(issueRef, forge.GetIssueInfoOpts{AllowClosed: allowClosed})
+	if err != nil {
+		return nil, c.translateIssueError(err)
+	}
+	if issueInfo.Stale {
+		c.VerbosePrint("Warning: forge unreachable, issue %s is stale (from the issue cache)", issueInfo.DisplayID())
+	}
+	return issueInfo, nil
+}
+
 // translateIssueError translates issue-related errors to preserve the original error types.
 func (c *realCodeManager) translateIssueError(err error) error {
 	if err == nil {
@@ -430,9 +813,29 @@ func (c *realCodeManager) handleInteractiveTargetSelection(options *CreateWorkTr
 	return nil
 }
 
+// handlePickIssue lets the user pick the issue to create the worktree from, when requested.
+func (c *realCodeManager) handlePickIssue(options *CreateWorkTreeOpts) error {
+	if !options.PickIssue {
+		return nil
+	}
+	if options.WorkspaceName != "" {
+		return ErrPickIssueWorkspaceNotSupported
+	}
+
+	selected, err := c.pickIssue(options.RepositoryName, options.IssueFilters)
+	if err != nil {
+		return err
+	}
+
+	c.VerbosePrint("Selected issue #%d: %s", selected.Number, selected.Title)
+	options.IssueRef = issueReference(selected)
+	return nil
+}
+
 // handleBranchNameInput handles interactive branch name input if not provided.
-func (c *realCodeManager) handleBranchNameInput(branch *string, issueRef string) error {
-	if *branch == "" && issueRef == "" {
+// The branch is not prompted for when it is derived from an issue or a pull request.
+func (c *realCodeManager) handleBranchNameInput(branch *string, options CreateWorkTreeOpts) error {
+	if *branch == "" && options.IssueRef == "" && options.PullRequestRef == "" && options.Detach == "" {
 		branchName, err := c.deps.Prompt.PromptForBranchName()
 		if err != nil {
 			return fmt.Errorf("failed to get branch name: %w", err)
@@ -448,9 +851,16 @@ func (c *realCodeManager) prepareCreateWorkTreeParams(
 	params := map[string]interface{}{
 		"branch":         branch,
 		"issueRef":       options.IssueRef,
+		"pullRequestRef": options.PullRequestRef,
 		"workspaceName":  options.WorkspaceName,
 		"repositoryName": options.RepositoryName,
 		"force":          options.Force,
+		"pickIssue":      options.PickIssue,
+		"allowClosed":    options.AllowClosed,
+		"baseRef":        options.BaseRef,
+		"ephemeral":      options.Ephemeral,
+		"sparse":         options.Sparse,
+		"detach":         options.Detach,
 	}
 	if options.IDEName != "" {
 		params["ideName"] = options.IDEName
@@ -3,14 +3,20 @@
 package codemanager
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/lerenn/code-manager/pkg/code-manager/consts"
 	"github.com/lerenn/code-manager/pkg/config"
+	configmocks "github.com/lerenn/code-manager/pkg/config/mocks"
 	"github.com/lerenn/code-manager/pkg/dependencies"
+	"github.com/lerenn/code-manager/pkg/forge"
+	forgemocks "github.com/lerenn/code-manager/pkg/forge/mocks"
 	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
 	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
 	hooksMocks "github.com/lerenn/code-manager/pkg/hooks/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
 	"github.com/lerenn/code-manager/pkg/mode/repository"
 	repositoryMocks "github.com/lerenn/code-manager/pkg/mode/repository/mocks"
@@ -18,8 +24,11 @@ import (
 	workspaceMocks "github.com/lerenn/code-manager/pkg/mode/workspace/mocks"
 	"github.com/lerenn/code-manager/pkg/prompt"
 	promptMocks "github.com/lerenn/code-manager/pkg/prompt/mocks"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
 	statusMocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/lerenn/code-manager/pkg/tracker"
+	trackermocks "github.com/lerenn/code-manager/pkg/tracker/mocks"
 	"github.com/lerenn/code-manager/pkg/worktree"
 	worktreemocks "github.com/lerenn/code-manager/pkg/worktree/mocks"
 	"github.com/stretchr/testify/assert"
@@ -88,6 +97,235 @@ func TestCM_CreateWorkTree_SingleRepository(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCM_CreateWorkTree_FromBaseRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockPrompt := promptMocks.NewMockPrompter(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithFS(mockFS).
+			WithGit(gitmocks.NewMockGit(ctrl)).
+			WithStatusManager(mockStatus).
+			WithPrompt(mockPrompt).
+			WithRepositoryProvider(func(params repository.NewRepositoryParams) repository.Repository {
+				return mockRepository
+			}),
+	})
+	assert.NoError(t, err)
+
+	setBaselineExpectationsCreate(mockHookManager, mockStatus, mockPrompt, mockFS)
+
+	// The base ref is passed down to the repository
+	mockRepository.EXPECT().IsGitRepository().Return(true, nil).AnyTimes()
+	mockRepository.EXPECT().Validate().Return(nil)
+	mockRepository.EXPECT().CreateWorktree("hotfix/login", repository.CreateWorktreeOpts{BaseRef: "release/1.4"}).
+		Return("/test/base/path/test-repo/origin/hotfix/login", nil)
+
+	err = cm.CreateWorkTree("hotfix/login", CreateWorkTreeOpts{RepositoryName: "test-repo", BaseRef: "release/1.4"})
+	assert.NoError(t, err)
+
+	// Pull requests are loaded on their head branch, which has no base ref
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{PullRequestRef: "42", BaseRef: "release/1.4"})
+	assert.ErrorIs(t, err, ErrBaseRefWithPullRequest)
+}
+
+func TestCM_CreateWorkTree_CarryChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockPrompt := promptMocks.NewMockPrompter(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithFS(mockFS).
+			WithGit(gitmocks.NewMockGit(ctrl)).
+			WithStatusManager(mockStatus).
+			WithPrompt(mockPrompt).
+			WithRepositoryProvider(func(params repository.NewRepositoryParams) repository.Repository {
+				return mockRepository
+			}),
+	})
+	assert.NoError(t, err)
+
+	setBaselineExpectationsCreate(mockHookManager, mockStatus, mockPrompt, mockFS)
+
+	// Carrying the changes is left to the repository
+	mockRepository.EXPECT().IsGitRepository().Return(true, nil).AnyTimes()
+	mockRepository.EXPECT().Validate().Return(nil)
+	mockRepository.EXPECT().CreateWorktree("feature", repository.CreateWorktreeOpts{CarryChanges: true}).
+		Return("/test/base/path/test-repo/origin/feature", nil)
+
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{RepositoryName: "test-repo", CarryChanges: true})
+	assert.NoError(t, err)
+
+	// Pull request worktrees are loaded on an existing branch, changes are not carried into them
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{PullRequestRef: "42", CarryChanges: true})
+	assert.ErrorIs(t, err, ErrCarryChangesWithPullRequest)
+}
+
+func TestCM_CreateWorkTree_Sparse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockPrompt := promptMocks.NewMockPrompter(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithFS(mockFS).
+			WithGit(gitmocks.NewMockGit(ctrl)).
+			WithStatusManager(mockStatus).
+			WithPrompt(mockPrompt).
+			WithRepositoryProvider(func(params repository.NewRepositoryParams) repository.Repository {
+				return mockRepository
+			}),
+	})
+	assert.NoError(t, err)
+
+	setBaselineExpectationsCreate(mockHookManager, mockStatus, mockPrompt, mockFS)
+
+	// The sparse directories are passed down to the repository
+	mockRepository.EXPECT().IsGitRepository().Return(true, nil).AnyTimes()
+	mockRepository.EXPECT().Validate().Return(nil)
+	mockRepository.EXPECT().CreateWorktree("feature", repository.CreateWorktreeOpts{
+		Sparse: []string{"services/api", "libs/common"},
+	}).Return("/test/base/path/test-repo/origin/feature", nil)
+
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{
+		RepositoryName: "test-repo",
+		Sparse:         []string{"services/api", "libs/common"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestCM_CreateWorkTree_Detach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockPrompt := promptMocks.NewMockPrompter(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithFS(mockFS).
+			WithGit(gitmocks.NewMockGit(ctrl)).
+			WithStatusManager(mockStatus).
+			WithPrompt(mockPrompt).
+			WithRepositoryProvider(func(params repository.NewRepositoryParams) repository.Repository {
+				return mockRepository
+			}),
+	})
+	assert.NoError(t, err)
+
+	setBaselineExpectationsCreate(mockHookManager, mockStatus, mockPrompt, mockFS)
+
+	// No branch name is asked for, the worktree is named after the ref by the repository
+	mockRepository.EXPECT().IsGitRepository().Return(true, nil).AnyTimes()
+	mockRepository.EXPECT().Validate().Return(nil).Times(2)
+	mockRepository.EXPECT().CreateDetachedWorktree("v2.3.1", repository.CreateDetachedWorktreeOpts{}).
+		Return("/test/base/path/test-repo/detached/v2.3.1", nil)
+
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{RepositoryName: "test-repo", Detach: "v2.3.1"})
+	assert.NoError(t, err)
+
+	// Unknown refs are reported with the ref
+	mockRepository.EXPECT().CreateDetachedWorktree("v9.9.9", repository.CreateDetachedWorktreeOpts{}).
+		Return("", fmt.Errorf("%w: v9.9.9", repository.ErrRefNotFound))
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{RepositoryName: "test-repo", Detach: "v9.9.9"})
+	assert.ErrorIs(t, err, ErrRefNotFound)
+
+	// Detached worktrees have no branch
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{RepositoryName: "test-repo", Detach: "v2.3.1"})
+	assert.ErrorIs(t, err, ErrBranchWithDetach)
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{PullRequestRef: "42", Detach: "v2.3.1"})
+	assert.ErrorIs(t, err, ErrDetachWithIssueOrPullRequest)
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{RepositoryName: "test-repo", Detach: "v2.3.1", BaseRef: "main"})
+	assert.ErrorIs(t, err, ErrDetachWithBranchOptions)
+	err = cm.CreateWorkTree("", CreateWorkTreeOpts{WorkspaceName: "ws", Detach: "v2.3.1"})
+	assert.ErrorIs(t, err, ErrDetachWorkspaceNotSupported)
+}
+
+func TestCM_CreateWorkTree_Ephemeral(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockPrompt := promptMocks.NewMockPrompter(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithFS(mockFS).
+			WithGit(gitmocks.NewMockGit(ctrl)).
+			WithStatusManager(mockStatus).
+			WithPrompt(mockPrompt).
+			WithRepositoryProvider(func(params repository.NewRepositoryParams) repository.Repository {
+				return mockRepository
+			}),
+	})
+	assert.NoError(t, err)
+
+	setBaselineExpectationsCreate(mockHookManager, mockStatus, mockPrompt, mockFS)
+
+	// The creation time and TTL are recorded with the worktree, 48h by default
+	var ttls []time.Duration
+	mockRepository.EXPECT().IsGitRepository().Return(true, nil).AnyTimes()
+	mockRepository.EXPECT().Validate().Return(nil).Times(2)
+	mockRepository.EXPECT().CreateWorktree("feature", gomock.Any()).
+		DoAndReturn(func(_ string, opts ...repository.CreateWorktreeOpts) (string, error) {
+			assert.NotNil(t, opts[0].Ephemeral)
+			assert.WithinDuration(t, time.Now(), opts[0].Ephemeral.CreatedAt, time.Minute)
+			ttls = append(ttls, opts[0].Ephemeral.TTL)
+			return "/test/base/path/test-repo/origin/feature", nil
+		}).Times(2)
+
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{RepositoryName: "test-repo", Ephemeral: true})
+	assert.NoError(t, err)
+	err = cm.CreateWorkTree("feature",
+		CreateWorkTreeOpts{RepositoryName: "test-repo", Ephemeral: true, TTL: 4 * time.Hour})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{DefaultEphemeralTTL, 4 * time.Hour}, ttls)
+
+	// A TTL is only meaningful for ephemeral worktrees
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{RepositoryName: "test-repo", TTL: time.Hour})
+	assert.ErrorIs(t, err, ErrTTLWithoutEphemeral)
+	err = cm.CreateWorkTree("feature",
+		CreateWorkTreeOpts{RepositoryName: "test-repo", Ephemeral: true, TTL: -time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidTTL)
+
+	// GC cannot delete the worktrees of a workspace
+	err = cm.CreateWorkTree("feature", CreateWorkTreeOpts{WorkspaceName: "test-workspace", Ephemeral: true})
+	assert.ErrorIs(t, err, ErrEphemeralWorkspaceNotSupported)
+}
+
 func TestCM_CreateWorkTreeWithIDE(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -299,6 +537,34 @@ func TestCreateWorkTreeWithRepositoryName(t *testing.T) {
 			},
 			expectedError: "cannot specify both WorkspaceName and RepositoryName",
 		},
+		{
+			name:   "error when both issue and pull request specified",
+			branch: "",
+			opts: CreateWorkTreeOpts{
+				RepositoryName: "/path/to/repo",
+				IssueRef:       "owner/repo#1",
+				PullRequestRef: "owner/repo#2",
+			},
+			expectedError: ErrIssueAndPullRequestExclusive.Error(),
+		},
+		{
+			name:   "error when branch specified with pull request",
+			branch: "feature-branch",
+			opts: CreateWorkTreeOpts{
+				RepositoryName: "/path/to/repo",
+				PullRequestRef: "owner/repo#2",
+			},
+			expectedError: ErrBranchWithPullRequest.Error(),
+		},
+		{
+			name:   "error when workspace specified with pull request",
+			branch: "",
+			opts: CreateWorkTreeOpts{
+				WorkspaceName:  "test-workspace",
+				PullRequestRef: "owner/repo#2",
+			},
+			expectedError: ErrPullRequestWorkspaceNotSupported.Error(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -406,3 +672,177 @@ func TestCreateWorkTreeRepositoryResolution(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateBranchNameFromIssue(t *testing.T) {
+	bugIssue := &issue.Info{Number: 123, Title: "Fix Login Bug", Labels: []string{"bug"}}
+
+	tests := []struct {
+		name           string
+		config         config.Config
+		repositoryName string
+		setupMocks     func(mockGit *gitmocks.MockGit, mockStatus *statusMocks.MockManager)
+		expected       string
+	}{
+		{
+			name:     "default template",
+			config:   config.Config{},
+			expected: "123-fix-login-bug",
+		},
+		{
+			name: "global template",
+			config: config.Config{
+				BranchName: config.BranchNameConfig{Template: "{{.Type}}/{{.Number}}-{{.Slug}}"},
+			},
+			expected: "fix/123-fix-login-bug",
+		},
+		{
+			name: "repository override for current directory",
+			config: config.Config{
+				BranchName: config.BranchNameConfig{Template: "{{.Type}}/{{.Number}}-{{.Slug}}"},
+				Repositories: map[string]config.RepositoryConfig{
+					"github.com/octocat/Hello-World": {BranchName: config.BranchNameConfig{Template: "issue-{{.Number}}"}},
+				},
+			},
+			setupMocks: func(mockGit *gitmocks.MockGit, _ *statusMocks.MockManager) {
+				mockGit.EXPECT().GetRepositoryName(".").Return("github.com/octocat/Hello-World", nil)
+			},
+			expected: "issue-123",
+		},
+		{
+			name: "repository override for repository from status",
+			config: config.Config{
+				Repositories: map[string]config.RepositoryConfig{
+					"github.com/octocat/Hello-World": {BranchName: config.BranchNameConfig{
+						Template: "{{.Type}}/{{.Number}}",
+						Types:    map[string]string{"bug": "bugfix"},
+					}},
+				},
+			},
+			repositoryName: "github.com/octocat/Hello-World",
+			setupMocks: func(_ *gitmocks.MockGit, mockStatus *statusMocks.MockManager) {
+				mockStatus.EXPECT().GetRepository("github.com/octocat/Hello-World").Return(&status.Repository{}, nil)
+			},
+			expected: "bugfix/123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockConfig := configmocks.NewMockManager(ctrl)
+			mockGit := gitmocks.NewMockGit(ctrl)
+			mockStatus := statusMocks.NewMockManager(ctrl)
+
+			mockConfig.EXPECT().GetConfigWithFallback().Return(tt.config, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockGit, mockStatus)
+			}
+
+			c := &realCodeManager{deps: dependencies.New().
+				WithConfig(mockConfig).
+				WithGit(mockGit).
+				WithStatusManager(mockStatus)}
+
+			result, err := c.generateBranchNameFromIssue(forge.NewGitHub(), tt.repositoryName, bugIssue)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetIssueInfo_AllowClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockForge := forgemocks.NewMockForge(ctrl)
+	cm := &realCodeManager{deps: dependencies.New()}
+
+	mockForge.EXPECT().GetIssueInfo("123", forge.GetIssueInfoOpts{}).Return(nil, forge.ErrIssueClosed)
+	_, err := cm.getIssueInfo(mockForge, "123", false)
+	assert.ErrorIs(t, err, forge.ErrIssueClosed)
+
+	closedIssue := &issue.Info{Number: 123, State: issue.StateClosed, Stale: true}
+	mockForge.EXPECT().GetIssueInfo("123", forge.GetIssueInfoOpts{AllowClosed: true}).Return(closedIssue, nil)
+	info, err := cm.getIssueInfo(mockForge, "123", true)
+	assert.NoError(t, err)
+	assert.Equal(t, closedIssue, info)
+}
+
+func TestCreateWorkTreeFromIssueForSingleRepo_IssueTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := configmocks.NewMockManager(ctrl)
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockTrackerManager := trackermocks.NewMockManagerInterface(ctrl)
+	mockTracker := trackermocks.NewMockIssueTracker(ctrl)
+
+	cfg := config.Config{BranchName: config.BranchNameConfig{Template: "{{.Type}}/{{.ID}}-{{.Slug}}"}}
+	mockConfig.EXPECT().GetConfigWithFallback().Return(cfg, nil).AnyTimes()
+
+	// The issue is resolved by the issue tracker, the forge is never queried
+	jiraIssue := &issue.Info{Number: 123, Key: "PROJ-123", Title: "Add Dark Mode", State: issue.StateOpen,
+		Labels: []string{"story"}}
+	mockTrackerManager.EXPECT().GetTrackerForIssue("PROJ-123").Return(mockTracker, nil)
+	mockTracker.EXPECT().Name().Return("jira").AnyTimes()
+	mockTracker.EXPECT().GetIssueInfo("PROJ-123", forge.GetIssueInfoOpts{}).Return(jiraIssue, nil)
+	mockTracker.EXPECT().GenerateBranchName(jiraIssue, gomock.Any()).
+		DoAndReturn(func(info *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error) {
+			return forge.GenerateBranchName(info, opts...)
+		})
+	mockRepository.EXPECT().
+		CreateWorktree("feat/PROJ-123-add-dark-mode", repository.CreateWorktreeOpts{IssueInfo: jiraIssue}).
+		Return("/worktrees/feat/PROJ-123-add-dark-mode", nil)
+
+	c := &realCodeManager{deps: dependencies.New().
+		WithConfig(mockConfig).
+		WithRepositoryProvider(func(repository.NewRepositoryParams) repository.Repository { return mockRepository }).
+		WithForgeProvider(func(_ logger.Logger, _ status.Manager, _ config.Config) forge.ManagerInterface {
+			t.Fatal("the forge must not be used for issue tracker issues")
+			return nil
+		}).
+		WithTrackerProvider(func(_ logger.Logger, _ config.Config) tracker.ManagerInterface {
+			return mockTrackerManager
+		})}
+
+	worktreePath, err := c.createWorkTreeFromIssueForSingleRepo(createWorkTreeFromIssueForSingleRepoParams{
+		IssueRef: "PROJ-123",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/worktrees/feat/PROJ-123-add-dark-mode", worktreePath)
+}
+
+func TestCreateWorkTreeFromPullRequest_RenamedFork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := configmocks.NewMockManager(ctrl)
+	mockRepository := repositoryMocks.NewMockRepository(ctrl)
+	mockForgeManager := forgemocks.NewMockManagerInterface(ctrl)
+	mockForge := forgemocks.NewMockForge(ctrl)
+
+	mockConfig.EXPECT().GetConfigWithFallback().Return(config.Config{}, nil).AnyTimes()
+
+	// The fork is not named like the base repository: its remote is added from the URLs given by the forge
+	prInfo := &pullrequest.Info{
+		Number: 42, BaseBranch: "main", HeadBranch: "add-feature",
+		Owner: "octocat", Repository: "Hello-World",
+		HeadOwner: "contributor", HeadRepository: "hello-fork",
+		HeadCloneURL: "https://github.com/contributor/hello-fork.git",
+		HeadSSHURL:   "git@github.com:contributor/hello-fork.git",
+	}
+	mockForgeManager.EXPECT().GetForgeForRepository("/test/repo").Return(mockForge, nil)
+	mockForge.EXPECT().GetPullRequestInfo("42").Return(prInfo, nil)
+	mockRepository.EXPECT().HandleRemoteManagement("contributor", repository.HandleRemoteManagementOpts{
+		CloneURL: "https://github.com/contributor/hello-fork.git",
+		SSHURL:   "git@github.com:contributor/hello-fork.git",
+	}).Return(nil)
+	mockRepository.EXPECT().
+		LoadWorktree("contributor", "add-feature", repository.LoadWorktreeOpts{PullRequest: prInfo}).
+		Return("/worktrees/contributor/add-feature", nil)
+
+	c := &realCodeManager{deps: dependencies.New().
+		WithConfig(mockConfig).
+		WithRepositoryProvider(func(repository.NewRepositoryParams) repository.Repository { return mockRepository }).
+		WithForgeProvider(func(_ logger.Logger, _ status.Manager, _ config.Config) forge.ManagerInterface {
+			return mockForgeManager
+		})}
+
+	worktreePath, err := c.createWorkTreeFromPullRequest("42", "/test/repo", repository.LoadWorktreeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, "/worktrees/contributor/add-feature", worktreePath)
+}
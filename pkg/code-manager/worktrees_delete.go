@@ -185,6 +185,9 @@ func (c *realCodeManager) translateWorkspaceError(err error) error {
 	if errors.Is(err, ws.ErrDirectoryExists) {
 		return ErrDirectoryExists
 	}
+	if errors.Is(err, ws.ErrWorktreeLocked) {
+		return ErrWorktreeLocked
+	}
 
 	// Return the original error if no translation is needed
 	return err
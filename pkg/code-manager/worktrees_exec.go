@@ -0,0 +1,322 @@
+package codemanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/fs"
+	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// DefaultExecJobs is the number of commands run at the same time when no limit is given.
+const DefaultExecJobs = 4
+
+// WorktreeExecState is the outcome of running a command in a worktree.
+type WorktreeExecState string
+
+// Worktree command outcomes.
+const (
+	WorktreeExecPassed WorktreeExecState = "passed"
+	WorktreeExecFailed WorktreeExecState = "failed"
+)
+
+// WorktreeExecResult reports how a command ran in a worktree.
+type WorktreeExecResult struct {
+	RepoURL  string            `json:"repository"`
+	Branch   string            `json:"branch"`
+	Path     string            `json:"path"`
+	State    WorktreeExecState `json:"state"`
+	ExitCode int               `json:"exit_code"`
+	Output   string            `json:"output"`            // Standard output and error of the command
+	Message  string            `json:"message,omitempty"` // Reason of a command that could not be run
+}
+
+// ExecWorktreesOpts contains optional parameters for ExecWorktrees.
+type ExecWorktreesOpts struct {
+	WorkspaceName  string    // Name of the workspace holding the worktrees (optional)
+	RepositoryName string    // Name of the repository holding the worktrees (optional)
+	All            bool      // Run the command in the worktrees of every repository
+	Jobs           int       // Number of commands run at the same time (DefaultExecJobs if not set)
+	Output         io.Writer // Receives the output of the commands, each line prefixed by repository and branch
+}
+
+// ExecWorktrees runs a command in the directory of every worktree of a workspace, a repository, or all
+// repositories, running up to Jobs commands at the same time. The results of all worktrees are returned,
+// sorted by repository and branch, along with ErrExecIncomplete when the command failed in some of them.
+func (c *realCodeManager) ExecWorktrees(command []string, opts ...ExecWorktreesOpts) ([]WorktreeExecResult, error) {
+	// Parse options
+	options := c.extractExecWorktreesOptions(opts)
+
+	// Validate options
+	if len(command) == 0 {
+		return nil, ErrExecNoCommand
+	}
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return nil, fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+	if options.All && (options.WorkspaceName != "" || options.RepositoryName != "") {
+		return nil, ErrExecAllWithTarget
+	}
+	if options.Jobs < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidExecJobs, options.Jobs)
+	}
+	if options.Jobs == 0 {
+		options.Jobs = DefaultExecJobs
+	}
+
+	// Handle interactive selection if no target is specified
+	if !options.All && options.WorkspaceName == "" && options.RepositoryName == "" {
+		if err := c.handleInteractiveSelectionForExec(&options); err != nil {
+			return nil, err
+		}
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"command":         command,
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+		"all":             options.All,
+		"jobs":            options.Jobs,
+	}
+
+	// Execute with hooks
+	var results []WorktreeExecResult
+	err := c.executeWithHooks(consts.ExecWorktrees, params, func() error {
+		targets, err := c.execTargets(options)
+		if err != nil {
+			return err
+		}
+
+		results = c.execInWorktrees(command, targets, options)
+		return execResultsError(results)
+	})
+	return results, err
+}
+
+// handleInteractiveSelectionForExec prompts for the workspace or repository holding the worktrees.
+func (c *realCodeManager) handleInteractiveSelectionForExec(options *ExecWorktreesOpts) error {
+	result, err := c.promptSelectTargetOnly()
+	if err != nil {
+		return fmt.Errorf("failed to select target: %w", err)
+	}
+
+	switch result.Type {
+	case prompt.TargetWorkspace:
+		options.WorkspaceName = result.Name
+	case prompt.TargetRepository:
+		options.RepositoryName = result.Name
+	default:
+		return fmt.Errorf("invalid target type selected: %s", result.Type)
+	}
+
+	return nil
+}
+
+// execTarget is a worktree a command runs in.
+type execTarget struct {
+	RepoURL  string
+	Worktree status.WorktreeInfo
+}
+
+// execTargets returns the worktrees the command runs in, sorted by repository and branch.
+func (c *realCodeManager) execTargets(options ExecWorktreesOpts) ([]execTarget, error) {
+	var targets []execTarget
+	if !options.All {
+		repositories, err := c.targetRepositories(options.WorkspaceName, options.RepositoryName, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range repositories {
+			for _, worktree := range repository.Worktrees {
+				targets = append(targets, execTarget{RepoURL: repository.URL, Worktree: worktree})
+			}
+		}
+		return targets, nil
+	}
+
+	repositories, err := c.deps.StatusManager.ListRepositories()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToLoadRepositories, err)
+	}
+	for _, repoURL := range sortedKeys(repositories) {
+		worktrees := make([]status.WorktreeInfo, 0, len(repositories[repoURL].Worktrees))
+		for _, worktree := range repositories[repoURL].Worktrees {
+			worktrees = append(worktrees, worktree)
+		}
+		sort.Slice(worktrees, func(i, j int) bool { return worktrees[i].Branch < worktrees[j].Branch })
+		for _, worktree := range worktrees {
+			targets = append(targets, execTarget{RepoURL: repoURL, Worktree: worktree})
+		}
+	}
+	return targets, nil
+}
+
+// execInWorktrees runs the command in every worktree, with at most options.Jobs commands running at once.
+func (c *realCodeManager) execInWorktrees(
+	command []string, targets []execTarget, options ExecWorktreesOpts,
+) []WorktreeExecResult {
+	results := make([]WorktreeExecResult, len(targets))
+	outputMutex := &sync.Mutex{}
+	slots := make(chan struct{}, options.Jobs)
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			output := &prefixedWriter{
+				out:    options.Output,
+				mutex:  outputMutex,
+				prefix: fmt.Sprintf("[%s %s] ", target.RepoURL, target.Worktree.Branch),
+			}
+			results[i] = c.execInWorktree(command, target, output)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// execInWorktree runs the command in the directory of a worktree.
+func (c *realCodeManager) execInWorktree(
+	command []string, target execTarget, output *prefixedWriter,
+) WorktreeExecResult {
+	result := WorktreeExecResult{
+		RepoURL: target.RepoURL,
+		Branch:  target.Worktree.Branch,
+		Path:    c.BuildWorktreePath(target.RepoURL, target.Worktree.Remote, target.Worktree.Branch),
+	}
+
+	if exists, err := c.deps.FS.Exists(result.Path); err != nil || !exists {
+		return result.withFailure(-1, fmt.Sprintf("worktree directory not found: %s", result.Path))
+	}
+
+	c.VerbosePrint("Running %s in worktree %s of %s", strings.Join(command, " "), result.Branch, result.RepoURL)
+	exitCode, err := c.deps.FS.RunCommand(fs.RunCommandParams{
+		Dir:     result.Path,
+		Command: command[0],
+		Args:    command[1:],
+		Output:  output,
+	})
+	output.Flush()
+	result.Output = output.captured.String()
+
+	switch {
+	case err != nil:
+		return result.withFailure(exitCode, err.Error())
+	case exitCode != 0:
+		return result.withFailure(exitCode, "")
+	}
+	result.State = WorktreeExecPassed
+	return result
+}
+
+// withFailure marks the result as failed with the exit code and message, and returns it.
+func (r WorktreeExecResult) withFailure(exitCode int, message string) WorktreeExecResult {
+	r.State = WorktreeExecFailed
+	r.ExitCode = exitCode
+	r.Message = message
+	return r
+}
+
+// execResultsError returns ErrExecIncomplete when the command failed in some worktrees.
+func execResultsError(results []WorktreeExecResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.State == WorktreeExecFailed {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d worktrees", ErrExecIncomplete, failed, len(results))
+}
+
+// prefixedWriter captures the output of a command, and copies each complete line to out with a prefix.
+// Writers of commands running at the same time share the mutex, so that their lines are not mixed.
+type prefixedWriter struct {
+	out      io.Writer
+	mutex    *sync.Mutex
+	prefix   string
+	captured bytes.Buffer
+	pending  []byte // Last line, until it is complete
+}
+
+// Write captures p and copies its complete lines to out.
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	w.captured.Write(p)
+	if w.out == nil {
+		return len(p), nil
+	}
+
+	w.pending = append(w.pending, p...)
+	end := bytes.LastIndexByte(w.pending, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+	w.writeLines(w.pending[:end+1])
+	w.pending = append(w.pending[:0], w.pending[end+1:]...)
+	return len(p), nil
+}
+
+// Flush copies the last line to out, when the output does not end with a newline.
+func (w *prefixedWriter) Flush() {
+	if w.out == nil || len(w.pending) == 0 {
+		return
+	}
+	w.writeLines(append(w.pending, '\n'))
+	w.pending = nil
+}
+
+// writeLines writes newline terminated lines to out, each one prefixed.
+func (w *prefixedWriter) writeLines(lines []byte) {
+	var prefixed bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			prefixed.WriteString(w.prefix)
+			prefixed.Write(line)
+		}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	// Output errors must not fail the command, which keeps running
+	_, _ = w.out.Write(prefixed.Bytes())
+}
+
+// extractExecWorktreesOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractExecWorktreesOptions(opts []ExecWorktreesOpts) ExecWorktreesOpts {
+	var result ExecWorktreesOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if opt.All {
+			result.All = opt.All
+		}
+		if opt.Jobs != 0 {
+			result.Jobs = opt.Jobs
+		}
+		if opt.Output != nil {
+			result.Output = opt.Output
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"bytes"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/fs"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_ExecWorktrees_Repository(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+	repoURL := "github.com/octocat/Hello-World"
+	featurePath := cm.BuildWorktreePath(repoURL, "origin", "feature")
+	brokenPath := cm.BuildWorktreePath(repoURL, "origin", "broken")
+
+	expectHooks(mocks, consts.ExecWorktrees)
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  repoURL,
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+		Path: "/test/repo",
+		Worktrees: map[string]status.WorktreeInfo{
+			"origin:feature": {Remote: "origin", Branch: "feature"},
+			"origin:broken":  {Remote: "origin", Branch: "broken"},
+		},
+	}, nil)
+	mocks.fs.EXPECT().RunCommand(gomock.Any()).DoAndReturn(func(params fs.RunCommandParams) (int, error) {
+		assert.Equal(t, "go", params.Command)
+		assert.Equal(t, []string{"test", "./..."}, params.Args)
+		if params.Dir == brokenPath {
+			fmt.Fprint(params.Output, "FAIL\nexit status 1")
+			return 1, nil
+		}
+		fmt.Fprintln(params.Output, "ok")
+		return 0, nil
+	}).Times(2)
+
+	var output bytes.Buffer
+	results, err := cm.ExecWorktrees([]string{"go", "test", "./..."}, ExecWorktreesOpts{
+		RepositoryName: "Hello-World",
+		Output:         &output,
+	})
+	assert.ErrorIs(t, err, ErrExecIncomplete)
+	assert.Equal(t, []WorktreeExecResult{
+		{RepoURL: repoURL, Branch: "broken", Path: brokenPath, State: WorktreeExecFailed, ExitCode: 1,
+			Output: "FAIL\nexit status 1"},
+		{RepoURL: repoURL, Branch: "feature", Path: featurePath, State: WorktreeExecPassed, Output: "ok\n"},
+	}, results)
+
+	// Each line is prefixed, including the last one that has no newline
+	assert.Contains(t, output.String(), "[github.com/octocat/Hello-World broken] FAIL\n")
+	assert.Contains(t, output.String(), "[github.com/octocat/Hello-World broken] exit status 1\n")
+	assert.Contains(t, output.String(), "[github.com/octocat/Hello-World feature] ok\n")
+}
+
+func TestCM_ExecWorktrees_All(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	expectHooks(mocks, consts.ExecWorktrees)
+	worktrees := map[string]status.WorktreeInfo{}
+	for _, branch := range []string{"a", "b", "c", "d", "e"} {
+		worktrees["origin:"+branch] = status.WorktreeInfo{Remote: "origin", Branch: branch}
+	}
+	mocks.status.EXPECT().ListRepositories().Return(map[string]status.Repository{
+		"github.com/octocat/Hello-World": {Path: "/test/repo", Worktrees: worktrees},
+		"github.com/octocat/Spoon-Knife": {Path: "/test/other", Worktrees: map[string]status.WorktreeInfo{
+			"origin:main": {Remote: "origin", Branch: "main"},
+		}},
+	}, nil)
+
+	// No more commands than jobs run at the same time
+	var running, maxRunning atomic.Int32
+	mocks.fs.EXPECT().RunCommand(gomock.Any()).DoAndReturn(func(fs.RunCommandParams) (int, error) {
+		current := running.Add(1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return 0, nil
+	}).Times(6)
+
+	results, err := cm.ExecWorktrees([]string{"git", "pull"}, ExecWorktreesOpts{All: true, Jobs: 2})
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+	assert.Equal(t, "a", results[0].Branch)
+	assert.Equal(t, "github.com/octocat/Spoon-Knife", results[5].RepoURL)
+	for _, result := range results {
+		assert.Equal(t, WorktreeExecPassed, result.State)
+	}
+}
+
+func TestCM_ExecWorktrees_InvalidOptions(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	_, err := cm.ExecWorktrees(nil, ExecWorktreesOpts{All: true})
+	assert.ErrorIs(t, err, ErrExecNoCommand)
+
+	_, err = cm.ExecWorktrees([]string{"make"}, ExecWorktreesOpts{All: true, RepositoryName: "Hello-World"})
+	assert.ErrorIs(t, err, ErrExecAllWithTarget)
+
+	_, err = cm.ExecWorktrees([]string{"make"}, ExecWorktreesOpts{All: true, Jobs: -1})
+	assert.ErrorIs(t, err, ErrInvalidExecJobs)
+}
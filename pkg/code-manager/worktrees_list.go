@@ -3,6 +3,10 @@ package codemanager
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/lerenn/code-manager/pkg/code-manager/consts"
 	"github.com/lerenn/code-manager/pkg/mode"
@@ -13,8 +17,12 @@ import (
 
 // ListWorktreesOpts contains options for ListWorktrees.
 type ListWorktreesOpts struct {
-	WorkspaceName  string // Name of the workspace to list worktrees for (optional)
-	RepositoryName string // Name of the repository to list worktrees for (optional)
+	WorkspaceName  string   // Name of the workspace to list worktrees for (optional)
+	RepositoryName string   // Name of the repository to list worktrees for (optional)
+	Refresh        bool     // Re-query the forge for the state of linked issues and pull requests
+	Labels         []string // Only list worktrees with all these labels, set on them or on their linked issue
+	Milestone      string   // Only list worktrees whose linked issue is in this milestone
+	Grep           string   // Only list worktrees whose branch, labels, note or issue title match this regexp
 }
 
 // ListWorktrees lists worktrees for a workspace or repository.
@@ -27,6 +35,15 @@ func (c *realCodeManager) ListWorktrees(opts ...ListWorktreesOpts) ([]status.Wor
 		return nil, fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
 	}
 
+	// Compile the pattern before any prompt, matching is case-insensitive
+	var grep *regexp.Regexp
+	if options.Grep != "" {
+		var err error
+		if grep, err = regexp.Compile("(?i)" + options.Grep); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidGrepPattern, err)
+		}
+	}
+
 	// Handle interactive selection if neither workspace nor repository is specified
 	if options.WorkspaceName == "" && options.RepositoryName == "" {
 		if err := c.handleInteractiveTargetSelectionForList(&options); err != nil {
@@ -38,6 +55,10 @@ func (c *realCodeManager) ListWorktrees(opts ...ListWorktreesOpts) ([]status.Wor
 	params := map[string]interface{}{
 		"workspace_name":  options.WorkspaceName,
 		"repository_name": options.RepositoryName,
+		"refresh":         options.Refresh,
+		"labels":          options.Labels,
+		"milestone":       options.Milestone,
+		"grep":            options.Grep,
 	}
 
 	// Execute with hooks
@@ -52,12 +73,57 @@ func (c *realCodeManager) ListWorktrees(opts ...ListWorktreesOpts) ([]status.Wor
 			return fmt.Errorf("failed to detect project mode: %w", err)
 		}
 
+		// Refresh the forge state before listing so that the listing reflects it
+		var staleIssues map[string]bool
+		if options.Refresh {
+			if staleIssues, err = c.refreshForgeState(projectType, options); err != nil {
+				return err
+			}
+		}
+
 		result, err = c.handleWorktreeListingByMode(projectType, options)
+		markStaleIssues(result, staleIssues)
+		result = filterWorktrees(result, options.Labels, options.Milestone, grep)
 		return err
 	})
 	return result, err
 }
 
+// filterWorktrees keeps the worktrees that have all the labels (set on them or on their linked issue),
+// whose linked issue is in the milestone and that match the pattern. Worktrees are not filtered when
+// neither labels, milestone nor pattern are given.
+func filterWorktrees(
+	worktrees []status.WorktreeInfo, labels []string, milestone string, grep *regexp.Regexp,
+) []status.WorktreeInfo {
+	if len(labels) == 0 && milestone == "" && grep == nil {
+		return worktrees
+	}
+
+	filtered := make([]status.WorktreeInfo, 0, len(worktrees))
+	for _, worktree := range worktrees {
+		if milestone != "" && (worktree.Issue == nil || !strings.EqualFold(worktree.Issue.Milestone, milestone)) {
+			continue
+		}
+		if slices.ContainsFunc(labels, func(label string) bool { return !worktree.HasLabel(label) }) {
+			continue
+		}
+		if grep != nil && !matchesWorktree(grep, worktree) {
+			continue
+		}
+		filtered = append(filtered, worktree)
+	}
+	return filtered
+}
+
+// matchesWorktree reports whether the pattern matches the branch, labels, note or issue title of the worktree.
+func matchesWorktree(grep *regexp.Regexp, worktree status.WorktreeInfo) bool {
+	fields := append([]string{worktree.Branch, worktree.Note}, worktree.Labels...)
+	if worktree.Issue != nil {
+		fields = append(fields, worktree.Issue.Title)
+	}
+	return slices.ContainsFunc(fields, grep.MatchString)
+}
+
 // listWorkspaceWorktrees lists all worktrees associated with a workspace.
 func (c *realCodeManager) listWorkspaceWorktrees(workspaceName string) ([]status.WorktreeInfo, error) {
 	c.VerbosePrint("Listing worktrees for workspace: %s", workspaceName)
@@ -160,6 +226,18 @@ func (c *realCodeManager) extractListWorktreesOptions(opts []ListWorktreesOpts)
 		if opt.RepositoryName != "" {
 			result.RepositoryName = opt.RepositoryName
 		}
+		if opt.Refresh {
+			result.Refresh = opt.Refresh
+		}
+		if len(opt.Labels) > 0 {
+			result.Labels = opt.Labels
+		}
+		if opt.Milestone != "" {
+			result.Milestone = opt.Milestone
+		}
+		if opt.Grep != "" {
+			result.Grep = opt.Grep
+		}
 	}
 
 	return result
@@ -211,3 +289,65 @@ func (c *realCodeManager) handleWorktreeListingByMode(
 		return nil, fmt.Errorf("unknown project type")
 	}
 }
+
+// targetRepository holds a repository of the target of an operation along with its worktrees.
+type targetRepository struct {
+	URL        string
+	Repository *status.Repository
+	Worktrees  []status.WorktreeInfo // Sorted by branch
+}
+
+// targetRepositories returns the repositories of the workspace or repository holding worktrees, with
+// their worktrees. Workspaces only hold the worktrees they reference, and only the worktrees of the
+// given branch are kept when it is set. Repositories without any of these worktrees are left out.
+func (c *realCodeManager) targetRepositories(workspaceName, repositoryName, branch string) ([]targetRepository, error) {
+	projectType, err := c.detectProjectMode(workspaceName, repositoryName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect project mode: %w", err)
+	}
+
+	repoURLs, err := c.listedRepositoryURLs(projectType, ListWorktreesOpts{
+		WorkspaceName:  workspaceName,
+		RepositoryName: repositoryName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaceBranches []string
+	if projectType == mode.ModeWorkspace {
+		workspace, err := c.deps.StatusManager.GetWorkspace(workspaceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		workspaceBranches = append([]string{}, workspace.Worktrees...)
+	}
+
+	var targets []targetRepository
+	for _, repoURL := range repoURLs {
+		repository, err := c.deps.StatusManager.GetRepository(repoURL)
+		if err != nil {
+			c.VerbosePrint("Warning: failed to get repository %s: %v", repoURL, err)
+			continue
+		}
+
+		var worktrees []status.WorktreeInfo
+		for _, worktree := range repository.Worktrees {
+			if branch != "" && worktree.Branch != branch {
+				continue
+			}
+			if workspaceBranches != nil && !slices.Contains(workspaceBranches, worktree.Branch) {
+				continue
+			}
+			worktrees = append(worktrees, worktree)
+		}
+		if len(worktrees) == 0 {
+			continue
+		}
+
+		sort.Slice(worktrees, func(i, j int) bool { return worktrees[i].Branch < worktrees[j].Branch })
+		targets = append(targets, targetRepository{URL: repoURL, Repository: repository, Worktrees: worktrees})
+	}
+
+	return targets, nil
+}
@@ -4,6 +4,7 @@ package codemanager
 
 import (
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/lerenn/code-manager/pkg/code-manager/consts"
@@ -12,6 +13,7 @@ import (
 	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
 	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
 	hooksMocks "github.com/lerenn/code-manager/pkg/hooks/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
 	"github.com/lerenn/code-manager/pkg/mode/repository"
 	repositoryMocks "github.com/lerenn/code-manager/pkg/mode/repository/mocks"
@@ -288,3 +290,52 @@ func TestListWorktrees_RepositoryFallback(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedWorktrees, result)
 }
+
+func TestFilterWorktrees(t *testing.T) {
+	bug := status.WorktreeInfo{Branch: "fix/1-login", Issue: &issue.Info{
+		Number: 1, Title: "Login fails", Labels: []string{"Bug", "ui"}, Milestone: "v1.2",
+	}}
+	feature := status.WorktreeInfo{Branch: "feat/2-dark-mode", Issue: &issue.Info{
+		Number: 2, Labels: []string{"enhancement", "ui"}, Milestone: "v2.0",
+	}, Labels: []string{"review"}}
+	noIssue := status.WorktreeInfo{Branch: "chore", Labels: []string{"Review"}, Note: "Bump the linters"}
+	worktrees := []status.WorktreeInfo{bug, feature, noIssue}
+
+	tests := []struct {
+		name      string
+		labels    []string
+		milestone string
+		grep      string
+		expected  []status.WorktreeInfo
+	}{
+		{name: "no filter", expected: worktrees},
+		{name: "label", labels: []string{"ui"}, expected: []status.WorktreeInfo{bug, feature}},
+		{name: "labels are all required", labels: []string{"bug", "ui"}, expected: []status.WorktreeInfo{bug}},
+		{name: "worktree label", labels: []string{"review"}, expected: []status.WorktreeInfo{feature, noIssue}},
+		{name: "worktree and issue labels", labels: []string{"review", "ui"}, expected: []status.WorktreeInfo{feature}},
+		{name: "milestone", milestone: "v2.0", expected: []status.WorktreeInfo{feature}},
+		{name: "label and milestone", labels: []string{"bug"}, milestone: "v2.0", expected: []status.WorktreeInfo{}},
+		{name: "grep branch", grep: "dark", expected: []status.WorktreeInfo{feature}},
+		{name: "grep note", grep: "^bump", expected: []status.WorktreeInfo{noIssue}},
+		{name: "grep issue title", grep: "login", expected: []status.WorktreeInfo{bug}},
+		{name: "grep and label", grep: "o", labels: []string{"review"}, expected: []status.WorktreeInfo{feature, noIssue}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var grep *regexp.Regexp
+			if tt.grep != "" {
+				grep = regexp.MustCompile("(?i)" + tt.grep)
+			}
+			assert.Equal(t, tt.expected, filterWorktrees(worktrees, tt.labels, tt.milestone, grep))
+		})
+	}
+}
+
+func TestCM_ListWorktrees_InvalidGrep(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	// The pattern is checked before anything is listed
+	_, err := cm.ListWorktrees(ListWorktreesOpts{RepositoryName: "Hello-World", Grep: "review("})
+	assert.ErrorIs(t, err, ErrInvalidGrepPattern)
+}
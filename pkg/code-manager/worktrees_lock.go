@@ -0,0 +1,221 @@
+package codemanager
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/prompt"
+)
+
+// LockWorktreeOpts contains optional parameters for LockWorktree.
+type LockWorktreeOpts struct {
+	WorkspaceName  string // Name of the workspace holding the worktree (optional)
+	RepositoryName string // Name of the repository holding the worktree (optional)
+	Reason         string // Why the worktree is locked (optional)
+}
+
+// UnlockWorktreeOpts contains optional parameters for UnlockWorktree.
+type UnlockWorktreeOpts struct {
+	WorkspaceName  string // Name of the workspace holding the worktree (optional)
+	RepositoryName string // Name of the repository holding the worktree (optional)
+}
+
+// LockWorktree locks the worktrees of a branch, in git and in the status file. Locked worktrees are
+// skipped by delete-all, prune and gc, and deleting one directly needs force.
+func (c *realCodeManager) LockWorktree(branch string, opts ...LockWorktreeOpts) error {
+	// Parse options
+	options := c.extractLockWorktreeOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+
+	// Handle interactive selection if neither workspace nor repository is specified
+	if options.WorkspaceName == "" && options.RepositoryName == "" {
+		selectedBranch, err := c.handleInteractiveSelectionForWorktree(branch, &options.WorkspaceName,
+			&options.RepositoryName)
+		if err != nil {
+			return err
+		}
+		branch = selectedBranch
+	}
+	if branch == "" {
+		return fmt.Errorf("%w: branch", ErrArgumentEmpty)
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"branch":          branch,
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+		"reason":          options.Reason,
+	}
+
+	// Execute with hooks
+	return c.executeWithHooks(consts.LockWorktree, params, func() error {
+		return c.setWorktreeLock(branch, options.WorkspaceName, options.RepositoryName, true, options.Reason)
+	})
+}
+
+// UnlockWorktree unlocks the worktrees of a branch, in git and in the status file.
+func (c *realCodeManager) UnlockWorktree(branch string, opts ...UnlockWorktreeOpts) error {
+	// Parse options
+	options := c.extractUnlockWorktreeOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+
+	// Handle interactive selection if neither workspace nor repository is specified
+	if options.WorkspaceName == "" && options.RepositoryName == "" {
+		selectedBranch, err := c.handleInteractiveSelectionForWorktree(branch, &options.WorkspaceName,
+			&options.RepositoryName)
+		if err != nil {
+			return err
+		}
+		branch = selectedBranch
+	}
+	if branch == "" {
+		return fmt.Errorf("%w: branch", ErrArgumentEmpty)
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"branch":          branch,
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+	}
+
+	// Execute with hooks
+	return c.executeWithHooks(consts.UnlockWorktree, params, func() error {
+		return c.setWorktreeLock(branch, options.WorkspaceName, options.RepositoryName, false, "")
+	})
+}
+
+// handleInteractiveSelectionForWorktree prompts for the target, and for the worktree when no branch is given.
+// It returns the selected branch.
+func (c *realCodeManager) handleInteractiveSelectionForWorktree(
+	branch string, workspaceName, repositoryName *string,
+) (string, error) {
+	var (
+		result TargetSelectionResult
+		err    error
+	)
+	if branch == "" {
+		result, err = c.promptSelectTargetAndWorktree()
+	} else {
+		result, err = c.promptSelectTargetOnly()
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to select target: %w", err)
+	}
+
+	switch result.Type {
+	case prompt.TargetWorkspace:
+		*workspaceName = result.Name
+	case prompt.TargetRepository:
+		*repositoryName = result.Name
+	default:
+		return "", fmt.Errorf("invalid target type selected: %s", result.Type)
+	}
+
+	if branch == "" {
+		return result.Worktree, nil
+	}
+	return branch, nil
+}
+
+// setWorktreeLock locks or unlocks the worktrees of the branch in every repository of the target.
+func (c *realCodeManager) setWorktreeLock(
+	branch, workspaceName, repositoryName string, locked bool, reason string,
+) error {
+	targets, err := c.targetRepositories(workspaceName, repositoryName, branch)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("%w: %s", ErrWorktreeNotInStatus, branch)
+	}
+
+	for _, target := range targets {
+		for _, worktree := range target.Worktrees {
+			worktreePath := c.BuildWorktreePath(target.URL, worktree.Remote, worktree.Branch)
+
+			// Standalone clones are not known by git worktree, the status file is enough
+			if !worktree.Detached {
+				if err := c.setGitWorktreeLock(target.Repository.Path, worktreePath, worktree.Locked, locked,
+					reason); err != nil {
+					return err
+				}
+			}
+
+			worktree.Locked = locked
+			worktree.LockReason = reason
+			if err := c.deps.StatusManager.UpdateWorktree(target.URL, worktree.Branch, worktree); err != nil {
+				return fmt.Errorf("%w: %w", ErrStatusUpdate, err)
+			}
+			c.VerbosePrint("Set lock of worktree %s of repository %s to %t", worktree.Branch, target.URL, locked)
+		}
+	}
+
+	return nil
+}
+
+// setGitWorktreeLock locks or unlocks the worktree in git. Locked worktrees are unlocked first so that
+// their reason is updated, and failing to unlock is only a warning as the lock may have been removed already.
+func (c *realCodeManager) setGitWorktreeLock(
+	repoPath, worktreePath string, wasLocked, locked bool, reason string,
+) error {
+	if wasLocked || !locked {
+		if err := c.deps.Git.UnlockWorktree(repoPath, worktreePath); err != nil {
+			c.VerbosePrint("Warning: failed to unlock worktree %s in git: %v", worktreePath, err)
+		}
+	}
+	if !locked {
+		return nil
+	}
+
+	if err := c.deps.Git.LockWorktree(repoPath, worktreePath, reason); err != nil {
+		return fmt.Errorf("failed to lock worktree %s: %w", worktreePath, err)
+	}
+	return nil
+}
+
+// extractLockWorktreeOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractLockWorktreeOptions(opts []LockWorktreeOpts) LockWorktreeOpts {
+	var result LockWorktreeOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if opt.Reason != "" {
+			result.Reason = opt.Reason
+		}
+	}
+
+	return result
+}
+
+// extractUnlockWorktreeOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractUnlockWorktreeOptions(opts []UnlockWorktreeOpts) UnlockWorktreeOpts {
+	var result UnlockWorktreeOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+	}
+
+	return result
+}
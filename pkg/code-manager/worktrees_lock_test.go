@@ -0,0 +1,100 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func expectSingleWorktreeRepository(mocks testMocks, repoURL string, worktree status.WorktreeInfo) {
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  repoURL,
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+		Path:      "/test/repo",
+		Worktrees: map[string]status.WorktreeInfo{"origin:" + worktree.Branch: worktree},
+	}, nil)
+}
+
+func TestCM_LockWorktree(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+	worktreePath := cm.BuildWorktreePath(repoURL, "origin", "feature")
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{Remote: "origin", Branch: "feature"})
+	mocks.git.EXPECT().LockWorktree("/test/repo", worktreePath, "release candidate").Return(nil)
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "feature", status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Locked: true, LockReason: "release candidate",
+	}).Return(nil)
+
+	err := cm.LockWorktree("feature", LockWorktreeOpts{RepositoryName: "Hello-World", Reason: "release candidate"})
+	assert.NoError(t, err)
+}
+
+func TestCM_LockWorktree_UpdatesReason(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+	worktreePath := cm.BuildWorktreePath(repoURL, "origin", "feature")
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Locked: true, LockReason: "old",
+	})
+
+	// Git refuses to lock twice, the worktree is unlocked first
+	gomock.InOrder(
+		mocks.git.EXPECT().UnlockWorktree("/test/repo", worktreePath).Return(nil),
+		mocks.git.EXPECT().LockWorktree("/test/repo", worktreePath, "new").Return(nil),
+	)
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "feature", status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Locked: true, LockReason: "new",
+	}).Return(nil)
+
+	err := cm.LockWorktree("feature", LockWorktreeOpts{RepositoryName: "Hello-World", Reason: "new"})
+	assert.NoError(t, err)
+}
+
+func TestCM_LockWorktree_NotInStatus(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(consts.LockWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{Remote: "origin", Branch: "feature"})
+
+	err := cm.LockWorktree("unknown", LockWorktreeOpts{RepositoryName: "Hello-World"})
+	assert.ErrorIs(t, err, ErrWorktreeNotInStatus)
+}
+
+func TestCM_UnlockWorktree(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+	worktreePath := cm.BuildWorktreePath(repoURL, "origin", "feature")
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.UnlockWorktree, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.UnlockWorktree, gomock.Any()).Return(nil)
+	expectSingleWorktreeRepository(mocks, repoURL, status.WorktreeInfo{
+		Remote: "origin", Branch: "feature", Locked: true, LockReason: "release candidate",
+	})
+
+	// The status file is updated even when git has no lock anymore
+	mocks.git.EXPECT().UnlockWorktree("/test/repo", worktreePath).Return(errors.New("not locked"))
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "feature", status.WorktreeInfo{
+		Remote: "origin", Branch: "feature",
+	}).Return(nil)
+
+	err := cm.UnlockWorktree("feature", UnlockWorktreeOpts{RepositoryName: "Hello-World"})
+	assert.NoError(t, err)
+}
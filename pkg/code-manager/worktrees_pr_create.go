@@ -0,0 +1,222 @@
+package codemanager
+
+import (
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/issue"
+	repo "github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// defaultPullRequestBaseBranch is used when neither options nor status provide a base branch.
+const defaultPullRequestBaseBranch = "main"
+
+// CreatePullRequestOpts contains optional parameters for CreatePullRequest.
+type CreatePullRequestOpts struct {
+	RepositoryName string // Name of the repository holding the worktree (optional)
+	Title          string // Pull request title (defaults to the linked issue title, then the branch name)
+	Body           string // Pull request body (defaults to a reference to the linked issue)
+	BaseBranch     string // Branch to merge into (defaults to the origin default branch)
+}
+
+// CreatePullRequest pushes a worktree branch and opens a pull request for it.
+func (c *realCodeManager) CreatePullRequest(
+	branch string, opts ...CreatePullRequestOpts,
+) (*pullrequest.Info, error) {
+	// Parse options
+	options := c.extractCreatePullRequestOptions(opts)
+
+	// Handle interactive selection if no branch is specified
+	if branch == "" {
+		result, err := c.promptSelectTargetAndWorktree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to select target and worktree: %w", err)
+		}
+
+		switch result.Type {
+		case prompt.TargetRepository:
+			options.RepositoryName = result.Name
+		case prompt.TargetWorkspace:
+			return nil, ErrPullRequestWorkspaceNotSupported
+		default:
+			return nil, fmt.Errorf("invalid target type selected: %s", result.Type)
+		}
+
+		branch = result.Worktree
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"branch":          branch,
+		"repository_name": options.RepositoryName,
+		"title":           options.Title,
+		"base_branch":     options.BaseBranch,
+	}
+
+	// Execute with hooks
+	var prInfo *pullrequest.Info
+	err := c.executeWithHooks(consts.CreatePullRequest, params, func() error {
+		var err error
+		prInfo, err = c.createPullRequestForWorktree(branch, options)
+		if err == nil {
+			params["pull_request_url"] = prInfo.URL
+		}
+		return err
+	})
+	return prInfo, err
+}
+
+// createPullRequestForWorktree pushes the worktree branch, opens the pull request and records it in status.
+func (c *realCodeManager) createPullRequestForWorktree(
+	branch string, options CreatePullRequestOpts,
+) (*pullrequest.Info, error) {
+	c.VerbosePrint("Creating pull request for branch: %s", branch)
+
+	repoInstance := c.deps.RepositoryProvider(repo.NewRepositoryParams{
+		Dependencies:   c.deps,
+		RepositoryName: options.RepositoryName,
+	})
+
+	// Validate repository and get repository URL
+	validationResult, err := repoInstance.ValidateRepository(repo.ValidationParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate repository: %w", err)
+	}
+	repoURL := validationResult.RepoURL
+
+	// Check if the worktree exists in the status file
+	worktreeInfo, err := c.deps.StatusManager.GetWorktree(repoURL, branch)
+	if err != nil {
+		return nil, ErrWorktreeNotInStatus
+	}
+	if worktreeInfo.PullRequest != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPullRequestAlreadyExists, worktreeInfo.PullRequest.URL)
+	}
+
+	// Push the branch with upstream tracking so the forge can see it
+	worktreePath := c.BuildWorktreePath(repoURL, worktreeInfo.Remote, branch)
+	if err := c.deps.Git.SetUpstreamBranch(worktreePath, worktreeInfo.Remote, branch); err != nil {
+		return nil, fmt.Errorf("failed to set upstream branch: %w", err)
+	}
+	if err := c.deps.Git.Push(git.PushParams{
+		RepoPath:    worktreePath,
+		RemoteName:  worktreeInfo.Remote,
+		Branch:      branch,
+		SetUpstream: true,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to push branch: %w", err)
+	}
+
+	// Get the appropriate forge for the repository
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return nil, err
+	}
+	selectedForge, err := forgeManager.GetForgeForRepository(validationResult.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forge for repository: %w", err)
+	}
+
+	// Branches pushed to a fork remote are named after the fork owner
+	headOwner := ""
+	if worktreeInfo.Remote != repo.DefaultRemote {
+		headOwner = worktreeInfo.Remote
+	}
+
+	title, body := defaultPullRequestContent(branch, worktreeInfo, options)
+	prInfo, err := selectedForge.CreatePullRequest(forge.CreatePullRequestParams{
+		RepoPath:   validationResult.RepoPath,
+		HeadOwner:  headOwner,
+		HeadBranch: branch,
+		BaseBranch: c.pullRequestBaseBranch(repoURL, options),
+		Title:      title,
+		Body:       body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	// Store the pull request back into the status file
+	worktreeInfo.PullRequest = prInfo
+	if err := c.deps.StatusManager.UpdateWorktree(repoURL, branch, *worktreeInfo); err != nil {
+		return nil, fmt.Errorf("pull request %s created but failed to update status: %w", prInfo.URL, err)
+	}
+
+	return prInfo, nil
+}
+
+// pullRequestBaseBranch returns the base branch from options, falling back to the origin default branch.
+func (c *realCodeManager) pullRequestBaseBranch(repoURL string, options CreatePullRequestOpts) string {
+	if options.BaseBranch != "" {
+		return options.BaseBranch
+	}
+
+	repository, err := c.deps.StatusManager.GetRepository(repoURL)
+	if err == nil && repository != nil {
+		if remote, ok := repository.Remotes[repo.DefaultRemote]; ok && remote.DefaultBranch != "" {
+			return remote.DefaultBranch
+		}
+	}
+
+	return defaultPullRequestBaseBranch
+}
+
+// defaultPullRequestContent returns the pull request title and body, defaulting from the linked issue.
+func defaultPullRequestContent(
+	branch string, worktreeInfo *status.WorktreeInfo, options CreatePullRequestOpts,
+) (string, string) {
+	title, body := branch, ""
+	if worktreeInfo.Issue != nil {
+		title = worktreeInfo.Issue.Title
+		body = pullRequestIssueReference(*worktreeInfo.Issue)
+	}
+
+	if options.Title != "" {
+		title = options.Title
+	}
+	if options.Body != "" {
+		body = options.Body
+	}
+
+	return title, body
+}
+
+// pullRequestIssueReference returns the pull request body referencing the issue.
+// Forge issues are closed by the pull request, while the forge cannot close issues of a tracker.
+func pullRequestIssueReference(linkedIssue issue.Info) string {
+	if linkedIssue.Key == "" {
+		return fmt.Sprintf("Closes #%d", linkedIssue.Number)
+	}
+	if linkedIssue.URL == "" {
+		return fmt.Sprintf("Related to %s", linkedIssue.DisplayID())
+	}
+	return fmt.Sprintf("Related to %s (%s)", linkedIssue.DisplayID(), linkedIssue.URL)
+}
+
+// extractCreatePullRequestOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractCreatePullRequestOptions(opts []CreatePullRequestOpts) CreatePullRequestOpts {
+	var result CreatePullRequestOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if opt.Title != "" {
+			result.Title = opt.Title
+		}
+		if opt.Body != "" {
+			result.Body = opt.Body
+		}
+		if opt.BaseBranch != "" {
+			result.BaseBranch = opt.BaseBranch
+		}
+	}
+
+	return result
+}
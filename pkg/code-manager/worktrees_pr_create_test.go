@@ -0,0 +1,171 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/hooks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_CreatePullRequest_WorktreeNotInStatus(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.CreatePullRequest)
+
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  "github.com/octocat/Hello-World",
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetWorktree("github.com/octocat/Hello-World", "feature").
+		Return(nil, status.ErrWorktreeNotFound)
+
+	_, err := cm.CreatePullRequest("feature")
+	assert.ErrorIs(t, err, ErrWorktreeNotInStatus)
+}
+
+func TestCM_CreatePullRequest_AlreadyExists(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.CreatePullRequest)
+
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  "github.com/octocat/Hello-World",
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetWorktree("github.com/octocat/Hello-World", "feature").Return(&status.WorktreeInfo{
+		Remote:      "origin",
+		Branch:      "feature",
+		PullRequest: &pullrequest.Info{Number: 3, URL: "https://github.com/octocat/Hello-World/pull/3"},
+	}, nil)
+
+	_, err := cm.CreatePullRequest("feature")
+	assert.ErrorIs(t, err, ErrPullRequestAlreadyExists)
+}
+
+func TestCM_CreatePullRequest_PushFailure(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.CreatePullRequest)
+
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  "github.com/octocat/Hello-World",
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetWorktree("github.com/octocat/Hello-World", "feature").Return(&status.WorktreeInfo{
+		Remote: "origin",
+		Branch: "feature",
+	}, nil)
+	mocks.git.EXPECT().SetUpstreamBranch(gomock.Any(), "origin", "feature").Return(nil)
+	pushErr := errors.New("push rejected")
+	mocks.git.EXPECT().Push(gomock.Any()).DoAndReturn(func(params git.PushParams) error {
+		assert.Equal(t, "origin", params.RemoteName)
+		assert.Equal(t, "feature", params.Branch)
+		assert.True(t, params.SetUpstream)
+		return pushErr
+	})
+	mocks.status.EXPECT().UpdateWorktree(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := cm.CreatePullRequest("feature", CreatePullRequestOpts{RepositoryName: "Hello-World"})
+	assert.ErrorIs(t, err, pushErr)
+}
+
+func TestCM_CreatePullRequest(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+
+	repoURL := "github.com/octocat/Hello-World"
+	linkedIssue := &issue.Info{Number: 123, Title: "Fix login bug"}
+	worktreeInfo := status.WorktreeInfo{Remote: "origin", Branch: "123-fix-login-bug", Issue: linkedIssue}
+	prInfo := &pullrequest.Info{Number: 7, URL: "https://github.com/octocat/Hello-World/pull/7"}
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.CreatePullRequest, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.CreatePullRequest, gomock.Any()).
+		DoAndReturn(func(_ string, ctx *hooks.HookContext) error {
+			assert.Equal(t, prInfo.URL, ctx.Parameters["pull_request_url"])
+			return nil
+		})
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  repoURL,
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetWorktree(repoURL, "123-fix-login-bug").Return(&worktreeInfo, nil)
+	mocks.git.EXPECT().SetUpstreamBranch(gomock.Any(), "origin", "123-fix-login-bug").Return(nil)
+	mocks.git.EXPECT().Push(gomock.Any()).Return(nil)
+	mocks.forgeManager.EXPECT().GetForgeForRepository("/test/repo").Return(mocks.forge, nil)
+
+	// Title and body default to the linked issue, the base to the origin default branch
+	mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+		Remotes: map[string]status.Remote{"origin": {DefaultBranch: "develop"}},
+	}, nil)
+	mocks.forge.EXPECT().CreatePullRequest(forge.CreatePullRequestParams{
+		RepoPath:   "/test/repo",
+		HeadBranch: "123-fix-login-bug",
+		BaseBranch: "develop",
+		Title:      "Fix login bug",
+		Body:       "Closes #123",
+	}).Return(prInfo, nil)
+
+	// The pull request is stored with the worktree
+	mocks.status.EXPECT().UpdateWorktree(repoURL, "123-fix-login-bug", status.WorktreeInfo{
+		Remote: "origin", Branch: "123-fix-login-bug", Issue: linkedIssue, PullRequest: prInfo,
+	}).Return(nil)
+
+	result, err := cm.CreatePullRequest("123-fix-login-bug")
+	assert.NoError(t, err)
+	assert.Equal(t, prInfo, result)
+}
+
+func TestDefaultPullRequestContent(t *testing.T) {
+	linkedIssue := &issue.Info{Number: 123, Title: "Fix login bug"}
+
+	tests := []struct {
+		name          string
+		worktreeInfo  status.WorktreeInfo
+		options       CreatePullRequestOpts
+		expectedTitle string
+		expectedBody  string
+	}{
+		{
+			name:          "no linked issue",
+			worktreeInfo:  status.WorktreeInfo{Branch: "feature"},
+			expectedTitle: "feature",
+			expectedBody:  "",
+		},
+		{
+			name:          "linked issue",
+			worktreeInfo:  status.WorktreeInfo{Branch: "123-fix-login-bug", Issue: linkedIssue},
+			expectedTitle: "Fix login bug",
+			expectedBody:  "Closes #123",
+		},
+		{
+			name: "linked tracker issue",
+			worktreeInfo: status.WorktreeInfo{Branch: "PROJ-123-fix-login-bug", Issue: &issue.Info{
+				Key: "PROJ-123", Title: "Fix login bug", URL: "https://jira.example.com/browse/PROJ-123",
+			}},
+			expectedTitle: "Fix login bug",
+			expectedBody:  "Related to PROJ-123 (https://jira.example.com/browse/PROJ-123)",
+		},
+		{
+			name:          "explicit title and body",
+			worktreeInfo:  status.WorktreeInfo{Branch: "123-fix-login-bug", Issue: linkedIssue},
+			options:       CreatePullRequestOpts{Title: "Custom", Body: "Details"},
+			expectedTitle: "Custom",
+			expectedBody:  "Details",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := defaultPullRequestContent(tt.worktreeInfo.Branch, &tt.worktreeInfo, tt.options)
+			assert.Equal(t, tt.expectedTitle, title)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
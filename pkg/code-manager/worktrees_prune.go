@@ -0,0 +1,361 @@
+package codemanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/git"
+	repo "github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// PruneCandidate is a worktree that can be pruned, with the reasons why.
+type PruneCandidate struct {
+	RepoURL       string
+	RepoPath      string
+	WorkspaceName string // Workspace referencing the worktree, whose worktrees of the branch are deleted together
+	Worktree      status.WorktreeInfo
+	Reasons       []string // e.g. "merged into origin/main", "upstream deleted", "idle for 45 days"
+}
+
+// PruneWorktreesOpts contains optional parameters for PlanPruneWorktrees.
+type PruneWorktreesOpts struct {
+	WorkspaceName  string        // Name of the workspace to prune worktrees from (optional)
+	RepositoryName string        // Name of the repository to prune worktrees from (optional)
+	IdleAfter      time.Duration // Prune worktrees without commits for this long (disabled when zero)
+}
+
+// PlanPruneWorktrees finds the worktrees of a workspace or repository that can be pruned: those whose
+// branch is merged into its base (including squash merges and merged pull requests), whose upstream
+// was deleted, or that have been idle for longer than IdleAfter. Worktrees with uncommitted changes are
+// never pruned. Worktrees of a workspace are only pruned when the branch can be pruned in all its repositories.
+func (c *realCodeManager) PlanPruneWorktrees(opts ...PruneWorktreesOpts) ([]PruneCandidate, error) {
+	// Parse options
+	options := c.extractPruneWorktreesOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return nil, fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+
+	// Handle interactive selection if neither workspace nor repository is specified
+	if options.WorkspaceName == "" && options.RepositoryName == "" {
+		if err := c.handleInteractiveTargetSelectionForPrune(&options); err != nil {
+			return nil, err
+		}
+	}
+
+	targets, err := c.targetRepositories(options.WorkspaceName, options.RepositoryName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []PruneCandidate
+	for _, target := range targets {
+		// Prune the remote-tracking branches so that deleted upstreams are known
+		if err := c.deps.Git.FetchRemote(target.Repository.Path, repo.DefaultRemote,
+			git.FetchRemoteOpts{Prune: true}); err != nil {
+			c.VerbosePrint("Warning: failed to fetch %s for repository %s: %v", repo.DefaultRemote, target.URL, err)
+		}
+
+		selectedForge := c.pruneForge(target)
+		for _, worktree := range target.Worktrees {
+			reasons := c.pruneReasons(target, worktree, selectedForge, options.IdleAfter)
+			if len(reasons) == 0 {
+				continue
+			}
+			candidates = append(candidates, PruneCandidate{
+				RepoURL:  target.URL,
+				RepoPath: target.Repository.Path,
+				Worktree: worktree,
+				Reasons:  reasons,
+			})
+		}
+	}
+
+	if options.WorkspaceName != "" {
+		candidates = workspacePruneCandidates(options.WorkspaceName, targets, candidates)
+	}
+	return candidates, nil
+}
+
+// workspacePruneCandidates keeps the candidates whose branch can be pruned in every repository of the
+// workspace holding it, since the worktrees of a workspace are deleted together.
+func workspacePruneCandidates(
+	workspaceName string, targets []targetRepository, candidates []PruneCandidate,
+) []PruneCandidate {
+	worktreeCount := make(map[string]int)
+	for _, target := range targets {
+		for _, worktree := range target.Worktrees {
+			worktreeCount[worktree.Branch]++
+		}
+	}
+	candidateCount := make(map[string]int)
+	for _, candidate := range candidates {
+		candidateCount[candidate.Worktree.Branch]++
+	}
+
+	var kept []PruneCandidate
+	for _, candidate := range candidates {
+		if candidateCount[candidate.Worktree.Branch] != worktreeCount[candidate.Worktree.Branch] {
+			continue
+		}
+		candidate.WorkspaceName = workspaceName
+		kept = append(kept, candidate)
+	}
+	return kept
+}
+
+// PruneWorktrees deletes the planned worktrees through DeleteWorkTree, after confirmation unless forced.
+// Worktrees of a workspace are deleted from all its repositories at once, along with their workspace file.
+func (c *realCodeManager) PruneWorktrees(candidates []PruneCandidate, force bool) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Prepare parameters for hooks
+	branches := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		branches = append(branches, candidate.Worktree.Branch)
+	}
+	params := map[string]interface{}{
+		"branches": branches,
+		"force":    force,
+	}
+
+	// Execute with hooks
+	return c.executeWithHooks(consts.PruneWorktrees, params, func() error {
+		if !force {
+			confirmed, err := c.deps.Prompt.PromptForConfirmation(
+				fmt.Sprintf("Delete these %d worktrees?", len(candidates)), false)
+			if err != nil {
+				return fmt.Errorf("failed to get confirmation: %w", err)
+			}
+			if !confirmed {
+				return ErrDeletionCancelled
+			}
+		}
+
+		var errs []error
+		deletedWorkspaceWorktrees := make(map[string]bool)
+		for _, candidate := range candidates {
+			if err := c.pruneWorktree(candidate, deletedWorkspaceWorktrees); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("some worktrees failed to delete: %v", errs)
+		}
+		return nil
+	})
+}
+
+// pruneWorktree deletes the worktree of a candidate, or the worktrees of its branch in every repository
+// of its workspace unless they were deleted already.
+func (c *realCodeManager) pruneWorktree(candidate PruneCandidate, deletedWorkspaceWorktrees map[string]bool) error {
+	// The worktrees are clean and the deletion was confirmed already
+	if candidate.WorkspaceName == "" {
+		c.VerbosePrint("Pruning worktree %s of repository %s", candidate.Worktree.Branch, candidate.RepoURL)
+		if err := c.DeleteWorkTree(candidate.Worktree.Branch, true,
+			DeleteWorktreeOpts{RepositoryName: candidate.RepoPath}); err != nil {
+			return fmt.Errorf("failed to delete worktree %s of %s: %w", candidate.Worktree.Branch, candidate.RepoURL, err)
+		}
+		return nil
+	}
+
+	if deletedWorkspaceWorktrees[candidate.Worktree.Branch] {
+		return nil
+	}
+	deletedWorkspaceWorktrees[candidate.Worktree.Branch] = true
+
+	c.VerbosePrint("Pruning worktree %s of workspace %s", candidate.Worktree.Branch, candidate.WorkspaceName)
+	if err := c.deleteWorkspaceWorktree(candidate.WorkspaceName, candidate.Worktree, true); err != nil {
+		return fmt.Errorf("failed to delete worktree %s of workspace %s: %w",
+			candidate.Worktree.Branch, candidate.WorkspaceName, err)
+	}
+	return nil
+}
+
+// handleInteractiveTargetSelectionForPrune handles interactive target selection for prune.
+func (c *realCodeManager) handleInteractiveTargetSelectionForPrune(options *PruneWorktreesOpts) error {
+	result, err := c.promptSelectTargetOnly()
+	if err != nil {
+		return fmt.Errorf("failed to select target: %w", err)
+	}
+
+	switch result.Type {
+	case prompt.TargetWorkspace:
+		options.WorkspaceName = result.Name
+	case prompt.TargetRepository:
+		options.RepositoryName = result.Name
+	default:
+		return fmt.Errorf("invalid target type selected: %s", result.Type)
+	}
+
+	return nil
+}
+
+// pruneForge returns the forge of the repository when some of its worktrees have a pull request,
+// so that their current state can be checked. It returns nil when the forge is not needed or unavailable.
+func (c *realCodeManager) pruneForge(target targetRepository) forge.Forge {
+	hasPullRequest := false
+	for _, worktree := range target.Worktrees {
+		hasPullRequest = hasPullRequest || worktree.PullRequest != nil
+	}
+	if !hasPullRequest {
+		return nil
+	}
+
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		c.VerbosePrint("Warning: failed to create forge manager: %v", err)
+		return nil
+	}
+	selectedForge, err := forgeManager.GetForgeForRepository(target.URL)
+	if err != nil {
+		c.VerbosePrint("Warning: failed to get forge for repository %s: %v", target.URL, err)
+		return nil
+	}
+	return selectedForge
+}
+
+// pruneReasons returns why the worktree can be pruned, or nothing when it must be kept.
+func (c *realCodeManager) pruneReasons(
+	target targetRepository, worktree status.WorktreeInfo, selectedForge forge.Forge, idleAfter time.Duration,
+) []string {
+	if worktree.Locked {
+		c.VerbosePrint("Skipping worktree %s: locked", worktree.Branch)
+		return nil
+	}
+	if worktree.DetachedHead {
+		c.VerbosePrint("Skipping worktree %s: detached HEAD", worktree.Branch)
+		return nil
+	}
+	worktreePath := c.BuildWorktreePath(target.URL, worktree.Remote, worktree.Branch)
+	if exists, err := c.deps.FS.Exists(worktreePath); err != nil || !exists {
+		c.VerbosePrint("Skipping worktree %s: directory not found: %s", worktree.Branch, worktreePath)
+		return nil
+	}
+	if changes, err := c.deps.Git.GetWorkingTreeStatus(worktreePath); err != nil || changes.HasChanges() {
+		c.VerbosePrint("Skipping worktree %s: uncommitted changes", worktree.Branch)
+		return nil
+	}
+
+	var reasons []string
+	if c.isPullRequestMerged(worktree, selectedForge) {
+		reasons = append(reasons, fmt.Sprintf("pull request #%d merged", worktree.PullRequest.Number))
+	}
+	if reason := c.mergedReason(target.Repository, worktree, worktreePath); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if isGone, err := c.deps.Git.IsUpstreamGone(worktreePath, worktree.Branch); err != nil {
+		c.VerbosePrint("Warning: failed to check upstream of worktree %s: %v", worktree.Branch, err)
+	} else if isGone {
+		reasons = append(reasons, "upstream deleted")
+	}
+	if idleAfter > 0 {
+		if reason := c.idleReason(worktree, worktreePath, idleAfter); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return reasons
+}
+
+// isPullRequestMerged checks the state of the pull request of the worktree on the forge,
+// falling back to the state stored in the status file.
+func (c *realCodeManager) isPullRequestMerged(worktree status.WorktreeInfo, selectedForge forge.Forge) bool {
+	if worktree.PullRequest == nil {
+		return false
+	}
+	if selectedForge != nil {
+		info, err := selectedForge.GetPullRequestInfo(pullRequestReference(*worktree.PullRequest),
+			forge.GetPullRequestInfoOpts{AllowClosed: true})
+		if err == nil {
+			return info.State == pullrequest.StateMerged
+		}
+		c.VerbosePrint("Warning: failed to refresh pull request #%d of worktree %s: %v",
+			worktree.PullRequest.Number, worktree.Branch, err)
+	}
+	return worktree.PullRequest.State == pullrequest.StateMerged
+}
+
+// mergedReason reports whether the worktree branch was merged or squash-merged into its base.
+// Branches without commits beyond their base are only merged when commits were made on them,
+// so that freshly created worktrees and worktrees left behind by their base are kept.
+func (c *realCodeManager) mergedReason(
+	repository *status.Repository, worktree status.WorktreeInfo, worktreePath string,
+) string {
+	base := c.worktreeBase(repository, worktree, worktreePath)
+	if base == "" {
+		return ""
+	}
+
+	ahead, _, err := c.deps.Git.CountAheadBehind(worktreePath, base, "HEAD")
+	if err != nil {
+		c.VerbosePrint("Warning: failed to compare worktree %s with %s: %v", worktree.Branch, base, err)
+		return ""
+	}
+	if ahead == 0 {
+		hasCommits, err := c.deps.Git.HasBranchCommits(worktreePath, worktree.Branch)
+		if err != nil {
+			c.VerbosePrint("Warning: failed to check commits of worktree %s: %v", worktree.Branch, err)
+			return ""
+		}
+		if hasCommits {
+			return "merged into " + base
+		}
+		return ""
+	}
+
+	isSquashMerged, err := c.deps.Git.IsSquashMerged(worktreePath, base, "HEAD")
+	if err != nil {
+		c.VerbosePrint("Warning: failed to check squash merge of worktree %s: %v", worktree.Branch, err)
+		return ""
+	}
+	if isSquashMerged {
+		return "squash-merged into " + base
+	}
+	return ""
+}
+
+// idleReason reports whether the last commit of the worktree is older than idleAfter.
+func (c *realCodeManager) idleReason(
+	worktree status.WorktreeInfo, worktreePath string, idleAfter time.Duration,
+) string {
+	lastCommit, err := c.deps.Git.GetLastCommit(worktreePath)
+	if err != nil {
+		c.VerbosePrint("Warning: failed to get last commit of worktree %s: %v", worktree.Branch, err)
+		return ""
+	}
+
+	idle := time.Since(lastCommit.Date)
+	if idle <= idleAfter {
+		return ""
+	}
+	return fmt.Sprintf("idle for %d days", int(idle/(24*time.Hour)))
+}
+
+// extractPruneWorktreesOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractPruneWorktreesOptions(opts []PruneWorktreesOpts) PruneWorktreesOpts {
+	var result PruneWorktreesOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if opt.IdleAfter != 0 {
+			result.IdleAfter = opt.IdleAfter
+		}
+	}
+
+	return result
+}
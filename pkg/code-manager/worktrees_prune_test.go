@@ -0,0 +1,219 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/forge"
+	forgemocks "github.com/lerenn/code-manager/pkg/forge/mocks"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_PlanPruneWorktrees(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURL := "github.com/octocat/Hello-World"
+	mockForge := forgemocks.NewMockForge(gomock.NewController(t))
+
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  repoURL,
+		RepoPath: "/test/repo",
+	}, nil)
+	pullRequest := &pullrequest.Info{Number: 7, State: pullrequest.StateOpen, URL: "https://github.com/o/r/pull/7"}
+	mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+		Path:    "/test/repo",
+		Remotes: map[string]status.Remote{"origin": {DefaultBranch: "main"}},
+		Worktrees: map[string]status.WorktreeInfo{
+			"origin:active":   {Remote: "origin", Branch: "active"},
+			"origin:behind":   {Remote: "origin", Branch: "behind"},
+			"detached:v2.3.1": {Remote: "detached", Branch: "v2.3.1", DetachedHead: true, Commit: "1a2b3c4d"},
+			"origin:dirty":    {Remote: "origin", Branch: "dirty"},
+			"origin:fresh":    {Remote: "origin", Branch: "fresh"},
+			"origin:gone":     {Remote: "origin", Branch: "gone"},
+			"origin:idle":     {Remote: "origin", Branch: "idle"},
+			"origin:locked":   {Remote: "origin", Branch: "locked", Locked: true},
+			"origin:merged":   {Remote: "origin", Branch: "merged"},
+			"origin:reviewed": {Remote: "origin", Branch: "reviewed", PullRequest: pullRequest},
+			"origin:squashed": {Remote: "origin", Branch: "squashed"},
+		},
+	}, nil)
+	mocks.git.EXPECT().FetchRemote("/test/repo", "origin", git.FetchRemoteOpts{Prune: true}).Return(nil)
+	mocks.forgeManager.EXPECT().GetForgeForRepository(repoURL).Return(mockForge, nil)
+	mockForge.EXPECT().GetPullRequestInfo(pullRequest.URL, forge.GetPullRequestInfoOpts{AllowClosed: true}).
+		Return(&pullrequest.Info{Number: 7, State: pullrequest.StateMerged}, nil)
+
+	pathOf := func(branch string) gomock.Matcher {
+		return gomock.Cond(func(path any) bool { return strings.HasSuffix(path.(string), "/origin/"+branch) })
+	}
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+	mocks.git.EXPECT().GetWorkingTreeStatus(pathOf("dirty")).Return(&git.WorkingTreeStatus{Untracked: 1}, nil)
+	mocks.git.EXPECT().GetWorkingTreeStatus(gomock.Any()).Return(&git.WorkingTreeStatus{}, nil).AnyTimes()
+	mocks.git.EXPECT().IsUpstreamGone(gomock.Any(), "gone").Return(true, nil)
+	mocks.git.EXPECT().IsUpstreamGone(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	// Ahead and behind counts against the default branch
+	counts := map[string][2]int{
+		"active": {2, 0}, "behind": {0, 4}, "fresh": {0, 0}, "gone": {1, 0}, "idle": {1, 0},
+		"merged": {0, 3}, "reviewed": {1, 1}, "squashed": {2, 5},
+	}
+	for branch, count := range counts {
+		mocks.git.EXPECT().CountAheadBehind(pathOf(branch), "origin/main", "HEAD").Return(count[0], count[1], nil)
+	}
+	mocks.git.EXPECT().IsSquashMerged(pathOf("squashed"), "origin/main", "HEAD").Return(true, nil)
+	mocks.git.EXPECT().IsSquashMerged(gomock.Any(), "origin/main", "HEAD").Return(false, nil).AnyTimes()
+
+	// Branches without commits beyond their base are only merged when commits were made on them
+	mocks.git.EXPECT().HasBranchCommits(pathOf("merged"), "merged").Return(true, nil)
+	mocks.git.EXPECT().HasBranchCommits(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	// Only the idle worktree has no recent commit
+	mocks.git.EXPECT().GetLastCommit(pathOf("idle")).
+		Return(&git.CommitInfo{Date: time.Now().Add(-45 * 24 * time.Hour)}, nil)
+	mocks.git.EXPECT().GetLastCommit(gomock.Any()).Return(&git.CommitInfo{Date: time.Now()}, nil).AnyTimes()
+
+	candidates, err := cm.PlanPruneWorktrees(PruneWorktreesOpts{
+		RepositoryName: "Hello-World",
+		IdleAfter:      30 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	reasons := make(map[string][]string)
+	for _, candidate := range candidates {
+		assert.Equal(t, repoURL, candidate.RepoURL)
+		assert.Equal(t, "/test/repo", candidate.RepoPath)
+		reasons[candidate.Worktree.Branch] = candidate.Reasons
+	}
+	assert.Equal(t, map[string][]string{
+		"gone":     {"upstream deleted"},
+		"idle":     {"idle for 45 days"},
+		"merged":   {"merged into origin/main"},
+		"reviewed": {"pull request #7 merged"},
+		"squashed": {"squash-merged into origin/main"},
+	}, reasons)
+}
+
+func TestCM_PlanPruneWorktrees_Workspace(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURLs := []string{"github.com/o/api", "github.com/o/web"}
+
+	mocks.status.EXPECT().GetWorkspace("my-workspace").Return(&status.Workspace{
+		Repositories: repoURLs,
+		Worktrees:    []string{"merged", "partial"},
+	}, nil).AnyTimes()
+	for _, repoURL := range repoURLs {
+		mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+			Path:    "/test/" + repoURL,
+			Remotes: map[string]status.Remote{"origin": {DefaultBranch: "main"}},
+			Worktrees: map[string]status.WorktreeInfo{
+				"origin:merged":  {Remote: "origin", Branch: "merged"},
+				"origin:partial": {Remote: "origin", Branch: "partial"},
+			},
+		}, nil)
+	}
+	mocks.git.EXPECT().FetchRemote(gomock.Any(), "origin", git.FetchRemoteOpts{Prune: true}).Return(nil).Times(2)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+	mocks.git.EXPECT().GetWorkingTreeStatus(gomock.Any()).Return(&git.WorkingTreeStatus{}, nil).AnyTimes()
+	mocks.git.EXPECT().IsUpstreamGone(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mocks.git.EXPECT().IsSquashMerged(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mocks.git.EXPECT().HasBranchCommits(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+
+	// The partial branch still has commits of its own in the web repository
+	mocks.git.EXPECT().CountAheadBehind(
+		gomock.Cond(func(path any) bool { return strings.HasSuffix(path.(string), "web/origin/partial") }),
+		"origin/main", "HEAD").Return(2, 0, nil)
+	mocks.git.EXPECT().CountAheadBehind(gomock.Any(), "origin/main", "HEAD").Return(0, 1, nil).Times(3)
+
+	candidates, err := cm.PlanPruneWorktrees(PruneWorktreesOpts{WorkspaceName: "my-workspace"})
+	require.NoError(t, err)
+
+	require.Len(t, candidates, 2)
+	for i, candidate := range candidates {
+		assert.Equal(t, repoURLs[i], candidate.RepoURL)
+		assert.Equal(t, "my-workspace", candidate.WorkspaceName)
+		assert.Equal(t, "merged", candidate.Worktree.Branch)
+	}
+}
+
+func TestCM_PruneWorktrees(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	candidates := []PruneCandidate{
+		{RepoURL: "github.com/o/r", RepoPath: "/test/repo", Worktree: status.WorktreeInfo{Branch: "merged"}},
+		{RepoURL: "github.com/o/r", RepoPath: "/test/repo", Worktree: status.WorktreeInfo{Branch: "gone"}},
+	}
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+	mocks.prompt.EXPECT().PromptForConfirmation("Delete these 2 worktrees?", false).Return(true, nil)
+
+	// Each worktree goes through the deletion with its own hooks
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil).Times(2)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.DeleteWorkTree, gomock.Any()).Return(nil).Times(2)
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil).Times(2)
+	mocks.repository.EXPECT().DeleteWorktree("merged", true).Return(nil)
+	mocks.repository.EXPECT().DeleteWorktree("gone", true).Return(nil)
+
+	assert.NoError(t, cm.PruneWorktrees(candidates, false))
+}
+
+func TestCM_PruneWorktrees_Cancelled(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	candidates := []PruneCandidate{
+		{RepoURL: "github.com/o/r", RepoPath: "/test/repo", Worktree: status.WorktreeInfo{Branch: "merged"}},
+	}
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecuteErrorHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+	mocks.prompt.EXPECT().PromptForConfirmation(gomock.Any(), false).Return(false, nil)
+	mocks.repository.EXPECT().DeleteWorktree(gomock.Any(), gomock.Any()).Times(0)
+
+	assert.ErrorIs(t, cm.PruneWorktrees(candidates, false), ErrDeletionCancelled)
+}
+
+func TestCM_PruneWorktrees_Workspace(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	repoURLs := []string{"github.com/o/api", "github.com/o/web"}
+	worktree := status.WorktreeInfo{Remote: "origin", Branch: "merged"}
+	candidates := []PruneCandidate{
+		{RepoURL: repoURLs[0], RepoPath: "/test/api", WorkspaceName: "my-workspace", Worktree: worktree},
+		{RepoURL: repoURLs[1], RepoPath: "/test/web", WorkspaceName: "my-workspace", Worktree: worktree},
+	}
+
+	mocks.hookManager.EXPECT().ExecutePreHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+	mocks.hookManager.EXPECT().ExecutePostHooks(consts.PruneWorktrees, gomock.Any()).Return(nil)
+
+	// The worktrees of the branch are deleted once from all the repositories of the workspace
+	mocks.status.EXPECT().GetWorkspace("my-workspace").DoAndReturn(func(string) (*status.Workspace, error) {
+		return &status.Workspace{Repositories: repoURLs, Worktrees: []string{"merged", "active"}}, nil
+	}).Times(2)
+	for _, repoURL := range repoURLs {
+		mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+			Path:      "/test/" + repoURL,
+			Worktrees: map[string]status.WorktreeInfo{"origin:merged": worktree},
+		}, nil).AnyTimes()
+		mocks.git.EXPECT().WorktreeExists("/test/"+repoURL, "merged").Return(true, nil)
+		mocks.git.EXPECT().RemoveWorktree("/test/"+repoURL, gomock.Any(), true).Return(nil)
+		mocks.status.EXPECT().RemoveWorktree(repoURL, "merged").Return(nil)
+	}
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	// The workspace file of the worktree and its reference in the workspace are removed
+	mocks.fs.EXPECT().Remove(gomock.Cond(func(path any) bool {
+		return strings.HasSuffix(path.(string), "my-workspace/merged.code-workspace")
+	})).Return(nil)
+	mocks.status.EXPECT().UpdateWorkspace("my-workspace", status.Workspace{
+		Repositories: repoURLs,
+		Worktrees:    []string{"active"},
+	}).Return(nil)
+
+	assert.NoError(t, cm.PruneWorktrees(candidates, true))
+}
@@ -0,0 +1,210 @@
+package codemanager
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/mode"
+	repo "github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/tracker"
+)
+
+// refreshForgeState re-queries the forge for the issues and pull requests linked to the worktrees
+// of the listed repositories, and stores their current state in the status file.
+// Failures are reported as warnings so that listing still works offline. It returns the references
+// of the issues only available from the issue cache, which are left untouched in the status file.
+func (c *realCodeManager) refreshForgeState(projectType mode.Mode, options ListWorktreesOpts) (map[string]bool, error) {
+	repoURLs, err := c.listedRepositoryURLs(projectType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	forgeManager, err := c.newForgeManager()
+	if err != nil {
+		return nil, err
+	}
+
+	trackerManager, err := c.newTrackerManager()
+	if err != nil {
+		return nil, err
+	}
+
+	staleIssues := make(map[string]bool)
+	for _, repoURL := range repoURLs {
+		c.refreshRepositoryForgeState(forgeManager, trackerManager, repoURL, staleIssues)
+	}
+
+	return staleIssues, nil
+}
+
+// markStaleIssues flags the listed worktrees whose issue could only be refreshed from the issue cache.
+func markStaleIssues(worktrees []status.WorktreeInfo, staleIssues map[string]bool) {
+	for i, worktree := range worktrees {
+		if worktree.Issue == nil || !staleIssues[issueReference(*worktree.Issue)] {
+			continue
+		}
+		staleIssue := *worktree.Issue
+		staleIssue.Stale = true
+		worktrees[i].Issue = &staleIssue
+	}
+}
+
+// listedRepositoryURLs returns the URLs of the repositories whose worktrees are listed.
+func (c *realCodeManager) listedRepositoryURLs(projectType mode.Mode, options ListWorktreesOpts) ([]string, error) {
+	switch projectType {
+	case mode.ModeSingleRepo:
+		repositoryName := options.RepositoryName
+		if repositoryName == "" {
+			repositoryName = "."
+		}
+		repoInstance := c.deps.RepositoryProvider(repo.NewRepositoryParams{
+			Dependencies:   c.deps,
+			RepositoryName: repositoryName,
+		})
+		validationResult, err := repoInstance.ValidateRepository(repo.ValidationParams{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate repository: %w", err)
+		}
+		return []string{validationResult.RepoURL}, nil
+	case mode.ModeWorkspace:
+		workspace, err := c.deps.StatusManager.GetWorkspace(options.WorkspaceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		return workspace.Repositories, nil
+	case mode.ModeNone:
+		return nil, ErrNoGitRepositoryOrWorkspaceFound
+	default:
+		return nil, fmt.Errorf("unknown project type")
+	}
+}
+
+// refreshRepositoryForgeState refreshes the issues and pull requests linked to the worktrees of a repository,
+// recording the references of the issues that are stale into staleIssues.
+func (c *realCodeManager) refreshRepositoryForgeState(
+	forgeManager forge.ManagerInterface, trackerManager tracker.ManagerInterface,
+	repoURL string, staleIssues map[string]bool,
+) {
+	repository, err := c.deps.StatusManager.GetRepository(repoURL)
+	if err != nil {
+		c.VerbosePrint("Warning: failed to get repository %s: %v", repoURL, err)
+		return
+	}
+
+	// Only worktrees linked to an issue or a pull request need the forge
+	var keys []string
+	for key, worktree := range repository.Worktrees {
+		if worktree.Issue != nil || worktree.PullRequest != nil {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+
+	selectedForge, err := forgeManager.GetForgeForRepository(repoURL)
+	if err != nil {
+		c.VerbosePrint("Warning: failed to get forge for repository %s: %v", repoURL, err)
+		return
+	}
+
+	for _, key := range keys {
+		worktree := repository.Worktrees[key]
+		refreshed, changed := c.refreshWorktreeForgeState(selectedForge, worktree,
+			refreshWorktreeForgeStateOpts{IssueTracker: c.worktreeIssueTracker(trackerManager, worktree)})
+		if refreshed.Issue != nil && refreshed.Issue.Stale {
+			staleIssues[issueReference(*refreshed.Issue)] = true
+		}
+		if !changed {
+			continue
+		}
+		if err := c.deps.StatusManager.UpdateWorktree(repoURL, worktree.Branch, refreshed); err != nil {
+			c.VerbosePrint("Warning: failed to update worktree %s: %v", worktree.Branch, err)
+		}
+	}
+}
+
+// worktreeIssueTracker returns the issue tracker of the issue linked to the worktree,
+// or nil when the worktree has no issue tracker issue.
+func (c *realCodeManager) worktreeIssueTracker(
+	trackerManager tracker.ManagerInterface, worktree status.WorktreeInfo,
+) tracker.IssueTracker {
+	if worktree.Issue == nil || worktree.Issue.Key == "" {
+		return nil
+	}
+
+	issueTracker, err := trackerManager.GetTrackerForIssue(issueReference(*worktree.Issue))
+	if err != nil {
+		c.VerbosePrint("Warning: failed to get issue tracker for issue %s: %v", worktree.Issue.Key, err)
+		return nil
+	}
+	return issueTracker
+}
+
+// refreshWorktreeForgeStateOpts contains optional parameters for refreshWorktreeForgeState.
+type refreshWorktreeForgeStateOpts struct {
+	IssueTracker tracker.IssueTracker // Tracker of the linked issue, when it does not live on the forge
+}
+
+// refreshWorktreeForgeState fetches the current state of the issue and pull request linked to a worktree.
+// It returns the updated worktree and whether anything changed. Issues served from the issue cache
+// because the forge is unreachable are returned flagged as stale without being counted as changes.
+func (c *realCodeManager) refreshWorktreeForgeState(
+	selectedForge forge.Forge, worktree status.WorktreeInfo, opts ...refreshWorktreeForgeStateOpts,
+) (status.WorktreeInfo, bool) {
+	changed := false
+
+	if worktree.Issue != nil {
+		var source issueSource = selectedForge
+		if len(opts) > 0 && opts[0].IssueTracker != nil {
+			source = opts[0].IssueTracker
+		}
+
+		// Refreshing must reach the forge, cached issues are only used when it is unreachable
+		info, err := source.GetIssueInfo(issueReference(*worktree.Issue),
+			forge.GetIssueInfoOpts{AllowClosed: true, SkipCache: true})
+		switch {
+		case err != nil:
+			c.VerbosePrint("Warning: failed to refresh issue %s of worktree %s: %v",
+				worktree.Issue.DisplayID(), worktree.Branch, err)
+		case info.Stale:
+			staleIssue := *worktree.Issue
+			staleIssue.Stale = true
+			worktree.Issue = &staleIssue
+		case !reflect.DeepEqual(*worktree.Issue, *info):
+			worktree.Issue = info
+			changed = true
+		}
+	}
+
+	if worktree.PullRequest != nil {
+		info, err := selectedForge.GetPullRequestInfo(pullRequestReference(*worktree.PullRequest),
+			forge.GetPullRequestInfoOpts{AllowClosed: true})
+		if err != nil {
+			c.VerbosePrint("Warning: failed to refresh pull request #%d of worktree %s: %v",
+				worktree.PullRequest.Number, worktree.Branch, err)
+		} else if info.State != worktree.PullRequest.State || info.Title != worktree.PullRequest.Title {
+			// Keep the recorded head repository, which is gone once a fork is deleted
+			refreshed := *worktree.PullRequest
+			refreshed.State = info.State
+			refreshed.Title = info.Title
+			worktree.PullRequest = &refreshed
+			changed = true
+		}
+	}
+
+	return worktree, changed
+}
+
+// pullRequestReference returns a reference that the forges can resolve back to the pull request.
+func pullRequestReference(info pullrequest.Info) string {
+	if info.URL != "" {
+		return info.URL
+	}
+	return fmt.Sprintf("%s/%s#%d", info.Owner, info.Repository, info.Number)
+}
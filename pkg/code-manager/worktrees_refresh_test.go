@@ -0,0 +1,128 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/dependencies"
+	"github.com/lerenn/code-manager/pkg/forge"
+	forgemocks "github.com/lerenn/code-manager/pkg/forge/mocks"
+	hooksMocks "github.com/lerenn/code-manager/pkg/hooks/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+	statusmocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// TestListWorktrees_Refresh tests that linked issues and pull requests are refreshed before listing.
+func TestListWorktrees_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockStatus := statusmocks.NewMockManager(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+	mockForgeManager := forgemocks.NewMockManagerInterface(ctrl)
+	mockForge := forgemocks.NewMockForge(ctrl)
+
+	cm := &realCodeManager{
+		deps: dependencies.New().
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithStatusManager(mockStatus).
+			WithHookManager(mockHookManager).
+			WithForgeProvider(func(_ logger.Logger, _ status.Manager, _ config.Config) forge.ManagerInterface {
+				return mockForgeManager
+			}),
+	}
+
+	mockHookManager.EXPECT().ExecutePreHooks(gomock.Any(), gomock.Any()).Return(nil)
+	mockHookManager.EXPECT().ExecutePostHooks(gomock.Any(), gomock.Any()).Return(nil)
+
+	openIssue := &issue.Info{Number: 12, Title: "Fix login", State: issue.StateOpen, URL: "https://github.com/o/r/issues/12"}
+	unchangedIssue := &issue.Info{Number: 13, State: issue.StateOpen, URL: "https://github.com/o/r/issues/13"}
+	openPR := &pullrequest.Info{Number: 3, Title: "Add feature", State: pullrequest.StateOpen,
+		URL: "https://github.com/o/r/pull/3", HeadOwner: "contributor"}
+	repo := &status.Repository{
+		Worktrees: map[string]status.WorktreeInfo{
+			"origin:12-fix-login": {Remote: "origin", Branch: "12-fix-login", Issue: openIssue},
+			"origin:add-feature":  {Remote: "origin", Branch: "add-feature", PullRequest: openPR},
+			"origin:unchanged":    {Remote: "origin", Branch: "unchanged", Issue: unchangedIssue},
+			"origin:plain":        {Remote: "origin", Branch: "plain"},
+		},
+	}
+	mockStatus.EXPECT().GetWorkspace("test-workspace").Return(&status.Workspace{
+		Worktrees:    []string{"12-fix-login"},
+		Repositories: []string{"github.com/o/r"},
+	}, nil).Times(2)
+	mockStatus.EXPECT().GetRepository("github.com/o/r").Return(repo, nil).AnyTimes()
+	mockForgeManager.EXPECT().GetForgeForRepository("github.com/o/r").Return(mockForge, nil)
+
+	closedIssue := *openIssue
+	closedIssue.State = issue.StateClosed
+	mockForge.EXPECT().GetIssueInfo(openIssue.URL, forge.GetIssueInfoOpts{AllowClosed: true, SkipCache: true}).
+		Return(&closedIssue, nil)
+	mockForge.EXPECT().GetIssueInfo(unchangedIssue.URL, gomock.Any()).Return(unchangedIssue, nil)
+	mockForge.EXPECT().GetPullRequestInfo(openPR.URL, forge.GetPullRequestInfoOpts{AllowClosed: true}).
+		Return(&pullrequest.Info{Number: 3, Title: "Add feature", State: pullrequest.StateMerged}, nil)
+
+	mockStatus.EXPECT().UpdateWorktree("github.com/o/r", "12-fix-login", gomock.Any()).
+		DoAndReturn(func(_, _ string, worktree status.WorktreeInfo) error {
+			assert.Equal(t, issue.StateClosed, worktree.Issue.State)
+			return nil
+		})
+	mockStatus.EXPECT().UpdateWorktree("github.com/o/r", "add-feature", gomock.Any()).
+		DoAndReturn(func(_, _ string, worktree status.WorktreeInfo) error {
+			assert.Equal(t, pullrequest.StateMerged, worktree.PullRequest.State)
+			assert.Equal(t, "contributor", worktree.PullRequest.HeadOwner)
+			return nil
+		})
+
+	_, err := cm.ListWorktrees(ListWorktreesOpts{WorkspaceName: "test-workspace", Refresh: true})
+	assert.NoError(t, err)
+}
+
+// TestRefreshWorktreeForgeState_ForgeError tests that forge errors leave the worktree untouched.
+func TestRefreshWorktreeForgeState_ForgeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockForge := forgemocks.NewMockForge(ctrl)
+	cm := &realCodeManager{deps: dependencies.New()}
+
+	worktree := status.WorktreeInfo{
+		Branch: "12-fix-login",
+		Issue:  &issue.Info{Number: 12, State: issue.StateOpen, URL: "https://github.com/o/r/issues/12"},
+	}
+	mockForge.EXPECT().GetIssueInfo(gomock.Any(), gomock.Any()).Return(nil, errors.New("offline"))
+
+	refreshed, changed := cm.refreshWorktreeForgeState(mockForge, worktree)
+	assert.False(t, changed)
+	assert.Equal(t, worktree, refreshed)
+}
+
+// TestRefreshWorktreeForgeState_StaleIssue tests that issues served from the offline cache
+// are flagged as stale without being stored.
+func TestRefreshWorktreeForgeState_StaleIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockForge := forgemocks.NewMockForge(ctrl)
+	cm := &realCodeManager{deps: dependencies.New()}
+
+	linkedIssue := &issue.Info{Number: 12, State: issue.StateOpen, URL: "https://github.com/o/r/issues/12"}
+	worktree := status.WorktreeInfo{Branch: "12-fix-login", Issue: linkedIssue}
+	mockForge.EXPECT().GetIssueInfo(linkedIssue.URL, gomock.Any()).Return(&issue.Info{
+		Number: 12, State: issue.StateClosed, URL: linkedIssue.URL, Stale: true,
+	}, nil)
+
+	refreshed, changed := cm.refreshWorktreeForgeState(mockForge, worktree)
+	assert.False(t, changed)
+	assert.True(t, refreshed.Issue.Stale)
+	assert.Equal(t, issue.StateOpen, refreshed.Issue.State)
+	assert.False(t, linkedIssue.Stale, "the listed worktree must not be modified")
+
+	listed := []status.WorktreeInfo{worktree, {Branch: "plain"}}
+	markStaleIssues(listed, map[string]bool{linkedIssue.URL: true})
+	assert.True(t, listed[0].Issue.Stale)
+	assert.Nil(t, listed[1].Issue)
+}
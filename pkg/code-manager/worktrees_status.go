@@ -0,0 +1,161 @@
+package codemanager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// worktreeStatusConcurrency bounds the number of worktrees queried at the same time.
+const worktreeStatusConcurrency = 8
+
+// WorktreeStatus describes the state of a worktree as reported by git.
+type WorktreeStatus struct {
+	RepoURL    string
+	Worktree   status.WorktreeInfo
+	Path       string
+	Missing    bool                   // The worktree directory does not exist
+	Changes    *git.WorkingTreeStatus // File counts and position against the upstream
+	Base       string                 // Ref the worktree is based on, empty when unknown
+	AheadBase  int                    // Commits not in the base
+	BehindBase int                    // Commits of the base not in the worktree
+	LastCommit *git.CommitInfo
+	Errors     []string // Queries that failed, leaving the matching fields unset
+}
+
+// GetWorktreesStatusOpts contains optional parameters for GetWorktreesStatus.
+type GetWorktreesStatusOpts struct {
+	WorkspaceName  string // Name of the workspace to get the worktrees status for (optional)
+	RepositoryName string // Name of the repository to get the worktrees status for (optional)
+}
+
+// GetWorktreesStatus asks git for the state of the worktrees of a workspace or repository.
+// Worktrees are queried concurrently and returned sorted by repository and branch.
+func (c *realCodeManager) GetWorktreesStatus(opts ...GetWorktreesStatusOpts) ([]WorktreeStatus, error) {
+	// Parse options
+	options := c.extractGetWorktreesStatusOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return nil, fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+
+	// Handle interactive selection if neither workspace nor repository is specified
+	if options.WorkspaceName == "" && options.RepositoryName == "" {
+		result, err := c.promptSelectTargetOnly()
+		if err != nil {
+			return nil, fmt.Errorf("failed to select target: %w", err)
+		}
+		switch result.Type {
+		case prompt.TargetWorkspace:
+			options.WorkspaceName = result.Name
+		case prompt.TargetRepository:
+			options.RepositoryName = result.Name
+		default:
+			return nil, fmt.Errorf("invalid target type selected: %s", result.Type)
+		}
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+	}
+
+	// Execute with hooks
+	var result []WorktreeStatus
+	err := c.executeWithHooks(consts.GetWorktreesStatus, params, func() error {
+		targets, err := c.targetRepositories(options.WorkspaceName, options.RepositoryName, "")
+		if err != nil {
+			return err
+		}
+		result = c.collectWorktreesStatus(targets)
+		return nil
+	})
+	return result, err
+}
+
+// collectWorktreesStatus queries the worktrees of the target repositories concurrently.
+func (c *realCodeManager) collectWorktreesStatus(targets []targetRepository) []WorktreeStatus {
+	// Paths are built upfront, as building them reads the configuration
+	var statuses []WorktreeStatus
+	var repositories []*status.Repository
+	for _, target := range targets {
+		for _, worktree := range target.Worktrees {
+			statuses = append(statuses, WorktreeStatus{
+				RepoURL:  target.URL,
+				Worktree: worktree,
+				Path:     c.BuildWorktreePath(target.URL, worktree.Remote, worktree.Branch),
+			})
+			repositories = append(repositories, target.Repository)
+		}
+	}
+
+	c.VerbosePrint("Collecting the status of %d worktrees", len(statuses))
+	semaphore := make(chan struct{}, worktreeStatusConcurrency)
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			c.collectWorktreeStatus(&statuses[i], repositories[i])
+		}()
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// collectWorktreeStatus fills the worktree status from git, recording the queries that fail.
+func (c *realCodeManager) collectWorktreeStatus(worktreeStatus *WorktreeStatus, repository *status.Repository) {
+	if exists, err := c.deps.FS.Exists(worktreeStatus.Path); err != nil || !exists {
+		worktreeStatus.Missing = true
+		return
+	}
+
+	changes, err := c.deps.Git.GetWorkingTreeStatus(worktreeStatus.Path)
+	if err != nil {
+		worktreeStatus.Errors = append(worktreeStatus.Errors, err.Error())
+	} else {
+		worktreeStatus.Changes = changes
+	}
+
+	if base := c.worktreeBase(repository, worktreeStatus.Worktree, worktreeStatus.Path); base != "" {
+		ahead, behind, err := c.deps.Git.CountAheadBehind(worktreeStatus.Path, base, "HEAD")
+		if err != nil {
+			worktreeStatus.Errors = append(worktreeStatus.Errors, err.Error())
+		} else {
+			worktreeStatus.Base, worktreeStatus.AheadBase, worktreeStatus.BehindBase = base, ahead, behind
+		}
+	}
+
+	lastCommit, err := c.deps.Git.GetLastCommit(worktreeStatus.Path)
+	if err != nil {
+		worktreeStatus.Errors = append(worktreeStatus.Errors, err.Error())
+	} else {
+		worktreeStatus.LastCommit = lastCommit
+	}
+}
+
+// extractGetWorktreesStatusOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractGetWorktreesStatusOptions(opts []GetWorktreesStatusOpts) GetWorktreesStatusOpts {
+	var result GetWorktreesStatusOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+	}
+
+	return result
+}
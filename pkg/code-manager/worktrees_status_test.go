@@ -0,0 +1,141 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/dependencies"
+	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	"github.com/lerenn/code-manager/pkg/git"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	hooksMocks "github.com/lerenn/code-manager/pkg/hooks/mocks"
+	"github.com/lerenn/code-manager/pkg/status"
+	statusMocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_GetWorktreesStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithGit(mockGit).
+			WithFS(mockFS).
+			WithStatusManager(mockStatus),
+	})
+	require.NoError(t, err)
+
+	mockHookManager.EXPECT().ExecutePreHooks(consts.GetWorktreesStatus, gomock.Any()).Return(nil)
+	mockHookManager.EXPECT().ExecutePostHooks(consts.GetWorktreesStatus, gomock.Any()).Return(nil)
+
+	mockStatus.EXPECT().GetWorkspace("my-workspace").Return(&status.Workspace{
+		Worktrees:    []string{"feature", "missing"},
+		Repositories: []string{"github.com/o/r"},
+	}, nil).Times(2)
+	mockStatus.EXPECT().GetRepository("github.com/o/r").Return(&status.Repository{
+		Remotes: map[string]status.Remote{"origin": {DefaultBranch: "main"}},
+		Worktrees: map[string]status.WorktreeInfo{
+			"origin:missing":   {Remote: "origin", Branch: "missing"},
+			"origin:feature":   {Remote: "origin", Branch: "feature"},
+			"origin:unrelated": {Remote: "origin", Branch: "unrelated"},
+		},
+	}, nil)
+
+	isWorktree := func(branch string) gomock.Matcher {
+		return gomock.Cond(func(path any) bool { return strings.HasSuffix(path.(string), "/origin/"+branch) })
+	}
+	mockFS.EXPECT().Exists(isWorktree("missing")).Return(false, nil)
+	mockFS.EXPECT().Exists(isWorktree("feature")).Return(true, nil)
+
+	changes := &git.WorkingTreeStatus{Staged: 1, Modified: 2, Untracked: 3, Upstream: "origin/feature", Ahead: 1}
+	mockGit.EXPECT().GetWorkingTreeStatus(isWorktree("feature")).Return(changes, nil)
+	mockGit.EXPECT().CountAheadBehind(isWorktree("feature"), "origin/main", "HEAD").Return(4, 2, nil)
+	mockGit.EXPECT().GetLastCommit(isWorktree("feature")).Return(nil, errors.New("git log failed"))
+
+	statuses, err := cm.GetWorktreesStatus(GetWorktreesStatusOpts{WorkspaceName: "my-workspace"})
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "feature", statuses[0].Worktree.Branch)
+	assert.False(t, statuses[0].Missing)
+	assert.Equal(t, changes, statuses[0].Changes)
+	assert.Equal(t, "origin/main", statuses[0].Base)
+	assert.Equal(t, 4, statuses[0].AheadBase)
+	assert.Equal(t, 2, statuses[0].BehindBase)
+	assert.Nil(t, statuses[0].LastCommit)
+	assert.Equal(t, []string{"git log failed"}, statuses[0].Errors)
+
+	assert.Equal(t, "missing", statuses[1].Worktree.Branch)
+	assert.True(t, statuses[1].Missing)
+	assert.Nil(t, statuses[1].Changes)
+}
+
+func TestCM_GetWorktreesStatus_Concurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockHookManager := hooksMocks.NewMockHookManagerInterface(ctrl)
+
+	cm, err := NewCodeManager(NewCodeManagerParams{
+		Dependencies: dependencies.New().
+			WithHookManager(mockHookManager).
+			WithConfig(config.NewConfigManager("/test/config.yaml")).
+			WithGit(mockGit).
+			WithFS(mockFS).
+			WithStatusManager(mockStatus),
+	})
+	require.NoError(t, err)
+
+	mockHookManager.EXPECT().ExecutePreHooks(gomock.Any(), gomock.Any()).Return(nil)
+	mockHookManager.EXPECT().ExecutePostHooks(gomock.Any(), gomock.Any()).Return(nil)
+
+	const worktreeCount = 50
+	worktrees := make(map[string]status.WorktreeInfo)
+	branches := make([]string, 0, worktreeCount)
+	for i := 0; i < worktreeCount; i++ {
+		branch := fmt.Sprintf("feature-%02d", i)
+		worktrees["origin:"+branch] = status.WorktreeInfo{Remote: "origin", Branch: branch}
+		branches = append(branches, branch)
+	}
+	mockStatus.EXPECT().GetWorkspace("my-workspace").Return(&status.Workspace{
+		Worktrees:    branches,
+		Repositories: []string{"github.com/o/r"},
+	}, nil).Times(2)
+	mockStatus.EXPECT().GetRepository("github.com/o/r").Return(&status.Repository{Worktrees: worktrees}, nil)
+
+	// Slow git queries overlap instead of adding up
+	const queryDuration = 50 * time.Millisecond
+	mockFS.EXPECT().Exists(gomock.Any()).Return(true, nil).Times(worktreeCount)
+	mockGit.EXPECT().GetWorkingTreeStatus(gomock.Any()).DoAndReturn(func(string) (*git.WorkingTreeStatus, error) {
+		time.Sleep(queryDuration)
+		return &git.WorkingTreeStatus{}, nil
+	}).Times(worktreeCount)
+	mockGit.EXPECT().GetLastCommit(gomock.Any()).Return(&git.CommitInfo{Subject: "Commit"}, nil).Times(worktreeCount)
+
+	start := time.Now()
+	statuses, err := cm.GetWorktreesStatus(GetWorktreesStatusOpts{WorkspaceName: "my-workspace"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), worktreeCount*queryDuration/2)
+
+	require.Len(t, statuses, worktreeCount)
+	for i, worktreeStatus := range statuses {
+		assert.Equal(t, branches[i], worktreeStatus.Worktree.Branch)
+		assert.Equal(t, "Commit", worktreeStatus.LastCommit.Subject)
+	}
+}
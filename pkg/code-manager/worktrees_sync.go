@@ -0,0 +1,268 @@
+package codemanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	repo "github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/prompt"
+	"github.com/lerenn/code-manager/pkg/status"
+)
+
+// WorktreeSyncState is the outcome of syncing a worktree.
+type WorktreeSyncState string
+
+// Worktree sync outcomes.
+const (
+	WorktreeSyncUpdated  WorktreeSyncState = "updated"
+	WorktreeSyncUpToDate WorktreeSyncState = "up-to-date"
+	WorktreeSyncSkipped  WorktreeSyncState = "skipped"
+	WorktreeSyncConflict WorktreeSyncState = "conflict"
+	WorktreeSyncFailed   WorktreeSyncState = "failed"
+)
+
+// WorktreeSyncResult reports how a worktree was synced with its base.
+type WorktreeSyncResult struct {
+	RepoURL string
+	Branch  string
+	Base    string // Ref the worktree was rebased onto or merged with
+	State   WorktreeSyncState
+	Message string // Reason of a skipped, conflicting or failed sync
+}
+
+// SyncWorktreesOpts contains optional parameters for SyncWorktrees.
+type SyncWorktreesOpts struct {
+	WorkspaceName  string // Name of the workspace holding the worktrees (optional)
+	RepositoryName string // Name of the repository holding the worktrees (optional)
+	All            bool   // Sync every worktree of the repository or workspace
+	Merge          bool   // Merge the base into the worktrees instead of rebasing them
+}
+
+// SyncWorktrees fetches the remote and rebases (or merges) worktrees onto their base.
+// Worktrees with uncommitted changes are skipped and conflicting syncs are aborted. The results of
+// all worktrees are returned, along with ErrWorktreeSyncIncomplete when some of them could not be synced.
+func (c *realCodeManager) SyncWorktrees(branch string, opts ...SyncWorktreesOpts) ([]WorktreeSyncResult, error) {
+	// Parse options
+	options := c.extractSyncWorktreesOptions(opts)
+
+	// Validate that workspace and repository are not both specified
+	if options.WorkspaceName != "" && options.RepositoryName != "" {
+		return nil, fmt.Errorf("cannot specify both WorkspaceName and RepositoryName")
+	}
+	if branch != "" && options.All {
+		return nil, ErrSyncBranchWithAll
+	}
+
+	// Handle interactive selection if neither a branch nor all worktrees are requested
+	if branch == "" {
+		selectedBranch, err := c.handleInteractiveSelectionForSync(&options)
+		if err != nil {
+			return nil, err
+		}
+		branch = selectedBranch
+	}
+
+	// Prepare parameters for hooks
+	params := map[string]interface{}{
+		"branch":          branch,
+		"workspace_name":  options.WorkspaceName,
+		"repository_name": options.RepositoryName,
+		"all":             options.All,
+		"merge":           options.Merge,
+	}
+
+	// Execute with hooks
+	var results []WorktreeSyncResult
+	err := c.executeWithHooks(consts.SyncWorktrees, params, func() error {
+		var err error
+		results, err = c.syncWorktrees(branch, options)
+		return err
+	})
+	return results, err
+}
+
+// handleInteractiveSelectionForSync prompts for the target, and for the worktree unless all worktrees are synced.
+// It returns the selected branch, or an empty string when all worktrees are synced.
+func (c *realCodeManager) handleInteractiveSelectionForSync(options *SyncWorktreesOpts) (string, error) {
+	var (
+		result TargetSelectionResult
+		err    error
+	)
+	switch {
+	case !options.All:
+		result, err = c.promptSelectTargetAndWorktree()
+	case options.WorkspaceName == "" && options.RepositoryName == "":
+		result, err = c.promptSelectTargetOnly()
+	default:
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to select target: %w", err)
+	}
+
+	switch result.Type {
+	case prompt.TargetWorkspace:
+		options.WorkspaceName = result.Name
+	case prompt.TargetRepository:
+		options.RepositoryName = result.Name
+	default:
+		return "", fmt.Errorf("invalid target type selected: %s", result.Type)
+	}
+
+	return result.Worktree, nil
+}
+
+// syncWorktrees syncs the worktrees of every repository of the target.
+func (c *realCodeManager) syncWorktrees(branch string, options SyncWorktreesOpts) ([]WorktreeSyncResult, error) {
+	targets, err := c.targetRepositories(options.WorkspaceName, options.RepositoryName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []WorktreeSyncResult
+	for _, target := range targets {
+		// Fetch once per repository, the worktrees share its remote-tracking branches
+		c.VerbosePrint("Fetching %s for repository %s", repo.DefaultRemote, target.URL)
+		if err := c.deps.Git.FetchRemote(target.Repository.Path, repo.DefaultRemote); err != nil {
+			c.VerbosePrint("Warning: failed to fetch %s for repository %s: %v", repo.DefaultRemote, target.URL, err)
+		}
+
+		for _, worktree := range target.Worktrees {
+			results = append(results, c.syncWorktree(target.URL, target.Repository, worktree, options.Merge))
+		}
+	}
+
+	if branch != "" && len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrWorktreeNotInStatus, branch)
+	}
+
+	return results, syncResultsError(results)
+}
+
+// syncWorktree rebases (or merges) a worktree onto its base, unless it has uncommitted changes or a detached HEAD.
+func (c *realCodeManager) syncWorktree(
+	repoURL string, repository *status.Repository, worktree status.WorktreeInfo, merge bool,
+) WorktreeSyncResult {
+	result := WorktreeSyncResult{RepoURL: repoURL, Branch: worktree.Branch}
+	if worktree.DetachedHead {
+		return result.withState(WorktreeSyncSkipped, "detached HEAD")
+	}
+	worktreePath := c.BuildWorktreePath(repoURL, worktree.Remote, worktree.Branch)
+
+	if exists, err := c.deps.FS.Exists(worktreePath); err != nil || !exists {
+		return result.withState(WorktreeSyncFailed, fmt.Sprintf("worktree directory not found: %s", worktreePath))
+	}
+
+	changes, err := c.deps.Git.GetWorkingTreeStatus(worktreePath)
+	if err != nil {
+		return result.withState(WorktreeSyncFailed, err.Error())
+	}
+	if changes.HasChanges() {
+		return result.withState(WorktreeSyncSkipped, "uncommitted changes")
+	}
+
+	result.Base = c.worktreeBase(repository, worktree, worktreePath)
+	if result.Base == "" {
+		return result.withState(WorktreeSyncFailed, "no base ref recorded and no default branch known")
+	}
+
+	upToDate, err := c.deps.Git.IsAncestor(worktreePath, result.Base, "HEAD")
+	if err != nil {
+		return result.withState(WorktreeSyncFailed, err.Error())
+	}
+	if upToDate {
+		result.State = WorktreeSyncUpToDate
+		return result
+	}
+
+	c.VerbosePrint("Syncing worktree %s onto %s", worktree.Branch, result.Base)
+	if merge {
+		err = c.deps.Git.Merge(worktreePath, result.Base)
+	} else {
+		err = c.deps.Git.Rebase(worktreePath, result.Base)
+	}
+	switch {
+	case errors.Is(err, git.ErrConflict):
+		return result.withState(WorktreeSyncConflict, fmt.Sprintf("conflicts with %s, worktree left unchanged", result.Base))
+	case err != nil:
+		return result.withState(WorktreeSyncFailed, err.Error())
+	}
+
+	// Files outside of the sparse directories may have been written while rebasing or merging
+	if len(worktree.Sparse) > 0 {
+		if err := c.deps.Git.SparseCheckoutSet(worktreePath, worktree.Sparse); err != nil {
+			c.VerbosePrint("Warning: failed to restore sparse-checkout of %s: %v", worktreePath, err)
+		}
+	}
+
+	result.State = WorktreeSyncUpdated
+	return result
+}
+
+// withState sets the state and message of the result and returns it.
+func (r WorktreeSyncResult) withState(state WorktreeSyncState, message string) WorktreeSyncResult {
+	r.State = state
+	r.Message = message
+	return r
+}
+
+// worktreeBase returns the ref the worktree is based on: its recorded base ref, preferring
+// its remote-tracking branch which is the one updated by fetches, or the origin default branch.
+func (c *realCodeManager) worktreeBase(
+	repository *status.Repository, worktree status.WorktreeInfo, worktreePath string,
+) string {
+	if worktree.BaseRef != "" {
+		if !strings.HasPrefix(worktree.BaseRef, repo.DefaultRemote+"/") {
+			remoteRef := repo.DefaultRemote + "/" + worktree.BaseRef
+			if _, err := c.deps.Git.ResolveRef(worktreePath, remoteRef); err == nil {
+				return remoteRef
+			}
+		}
+		return worktree.BaseRef
+	}
+
+	if remote, ok := repository.Remotes[repo.DefaultRemote]; ok && remote.DefaultBranch != "" {
+		return repo.DefaultRemote + "/" + remote.DefaultBranch
+	}
+	return ""
+}
+
+// syncResultsError returns ErrWorktreeSyncIncomplete when some worktrees conflicted or failed to sync.
+func syncResultsError(results []WorktreeSyncResult) error {
+	incomplete := 0
+	for _, result := range results {
+		if result.State == WorktreeSyncConflict || result.State == WorktreeSyncFailed {
+			incomplete++
+		}
+	}
+	if incomplete == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d worktrees", ErrWorktreeSyncIncomplete, incomplete, len(results))
+}
+
+// extractSyncWorktreesOptions extracts and merges options from the variadic parameter.
+func (c *realCodeManager) extractSyncWorktreesOptions(opts []SyncWorktreesOpts) SyncWorktreesOpts {
+	var result SyncWorktreesOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.WorkspaceName != "" {
+			result.WorkspaceName = opt.WorkspaceName
+		}
+		if opt.RepositoryName != "" {
+			result.RepositoryName = opt.RepositoryName
+		}
+		if opt.All {
+			result.All = opt.All
+		}
+		if opt.Merge {
+			result.Merge = opt.Merge
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+//go:build unit
+
+package codemanager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/code-manager/consts"
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/mode/repository"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCM_SyncWorktrees_Repository(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.SyncWorktrees)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+	repoURL := "github.com/octocat/Hello-World"
+
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL:  repoURL,
+		RepoPath: "/test/repo",
+	}, nil)
+	mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+		Path:    "/test/repo",
+		Remotes: map[string]status.Remote{"origin": {DefaultBranch: "main"}},
+		Worktrees: map[string]status.WorktreeInfo{
+			"origin:conflicting": {Remote: "origin", Branch: "conflicting"},
+			"origin:dirty":       {Remote: "origin", Branch: "dirty"},
+			"detached:v2.3.1":    {Remote: "detached", Branch: "v2.3.1", DetachedHead: true},
+			"origin:hotfix": {Remote: "origin", Branch: "hotfix", BaseRef: "release/1.4",
+				Sparse: []string{"services/api"}},
+			"origin:current": {Remote: "origin", Branch: "current"},
+		},
+	}, nil)
+	mocks.git.EXPECT().FetchRemote("/test/repo", "origin").Return(nil)
+
+	// Worktree paths end with <repository URL>/<remote>/<branch> under the repositories directory
+	pathOf := func(branch string) gomock.Matcher {
+		return gomock.Cond(func(path any) bool {
+			return strings.HasSuffix(path.(string), "/"+repoURL+"/origin/"+branch)
+		})
+	}
+
+	// Dirty worktrees are skipped without being touched
+	mocks.git.EXPECT().GetWorkingTreeStatus(pathOf("dirty")).Return(&git.WorkingTreeStatus{Untracked: 1}, nil)
+
+	// Up-to-date worktrees are not rebased
+	mocks.git.EXPECT().GetWorkingTreeStatus(pathOf("current")).Return(&git.WorkingTreeStatus{}, nil)
+	mocks.git.EXPECT().IsAncestor(pathOf("current"), "origin/main", "HEAD").Return(true, nil)
+
+	// Worktrees created from a base ref are rebased onto its remote-tracking branch
+	mocks.git.EXPECT().GetWorkingTreeStatus(pathOf("hotfix")).Return(&git.WorkingTreeStatus{}, nil)
+	mocks.git.EXPECT().ResolveRef(pathOf("hotfix"), "origin/release/1.4").Return("abc123", nil)
+	mocks.git.EXPECT().IsAncestor(pathOf("hotfix"), "origin/release/1.4", "HEAD").Return(false, nil)
+	mocks.git.EXPECT().Rebase(pathOf("hotfix"), "origin/release/1.4").Return(nil)
+
+	// Sparse worktrees keep their sparse directories
+	mocks.git.EXPECT().SparseCheckoutSet(pathOf("hotfix"), []string{"services/api"}).Return(nil)
+
+	// Conflicts are reported
+	mocks.git.EXPECT().GetWorkingTreeStatus(pathOf("conflicting")).Return(&git.WorkingTreeStatus{}, nil)
+	mocks.git.EXPECT().IsAncestor(pathOf("conflicting"), "origin/main", "HEAD").Return(false, nil)
+	mocks.git.EXPECT().Rebase(pathOf("conflicting"), "origin/main").Return(git.ErrConflict)
+
+	results, err := cm.SyncWorktrees("", SyncWorktreesOpts{RepositoryName: "Hello-World", All: true})
+	assert.ErrorIs(t, err, ErrWorktreeSyncIncomplete)
+	assert.Equal(t, []WorktreeSyncResult{
+		{RepoURL: repoURL, Branch: "conflicting", Base: "origin/main", State: WorktreeSyncConflict,
+			Message: "conflicts with origin/main, worktree left unchanged"},
+		{RepoURL: repoURL, Branch: "current", Base: "origin/main", State: WorktreeSyncUpToDate},
+		{RepoURL: repoURL, Branch: "dirty", State: WorktreeSyncSkipped, Message: "uncommitted changes"},
+		{RepoURL: repoURL, Branch: "hotfix", Base: "origin/release/1.4", State: WorktreeSyncUpdated},
+		{RepoURL: repoURL, Branch: "v2.3.1", State: WorktreeSyncSkipped, Message: "detached HEAD"},
+	}, results)
+}
+
+func TestCM_SyncWorktrees_WorkspaceMerge(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.SyncWorktrees)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	mocks.status.EXPECT().GetWorkspace("my-workspace").Return(&status.Workspace{
+		Worktrees:    []string{"feature"},
+		Repositories: []string{"github.com/o/frontend", "github.com/o/backend"},
+	}, nil).Times(2)
+	for _, repoURL := range []string{"github.com/o/frontend", "github.com/o/backend"} {
+		repoPath := "/test/" + repoURL
+		mocks.status.EXPECT().GetRepository(repoURL).Return(&status.Repository{
+			Path:    repoPath,
+			Remotes: map[string]status.Remote{"origin": {DefaultBranch: "main"}},
+			Worktrees: map[string]status.WorktreeInfo{
+				"origin:feature":   {Remote: "origin", Branch: "feature"},
+				"origin:unrelated": {Remote: "origin", Branch: "unrelated"},
+			},
+		}, nil)
+		mocks.git.EXPECT().FetchRemote(repoPath, "origin").Return(nil)
+	}
+
+	// Only the worktrees of the workspace are merged, in every repository
+	mocks.git.EXPECT().GetWorkingTreeStatus(gomock.Any()).Return(&git.WorkingTreeStatus{}, nil).Times(2)
+	mocks.git.EXPECT().IsAncestor(gomock.Any(), "origin/main", "HEAD").Return(false, nil).Times(2)
+	mocks.git.EXPECT().Merge(gomock.Any(), "origin/main").Return(nil).Times(2)
+	mocks.git.EXPECT().Rebase(gomock.Any(), gomock.Any()).Times(0)
+
+	results, err := cm.SyncWorktrees("", SyncWorktreesOpts{WorkspaceName: "my-workspace", All: true, Merge: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, "feature", result.Branch)
+		assert.Equal(t, WorktreeSyncUpdated, result.State)
+	}
+}
+
+func TestCM_SyncWorktrees_BranchNotFound(t *testing.T) {
+	cm, mocks := newTestCodeManager(t)
+	expectHooks(mocks, consts.SyncWorktrees)
+	mocks.fs.EXPECT().Exists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	mocks.repository.EXPECT().IsGitRepository().Return(true, nil)
+	mocks.repository.EXPECT().ValidateRepository(gomock.Any()).Return(&repository.ValidationResult{
+		RepoURL: "github.com/octocat/Hello-World",
+	}, nil)
+	mocks.status.EXPECT().GetRepository("github.com/octocat/Hello-World").Return(&status.Repository{
+		Worktrees: map[string]status.WorktreeInfo{"origin:other": {Remote: "origin", Branch: "other"}},
+	}, nil)
+	mocks.git.EXPECT().FetchRemote(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := cm.SyncWorktrees("feature")
+	assert.ErrorIs(t, err, ErrWorktreeNotInStatus)
+}
+
+func TestCM_SyncWorktrees_BranchWithAll(t *testing.T) {
+	cm, _ := newTestCodeManager(t)
+
+	_, err := cm.SyncWorktrees("feature", SyncWorktreesOpts{All: true})
+	assert.ErrorIs(t, err, ErrSyncBranchWithAll)
+}
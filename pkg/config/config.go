@@ -3,16 +3,151 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/branch"
 )
 
+// DefaultIssueCacheTTL is the duration during which cached issues are used without querying the forge.
+const DefaultIssueCacheTTL = time.Hour
+
 // Config represents the application configuration.
 type Config struct {
 	RepositoriesDir string `yaml:"repositories_dir"` // User's repositories directory (default: ~/Code/repos)
 	WorkspacesDir   string `yaml:"workspaces_dir"`   // User's workspaces directory (default: ~/Code/workspaces)
 	StatusFile      string `yaml:"status_file"`      // Status file path (default: ~/.cm/status.yaml)
+	// Forges maps forge hostnames (e.g. github.example.com) to their configuration
+	Forges map[string]ForgeConfig `yaml:"forges,omitempty"`
+	// BranchName configures the names of branches generated from issues
+	BranchName BranchNameConfig `yaml:"branch_name,omitempty"`
+	// Repositories maps repository URLs (e.g. github.com/owner/repo) to repository-specific settings
+	Repositories map[string]RepositoryConfig `yaml:"repositories,omitempty"`
+	// IssueCache configures the on-disk cache of issues fetched from forges
+	IssueCache IssueCacheConfig `yaml:"issue_cache,omitempty"`
+	// IssueTrackers maps issue tracker names to their configuration, for issues not living on the forge
+	IssueTrackers map[string]IssueTrackerConfig `yaml:"issue_trackers,omitempty"`
+	// ForgeHTTP configures the HTTP requests sent to forge APIs
+	ForgeHTTP ForgeHTTPConfig `yaml:"forge_http,omitempty"`
+}
+
+// ForgeConfig represents the configuration of a forge instance.
+type ForgeConfig struct {
+	Type     string `yaml:"type"`                // Forge type (github, gitlab, gitea or forgejo)
+	APIURL   string `yaml:"api_url,omitempty"`   // REST API base URL (defaults to the forge API on the host)
+	TokenEnv string `yaml:"token_env,omitempty"` // Environment variable holding the token (defaults per forge type)
+}
+
+// IssueTrackerConfig represents the configuration of an issue tracker instance.
+type IssueTrackerConfig struct {
+	Type     string   `yaml:"type"`                // Issue tracker type (jira)
+	URL      string   `yaml:"url"`                 // Base URL of the tracker (e.g. https://example.atlassian.net)
+	Projects []string `yaml:"projects,omitempty"`  // Project keys handled by the tracker (all projects if empty)
+	TokenEnv string   `yaml:"token_env,omitempty"` // Environment variable holding the API token
+	UserEnv  string   `yaml:"user_env,omitempty"`  // Environment variable holding the user for basic authentication
+}
+
+// IssueCacheConfig represents the configuration of the on-disk issue cache.
+type IssueCacheConfig struct {
+	Dir string        `yaml:"dir,omitempty"` // Cache directory (defaults to cache/issues next to the status file)
+	TTL time.Duration `yaml:"ttl,omitempty"` // Duration during which cached issues are fresh (defaults to 1h)
+}
+
+// IssueCacheDir returns the directory of the issue cache, or an empty string when it cannot be determined.
+func (c Config) IssueCacheDir() string {
+	if c.IssueCache.Dir != "" {
+		return c.IssueCache.Dir
+	}
+	if c.StatusFile == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(c.StatusFile), "cache", "issues")
+}
+
+// IssueCacheTTL returns the duration during which cached issues are used without querying the forge.
+func (c Config) IssueCacheTTL() time.Duration {
+	if c.IssueCache.TTL == 0 {
+		return DefaultIssueCacheTTL
+	}
+	return c.IssueCache.TTL
+}
+
+// ForgeHTTPConfig represents the configuration of the HTTP requests sent to forge APIs.
+// Zero values use the forge defaults.
+type ForgeHTTPConfig struct {
+	Timeout      time.Duration `yaml:"timeout,omitempty"`        // Timeout of a single request (defaults to 10s)
+	Budget       time.Duration `yaml:"budget,omitempty"`         // Time allowed to a forge call, retries included (1m)
+	MaxRetries   int           `yaml:"max_retries,omitempty"`    // Retries of failed requests (defaults to 3, -1 disables)
+	MaxRetryWait time.Duration `yaml:"max_retry_wait,omitempty"` // Longest rate limit wait before a retry (1m)
+	CacheDir     string        `yaml:"cache_dir,omitempty"`      // ETag cache (defaults to cache/http next to status file)
+}
+
+// ForgeHTTPCacheDir returns the directory of the forge responses cache,
+// or an empty string when it cannot be determined.
+func (c Config) ForgeHTTPCacheDir() string {
+	if c.ForgeHTTP.CacheDir != "" {
+		return c.ForgeHTTP.CacheDir
+	}
+	if c.StatusFile == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(c.StatusFile), "cache", "http")
+}
+
+// BranchNameConfig represents the configuration of branch names generated from issues.
+type BranchNameConfig struct {
+	Template string            `yaml:"template,omitempty"` // Go text/template rendered with the issue information
+	Types    map[string]string `yaml:"types,omitempty"`    // Issue label to branch type (e.g. bug: fix)
+}
+
+// RepositoryConfig represents settings overriding the global configuration for a repository.
+type RepositoryConfig struct {
+	BranchName BranchNameConfig `yaml:"branch_name,omitempty"`
+	Sparse     []string         `yaml:"sparse,omitempty"` // Directories checked out in new worktrees (sparse-checkout)
+}
+
+// SparseFor returns the default sparse-checkout directories of a repository URL,
+// or nil when its worktrees are fully checked out.
+func (c Config) SparseFor(repoURL string) []string {
+	return c.Repositories[repoURL].Sparse
+}
+
+// BranchNameFor returns the branch name configuration for a repository URL,
+// with the repository template and types overriding the global ones.
+func (c Config) BranchNameFor(repoURL string) BranchNameConfig {
+	result := BranchNameConfig{
+		Template: c.BranchName.Template,
+		Types:    make(map[string]string, len(c.BranchName.Types)),
+	}
+	for label, branchType := range c.BranchName.Types {
+		result.Types[label] = branchType
+	}
+
+	repoConfig, ok := c.Repositories[repoURL]
+	if !ok {
+		return result
+	}
+	if repoConfig.BranchName.Template != "" {
+		result.Template = repoConfig.BranchName.Template
+	}
+	for label, branchType := range repoConfig.BranchName.Types {
+		result.Types[label] = branchType
+	}
+	return result
+}
+
+// validateBranchNameTemplate checks that a branch name template can be parsed.
+func validateBranchNameTemplate(tmpl, location string) error {
+	if tmpl == "" {
+		return nil
+	}
+	if _, err := branch.ParseNameTemplate(tmpl); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidBranchNameTemplate, location, err)
+	}
+	return nil
 }
 
 // validateDirectoryAccessibility checks if a directory path is accessible and can be created.
@@ -44,6 +179,48 @@ func (c Config) Validate() error {
 		return ErrWorkspacesDirEmpty
 	}
 
+	// Check that every configured forge declares its type
+	for host, forge := range c.Forges {
+		if forge.Type == "" {
+			return fmt.Errorf("%w: %s", ErrForgeTypeEmpty, host)
+		}
+		if forge.APIURL != "" {
+			if apiURL, err := url.Parse(forge.APIURL); err != nil || apiURL.Host == "" ||
+				(apiURL.Scheme != "http" && apiURL.Scheme != "https") {
+				return fmt.Errorf("%w: %s: %s", ErrInvalidForgeAPIURL, host, forge.APIURL)
+			}
+		}
+	}
+
+	// Check that every configured issue tracker declares its type and URL
+	for name, tracker := range c.IssueTrackers {
+		if tracker.Type == "" {
+			return fmt.Errorf("%w: %s", ErrIssueTrackerTypeEmpty, name)
+		}
+		if trackerURL, err := url.Parse(tracker.URL); err != nil || trackerURL.Host == "" ||
+			(trackerURL.Scheme != "http" && trackerURL.Scheme != "https") {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidIssueTrackerURL, name, tracker.URL)
+		}
+	}
+
+	if c.IssueCache.TTL < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidIssueCacheTTL, c.IssueCache.TTL)
+	}
+
+	if err := c.ForgeHTTP.validate(); err != nil {
+		return err
+	}
+
+	// Check that branch name templates can be parsed
+	if err := validateBranchNameTemplate(c.BranchName.Template, "branch_name"); err != nil {
+		return err
+	}
+	for repoURL, repoConfig := range c.Repositories {
+		if err := validateBranchNameTemplate(repoConfig.BranchName.Template, repoURL); err != nil {
+			return err
+		}
+	}
+
 	// Check if repositories directory is accessible
 	if err := c.validateDirectoryAccessibility(c.RepositoriesDir, "repositories_dir"); err != nil {
 		return err
@@ -57,6 +234,23 @@ func (c Config) Validate() error {
 	return nil
 }
 
+// validate checks that the forge HTTP durations and retries are not negative.
+func (f ForgeHTTPConfig) validate() error {
+	for name, duration := range map[string]time.Duration{
+		"timeout":        f.Timeout,
+		"budget":         f.Budget,
+		"max_retry_wait": f.MaxRetryWait,
+	} {
+		if duration < 0 {
+			return fmt.Errorf("%w: forge_http.%s: %s", ErrInvalidForgeHTTPSetting, name, duration)
+		}
+	}
+	if f.MaxRetries < -1 {
+		return fmt.Errorf("%w: forge_http.max_retries: %d", ErrInvalidForgeHTTPSetting, f.MaxRetries)
+	}
+	return nil
+}
+
 // expandTilde expands a single path if it starts with tilde.
 func (c *Config) expandTilde(path string, homeDir string) string {
 	if strings.HasPrefix(path, "~") {
@@ -76,6 +270,8 @@ func (c *Config) expandTildes() error {
 	c.RepositoriesDir = c.expandTilde(c.RepositoriesDir, homeDir)
 	c.WorkspacesDir = c.expandTilde(c.WorkspacesDir, homeDir)
 	c.StatusFile = c.expandTilde(c.StatusFile, homeDir)
+	c.IssueCache.Dir = c.expandTilde(c.IssueCache.Dir, homeDir)
+	c.ForgeHTTP.CacheDir = c.expandTilde(c.ForgeHTTP.CacheDir, homeDir)
 
 	return nil
 }
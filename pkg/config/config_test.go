@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,118 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "forge without type",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				Forges:          map[string]ForgeConfig{"gitlab.example.com": {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forge with relative API URL",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				Forges: map[string]ForgeConfig{
+					"github.example.com": {Type: "github", APIURL: "github.example.com/api/v3"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GitHub Enterprise forge",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				Forges: map[string]ForgeConfig{
+					"github.example.com": {
+						Type:     "github",
+						APIURL:   "https://github.example.com/api/v3",
+						TokenEnv: "GHE_TOKEN",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid branch name template",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				BranchName:      BranchNameConfig{Template: "{{.Number"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid repository branch name template",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				Repositories: map[string]RepositoryConfig{
+					"github.com/owner/repo": {BranchName: BranchNameConfig{Template: "{{end}}"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Jira issue tracker",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				IssueTrackers: map[string]IssueTrackerConfig{
+					"jira": {Type: "jira", URL: "https://example.atlassian.net", Projects: []string{"PROJ"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "issue tracker without URL",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				IssueTrackers:   map[string]IssueTrackerConfig{"jira": {Type: "jira"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative issue cache TTL",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				IssueCache:      IssueCacheConfig{TTL: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative forge HTTP timeout",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				ForgeHTTP:       ForgeHTTPConfig{Timeout: -time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forge HTTP retries below -1",
+			config: Config{
+				RepositoriesDir: filepath.Join(t.TempDir(), "test", "path"),
+				WorkspacesDir:   filepath.Join(t.TempDir(), "test", "workspaces"),
+				StatusFile:      filepath.Join(t.TempDir(), "test", "status.yaml"),
+				ForgeHTTP:       ForgeHTTPConfig{MaxRetries: -2},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -60,6 +173,45 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_BranchNameFor(t *testing.T) {
+	cfg := Config{
+		BranchName: BranchNameConfig{
+			Template: "{{.Type}}/{{.Number}}-{{.Slug}}",
+			Types:    map[string]string{"bug": "fix"},
+		},
+		Repositories: map[string]RepositoryConfig{
+			"github.com/owner/repo": {BranchName: BranchNameConfig{
+				Template: "{{.Number}}",
+				Types:    map[string]string{"chore": "chore"},
+			}},
+		},
+	}
+
+	assert.Equal(t, BranchNameConfig{
+		Template: "{{.Type}}/{{.Number}}-{{.Slug}}",
+		Types:    map[string]string{"bug": "fix"},
+	}, cfg.BranchNameFor("github.com/other/repo"))
+
+	assert.Equal(t, BranchNameConfig{
+		Template: "{{.Number}}",
+		Types:    map[string]string{"bug": "fix", "chore": "chore"},
+	}, cfg.BranchNameFor("github.com/owner/repo"))
+
+	// Overrides must not leak into the global configuration
+	assert.Equal(t, map[string]string{"bug": "fix"}, cfg.BranchName.Types)
+}
+
+func TestConfig_SparseFor(t *testing.T) {
+	cfg := Config{
+		Repositories: map[string]RepositoryConfig{
+			"github.com/owner/repo": {Sparse: []string{"services/api", "libs/common"}},
+		},
+	}
+
+	assert.Equal(t, []string{"services/api", "libs/common"}, cfg.SparseFor("github.com/owner/repo"))
+	assert.Nil(t, cfg.SparseFor("github.com/other/repo"))
+}
+
 func TestRealManager_DefaultConfig(t *testing.T) {
 	manager := NewConfigManager("/test/config.yaml")
 	config := manager.DefaultConfig()
@@ -150,6 +302,47 @@ func TestLoadConfigWithFallback_WithMissingFile(t *testing.T) {
 	assert.Contains(t, config.RepositoriesDir, "Code")
 }
 
+func TestConfig_IssueCache(t *testing.T) {
+	cfg := Config{StatusFile: "/home/user/.cm/status.yaml"}
+	assert.Equal(t, filepath.Join("/home/user/.cm", "cache", "issues"), cfg.IssueCacheDir())
+	assert.Equal(t, DefaultIssueCacheTTL, cfg.IssueCacheTTL())
+
+	cfg.IssueCache = IssueCacheConfig{Dir: "/tmp/issues", TTL: 10 * time.Minute}
+	assert.Equal(t, "/tmp/issues", cfg.IssueCacheDir())
+	assert.Equal(t, 10*time.Minute, cfg.IssueCacheTTL())
+
+	assert.Empty(t, Config{}.IssueCacheDir())
+}
+
+func TestConfig_ForgeHTTPCacheDir(t *testing.T) {
+	cfg := Config{StatusFile: "/home/user/.cm/status.yaml"}
+	assert.Equal(t, filepath.Join("/home/user/.cm", "cache", "http"), cfg.ForgeHTTPCacheDir())
+
+	cfg.ForgeHTTP = ForgeHTTPConfig{CacheDir: "/tmp/http"}
+	assert.Equal(t, "/tmp/http", cfg.ForgeHTTPCacheDir())
+
+	assert.Empty(t, Config{}.ForgeHTTPCacheDir())
+}
+
+func TestRealManager_LoadConfig_IssueCacheTTL(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`repositories_dir: `+tempDir+`/repos
+workspaces_dir: `+tempDir+`/workspaces
+status_file: `+tempDir+`/status.yaml
+issue_cache:
+  ttl: 30m
+forge_http:
+  timeout: 5s
+  max_retries: -1
+`), 0644))
+
+	cfg, err := NewConfigManager(configPath).GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, cfg.IssueCache.TTL)
+	assert.Equal(t, ForgeHTTPConfig{Timeout: 5 * time.Second, MaxRetries: -1}, cfg.ForgeHTTP)
+}
+
 func TestConfig_ExpandTildes(t *testing.T) {
 	config := &Config{
 		RepositoriesDir: "~/.cm-test",
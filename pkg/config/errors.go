@@ -7,9 +7,16 @@ var (
 	// Configuration file errors.
 	ErrConfigFileParse = errors.New("failed to parse config file")
 	// Configuration validation errors.
-	ErrRepositoriesDirEmpty = errors.New("repositories_dir cannot be empty")
-	ErrWorkspacesDirEmpty   = errors.New("workspaces_dir cannot be empty")
-	ErrStatusFileEmpty      = errors.New("status_file cannot be empty")
+	ErrRepositoriesDirEmpty      = errors.New("repositories_dir cannot be empty")
+	ErrWorkspacesDirEmpty        = errors.New("workspaces_dir cannot be empty")
+	ErrStatusFileEmpty           = errors.New("status_file cannot be empty")
+	ErrForgeTypeEmpty            = errors.New("forge type cannot be empty")
+	ErrInvalidForgeAPIURL        = errors.New("forge API URL must be an absolute http(s) URL")
+	ErrInvalidBranchNameTemplate = errors.New("invalid branch name template")
+	ErrInvalidIssueCacheTTL      = errors.New("issue cache TTL cannot be negative")
+	ErrIssueTrackerTypeEmpty     = errors.New("issue tracker type cannot be empty")
+	ErrInvalidIssueTrackerURL    = errors.New("issue tracker URL must be an absolute http(s) URL")
+	ErrInvalidForgeHTTPSetting   = errors.New("forge HTTP durations cannot be negative nor retries below -1")
 	// Configuration initialization errors.
 	ErrConfigNotInitialized = errors.New("CM configuration not found. Run 'cm init' to initialize")
 )
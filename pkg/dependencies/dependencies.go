@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/forge"
 	"github.com/lerenn/code-manager/pkg/fs"
 	"github.com/lerenn/code-manager/pkg/git"
 	"github.com/lerenn/code-manager/pkg/hooks"
@@ -15,6 +16,7 @@ import (
 	workspaceinterfaces "github.com/lerenn/code-manager/pkg/mode/workspace/interfaces"
 	"github.com/lerenn/code-manager/pkg/prompt"
 	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/tracker"
 	worktreeinterfaces "github.com/lerenn/code-manager/pkg/worktree/interfaces"
 )
 
@@ -45,6 +47,8 @@ type Dependencies struct {
 	RepositoryProvider repositoryinterfaces.RepositoryProvider
 	WorkspaceProvider  workspaceinterfaces.WorkspaceProvider
 	WorktreeProvider   worktreeinterfaces.WorktreeProvider
+	ForgeProvider      forge.ManagerProvider
+	TrackerProvider    tracker.ManagerProvider
 }
 
 // New creates a new Dependencies instance with sensible defaults.
@@ -56,6 +60,12 @@ func New() *Dependencies {
 		Logger:      logger.NewNoopLogger(),
 		Prompt:      prompt.NewPrompt(),
 		HookManager: hooks.NewHookManager(),
+		ForgeProvider: func(l logger.Logger, sm status.Manager, cfg config.Config) forge.ManagerInterface {
+			return forge.NewManager(l, sm, cfg)
+		},
+		TrackerProvider: func(l logger.Logger, cfg config.Config) tracker.ManagerInterface {
+			return tracker.NewManager(l, cfg)
+		},
 		// Note: Config, StatusManager, and Providers are intentionally left nil
 		// as they require specific configuration or are set via With* methods
 	}
@@ -121,6 +131,18 @@ func (d *Dependencies) WithWorktreeProvider(wp worktreeinterfaces.WorktreeProvid
 	return d
 }
 
+// WithForgeProvider sets the forge manager provider and returns the instance for chaining.
+func (d *Dependencies) WithForgeProvider(fp forge.ManagerProvider) *Dependencies {
+	d.ForgeProvider = fp
+	return d
+}
+
+// WithTrackerProvider sets the issue tracker manager provider and returns the instance for chaining.
+func (d *Dependencies) WithTrackerProvider(tp tracker.ManagerProvider) *Dependencies {
+	d.TrackerProvider = tp
+	return d
+}
+
 // dependencyCheck represents a dependency validation check.
 type dependencyCheck struct {
 	dep interface{}
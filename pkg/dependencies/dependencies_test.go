@@ -59,6 +59,8 @@ func TestDependencies_New_Defaults(t *testing.T) {
 	assert.NotNil(t, deps.Logger)
 	assert.NotNil(t, deps.Prompt)
 	assert.NotNil(t, deps.HookManager)
+	assert.NotNil(t, deps.ForgeProvider)
+	assert.NotNil(t, deps.TrackerProvider)
 
 	// Check that configurable dependencies are nil by default
 	assert.Nil(t, deps.Config)
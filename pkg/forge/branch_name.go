@@ -0,0 +1,119 @@
+package forge
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/branch"
+	"github.com/lerenn/code-manager/pkg/issue"
+)
+
+// DefaultBranchNameTemplate is the branch name template used when none is configured.
+// It renders <issue-id>-<sanitized-issue-title>, the issue id being the issue number for forge issues.
+const DefaultBranchNameTemplate = "{{.ID}}-{{.Slug}}"
+
+// DefaultBranchTypes maps common issue labels to the branch type exposed to templates.
+var DefaultBranchTypes = map[string]string{
+	"bug":         "fix",
+	"enhancement": "feat",
+	"story":       "feat", // Issue type of issue tracker stories
+}
+
+// GenerateBranchNameOpts contains optional parameters for GenerateBranchName.
+type GenerateBranchNameOpts struct {
+	Template string            // Branch name template (defaults to DefaultBranchNameTemplate)
+	Types    map[string]string // Issue label to branch type, merged over DefaultBranchTypes
+}
+
+// BranchNameData is the data branch name templates are rendered with.
+// Issue fields such as .Number, .Title and .Labels are available alongside .ID, .Slug and .Type.
+type BranchNameData struct {
+	issue.Info
+	ID   string // Issue tracker key (e.g. PROJ-123), or the issue number for forge issues
+	Slug string // Sanitized issue title, limited to MaxTitleLength characters
+	Type string // Branch type of the first issue label found in the types (empty if none)
+}
+
+// GenerateBranchName generates a branch name from issue information that does not come from a forge,
+// such as issues of an issue tracker.
+func GenerateBranchName(issueInfo *issue.Info, opts ...GenerateBranchNameOpts) (string, error) {
+	return generateBranchName(issueInfo, opts)
+}
+
+// generateBranchName generates a branch name from issue information, shared by all forge implementations.
+func generateBranchName(issueInfo *issue.Info, opts []GenerateBranchNameOpts) (string, error) {
+	tmpl := DefaultBranchNameTemplate
+	types := make(map[string]string, len(DefaultBranchTypes))
+	for label, branchType := range DefaultBranchTypes {
+		types[label] = branchType
+	}
+	if len(opts) > 0 {
+		if opts[0].Template != "" {
+			tmpl = opts[0].Template
+		}
+		for label, branchType := range opts[0].Types {
+			types[label] = branchType
+		}
+	}
+
+	id := issueInfo.Key
+	if id == "" {
+		id = strconv.Itoa(issueInfo.Number)
+	}
+
+	return branch.RenderNameTemplate(tmpl, BranchNameData{
+		Info: *issueInfo,
+		ID:   id,
+		Slug: branchSlug(issueInfo.Title),
+		Type: branchType(issueInfo.Labels, types),
+	})
+}
+
+// branchSlug sanitizes the issue title and limits its length to MaxTitleLength.
+func branchSlug(title string) string {
+	// Sanitize the title
+	sanitizedTitle := sanitizeTitle(title)
+
+	// Limit length to MaxTitleLength
+	if len(sanitizedTitle) > MaxTitleLength {
+		sanitizedTitle = sanitizedTitle[:MaxTitleLength]
+	}
+
+	// Ensure no trailing hyphens
+	return strings.Trim(sanitizedTitle, "-")
+}
+
+// branchType returns the branch type of the first label found in types (labels are matched case-insensitively).
+func branchType(labels []string, types map[string]string) string {
+	for _, label := range labels {
+		for typeLabel, branchType := range types {
+			if strings.EqualFold(label, typeLabel) {
+				return branchType
+			}
+		}
+	}
+	return ""
+}
+
+// sanitizeTitle sanitizes the issue title for use in branch names.
+func sanitizeTitle(title string) string {
+	// Convert to lowercase
+	title = strings.ToLower(title)
+
+	// Replace spaces with hyphens
+	title = strings.ReplaceAll(title, " ", "-")
+
+	// Replace all non-alphanumeric characters with hyphens
+	re := regexp.MustCompile(`[^a-z0-9-]`)
+	title = re.ReplaceAllString(title, "-")
+
+	// Replace multiple consecutive hyphens with single hyphen
+	re = regexp.MustCompile(`-+`)
+	title = re.ReplaceAllString(title, "-")
+
+	// Trim leading and trailing hyphens
+	title = strings.Trim(title, "-")
+
+	return title
+}
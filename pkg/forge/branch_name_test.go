@@ -0,0 +1,100 @@
+//go:build unit
+
+package forge
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBranchName_Template(t *testing.T) {
+	bug := &issue.Info{Number: 123, Title: "Fix Login Bug", Labels: []string{"priority", "Bug"}}
+
+	tests := []struct {
+		name      string
+		issueInfo *issue.Info
+		opts      []GenerateBranchNameOpts
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:      "default template",
+			issueInfo: bug,
+			expected:  "123-fix-login-bug",
+		},
+		{
+			name:      "type from default label mapping",
+			issueInfo: bug,
+			opts:      []GenerateBranchNameOpts{{Template: "{{.Type}}/{{.Number}}-{{.Slug}}"}},
+			expected:  "fix/123-fix-login-bug",
+		},
+		{
+			name:      "enhancement label",
+			issueInfo: &issue.Info{Number: 7, Title: "Dark mode", Labels: []string{"enhancement"}},
+			opts:      []GenerateBranchNameOpts{{Template: "{{.Type}}/{{.Number}}-{{.Slug}}"}},
+			expected:  "feat/7-dark-mode",
+		},
+		{
+			name: "issue tracker key as id",
+			issueInfo: &issue.Info{
+				Number: 123, Key: "PROJ-123", Title: "Add Dark Mode", Labels: []string{"story"},
+			},
+			opts:     []GenerateBranchNameOpts{{Template: "{{.Type}}/{{.ID}}-{{.Slug}}"}},
+			expected: "feat/PROJ-123-add-dark-mode",
+		},
+		{
+			name:      "configured types override defaults",
+			issueInfo: bug,
+			opts: []GenerateBranchNameOpts{{
+				Template: "{{.Type}}/{{.Number}}-{{.Slug}}",
+				Types:    map[string]string{"bug": "bugfix"},
+			}},
+			expected: "bugfix/123-fix-login-bug",
+		},
+		{
+			name:      "no matching label",
+			issueInfo: &issue.Info{Number: 8, Title: "Chore", Labels: []string{"question"}},
+			opts:      []GenerateBranchNameOpts{{Template: "{{with .Type}}{{.}}/{{end}}{{.Number}}-{{.Slug}}"}},
+			expected:  "8-chore",
+		},
+		{
+			name:      "issue fields and functions",
+			issueInfo: &issue.Info{Number: 9, Title: "Docs", Owner: "Octocat"},
+			opts:      []GenerateBranchNameOpts{{Template: "{{lower .Owner}}/{{.Number}}"}},
+			expected:  "octocat/9",
+		},
+		{
+			name:      "rendered name is sanitized",
+			issueInfo: &issue.Info{Number: 10, Title: "Spaces"},
+			opts:      []GenerateBranchNameOpts{{Template: "{{.Number}} {{.Title}}"}},
+			expected:  "10_Spaces",
+		},
+		{
+			name:      "unknown field",
+			issueInfo: bug,
+			opts:      []GenerateBranchNameOpts{{Template: "{{.Unknown}}"}},
+			wantErr:   true,
+		},
+		{
+			name:      "empty result",
+			issueInfo: bug,
+			opts:      []GenerateBranchNameOpts{{Template: "{{if false}}x{{end}}"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := generateBranchName(tt.issueInfo, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package forge
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@latest  -source=credentials.go -destination=mocks/credentials.gen.go -package=mocks
+
+// Sources a forge credential can be resolved from, in lookup order.
+const (
+	CredentialSourceEnv           = "environment variable"
+	CredentialSourceGitCredential = "git credential helper"
+	CredentialSourceNetrc         = "netrc"
+	CredentialSourceGHHosts       = "gh hosts.yml"
+)
+
+// Credential is a forge access token along with the source it was resolved from.
+type Credential struct {
+	Token  string
+	Source string
+}
+
+// CredentialResolver resolves the access token used to authenticate against a forge host.
+type CredentialResolver interface {
+	// Resolve returns the credential for the host, trying the tokenEnv environment variable,
+	// the git credential helper, netrc and the gh CLI configuration in that order.
+	Resolve(host, tokenEnv string) (Credential, error)
+}
+
+// NewCredentialResolverOpts contains optional parameters for NewCredentialResolver.
+type NewCredentialResolverOpts struct {
+	Logger      logger.Logger // Logger reporting the credential source (defaults to a noop logger)
+	Git         git.Git       // Git used to query credential helpers (defaults to the git CLI)
+	NetrcPath   string        // netrc file (defaults to $NETRC, then ~/.netrc)
+	GHHostsPath string        // gh CLI hosts file (defaults to the gh configuration directory)
+}
+
+type realCredentialResolver struct {
+	logger      logger.Logger
+	git         git.Git
+	netrcPath   string
+	ghHostsPath string
+}
+
+// NewCredentialResolver creates a new credential resolver.
+func NewCredentialResolver(opts ...NewCredentialResolverOpts) CredentialResolver {
+	r := &realCredentialResolver{
+		logger: logger.NewNoopLogger(),
+		git:    git.NewGit(),
+	}
+
+	if len(opts) > 0 {
+		if opts[0].Logger != nil {
+			r.logger = opts[0].Logger
+		}
+		if opts[0].Git != nil {
+			r.git = opts[0].Git
+		}
+		r.netrcPath = opts[0].NetrcPath
+		r.ghHostsPath = opts[0].GHHostsPath
+	}
+
+	return r
+}
+
+// Resolve returns the credential for the host from the first source providing one.
+func (r *realCredentialResolver) Resolve(host, tokenEnv string) (Credential, error) {
+	lookups := []struct {
+		source string
+		lookup func(host, tokenEnv string) string
+	}{
+		{CredentialSourceEnv, func(_, tokenEnv string) string { return os.Getenv(tokenEnv) }},
+		{CredentialSourceGitCredential, func(host, _ string) string { return r.gitCredential(host) }},
+		{CredentialSourceNetrc, func(host, _ string) string { return r.netrcPassword(host) }},
+		{CredentialSourceGHHosts, func(host, _ string) string { return r.ghHostsToken(host) }},
+	}
+
+	for _, l := range lookups {
+		if token := l.lookup(host, tokenEnv); token != "" {
+			r.logger.Logf("Using %s credentials from %s", host, describeCredentialSource(l.source, tokenEnv))
+			return Credential{Token: token, Source: l.source}, nil
+		}
+	}
+
+	return Credential{}, fmt.Errorf("%w for %s: set the %s environment variable, "+
+		"configure a git credential helper for https://%s, add a 'machine %s' entry to %s, "+
+		"or run 'gh auth login --hostname %s'",
+		ErrCredentialsNotFound, host, tokenEnv, host, host, r.netrcFile(), host)
+}
+
+// gitCredential asks the configured git credential helpers for the host password.
+func (r *realCredentialResolver) gitCredential(host string) string {
+	credential, err := r.git.CredentialFill(git.CredentialFillParams{Host: host})
+	if err != nil {
+		if !errors.Is(err, git.ErrCredentialNotFound) {
+			r.logger.Logf("Warning: git credential helper lookup failed for %s: %v", host, err)
+		}
+		return ""
+	}
+	return credential.Password
+}
+
+// netrcPassword returns the password of the host machine entry in the netrc file.
+func (r *realCredentialResolver) netrcPassword(host string) string {
+	path := r.netrcFile()
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.logger.Logf("Warning: failed to read netrc file %s: %v", path, err)
+		}
+		return ""
+	}
+
+	return parseNetrc(string(data), host).password
+}
+
+// netrcFile returns the netrc file path, honoring the NETRC environment variable.
+func (r *realCredentialResolver) netrcFile() string {
+	if r.netrcPath != "" {
+		return r.netrcPath
+	}
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// ghHostsToken returns the OAuth token stored by the gh CLI for the host.
+func (r *realCredentialResolver) ghHostsToken(host string) string {
+	path := r.ghHostsFile()
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		r.logger.Logf("Warning: failed to parse gh hosts file %s: %v", path, err)
+		return ""
+	}
+
+	return hosts[host].OAuthToken
+}
+
+// ghHostsFile returns the gh CLI hosts file path, following the gh configuration directory lookup.
+func (r *realCredentialResolver) ghHostsFile() string {
+	if r.ghHostsPath != "" {
+		return r.ghHostsPath
+	}
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// describeCredentialSource returns a human readable description of a credential source.
+func describeCredentialSource(source, tokenEnv string) string {
+	if source == CredentialSourceEnv {
+		return fmt.Sprintf("%s environment variable", tokenEnv)
+	}
+	return source
+}
+
+// netrcEntry is a machine entry of a netrc file.
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+// parseNetrc returns the entry of the host machine in the netrc content,
+// falling back to the default entry when the host is not listed.
+func parseNetrc(content, host string) netrcEntry {
+	var (
+		found, fallback netrcEntry
+		current         *netrcEntry
+		hasHost         bool
+		hasDefault      bool
+	)
+
+	tokens := netrcTokens(content)
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			current = nil
+			if i+1 < len(tokens) {
+				i++
+				if tokens[i] == host && !hasHost {
+					current, hasHost = &found, true
+				}
+			}
+		case "default":
+			current = nil
+			if !hasDefault {
+				current, hasDefault = &fallback, true
+			}
+		case "login", "password", "account":
+			if i+1 >= len(tokens) {
+				break
+			}
+			i++
+			if current == nil {
+				continue
+			}
+			switch tokens[i-1] {
+			case "login":
+				current.login = tokens[i]
+			case "password":
+				current.password = tokens[i]
+			}
+		}
+	}
+
+	if hasHost {
+		return found
+	}
+	return fallback
+}
+
+// netrcTokens splits netrc content into tokens, skipping comments and macro definitions.
+func netrcTokens(content string) []string {
+	var tokens []string
+	inMacro := false
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+
+		// Macro definitions run until the next empty line
+		if inMacro {
+			if len(fields) == 0 {
+				inMacro = false
+			}
+			continue
+		}
+
+		for _, field := range fields {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			if field == "macdef" {
+				inMacro = true
+				break
+			}
+			tokens = append(tokens, field)
+		}
+	}
+
+	return tokens
+}
+
+// hostCredential lazily resolves the credential of a forge host on first use.
+type hostCredential struct {
+	host       string
+	tokenEnv   string
+	resolver   CredentialResolver
+	once       sync.Once
+	credential Credential
+	err        error
+}
+
+// newHostCredential creates a lazily resolved credential for the host.
+func newHostCredential(host, tokenEnv string, resolver CredentialResolver) *hostCredential {
+	if resolver == nil {
+		resolver = NewCredentialResolver()
+	}
+	return &hostCredential{host: host, tokenEnv: tokenEnv, resolver: resolver}
+}
+
+// token returns the resolved token, or an empty string when no credentials are available.
+func (h *hostCredential) token() string {
+	h.once.Do(func() {
+		h.credential, h.err = h.resolver.Resolve(h.host, h.tokenEnv)
+	})
+	return h.credential.Token
+}
+
+// unauthorizedError returns an actionable error for a request rejected as unauthorized.
+func (h *hostCredential) unauthorizedError() error {
+	if h.token() == "" {
+		return fmt.Errorf("%w: %w", ErrUnauthorized, h.err)
+	}
+	return fmt.Errorf("%w: the token from %s was rejected by %s",
+		ErrUnauthorized, describeCredentialSource(h.credential.Source, h.tokenEnv), h.host)
+}
+
+// credentialTransport authenticates requests with the bearer token of a lazily resolved credential.
+type credentialTransport struct {
+	base       http.RoundTripper
+	credential *hostCredential
+}
+
+// RoundTrip adds the Authorization header when a token is available.
+func (t *credentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if token := t.credential.token(); token != "" && req.Header.Get("Authorization") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// authenticatedHTTPClient returns a copy of the HTTP client authenticating its requests with the credential.
+func authenticatedHTTPClient(client *http.Client, credential *hostCredential) *http.Client {
+	authenticated := &http.Client{}
+	if client != nil {
+		*authenticated = *client
+	}
+
+	base := authenticated.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	authenticated.Transport = &credentialTransport{base: base, credential: credential}
+
+	return authenticated
+}
@@ -0,0 +1,220 @@
+//go:build unit
+
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testCredentialEnv = "CM_TEST_FORGE_TOKEN"
+
+// recordingLogger records formatted log messages.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Logf(format string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+// stubCredentialResolver returns a fixed credential and counts the resolutions.
+type stubCredentialResolver struct {
+	credential Credential
+	err        error
+	calls      int
+}
+
+func (r *stubCredentialResolver) Resolve(_, _ string) (Credential, error) {
+	r.calls++
+	return r.credential, r.err
+}
+
+// writeCredentialFile writes content to a file in a temporary directory and returns its path.
+func writeCredentialFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestCredentialResolver_Resolve(t *testing.T) {
+	netrcPath := writeCredentialFile(t, "netrc", "machine git.example.com login user password netrc-token\n")
+	ghHostsPath := writeCredentialFile(t, "hosts.yml", "git.example.com:\n  oauth_token: gh-token\n")
+	missingPath := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name           string
+		env            string
+		helperPassword string
+		netrcPath      string
+		ghHostsPath    string
+		expected       Credential
+		expectedLog    string
+	}{
+		{
+			name:           "environment variable first",
+			env:            "env-token",
+			helperPassword: "helper-token",
+			netrcPath:      netrcPath,
+			ghHostsPath:    ghHostsPath,
+			expected:       Credential{Token: "env-token", Source: CredentialSourceEnv},
+			expectedLog:    "Using git.example.com credentials from CM_TEST_FORGE_TOKEN environment variable",
+		},
+		{
+			name:           "git credential helper",
+			helperPassword: "helper-token",
+			netrcPath:      netrcPath,
+			ghHostsPath:    ghHostsPath,
+			expected:       Credential{Token: "helper-token", Source: CredentialSourceGitCredential},
+			expectedLog:    "Using git.example.com credentials from git credential helper",
+		},
+		{
+			name:        "netrc",
+			netrcPath:   netrcPath,
+			ghHostsPath: ghHostsPath,
+			expected:    Credential{Token: "netrc-token", Source: CredentialSourceNetrc},
+			expectedLog: "Using git.example.com credentials from netrc",
+		},
+		{
+			name:        "gh hosts file",
+			netrcPath:   missingPath,
+			ghHostsPath: ghHostsPath,
+			expected:    Credential{Token: "gh-token", Source: CredentialSourceGHHosts},
+			expectedLog: "Using git.example.com credentials from gh hosts.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testCredentialEnv, tt.env)
+
+			ctrl := gomock.NewController(t)
+			mockGit := gitmocks.NewMockGit(ctrl)
+			if tt.env == "" {
+				mockGit.EXPECT().CredentialFill(git.CredentialFillParams{Host: "git.example.com"}).
+					DoAndReturn(func(git.CredentialFillParams) (*git.Credential, error) {
+						if tt.helperPassword == "" {
+							return nil, git.ErrCredentialNotFound
+						}
+						return &git.Credential{Username: "user", Password: tt.helperPassword}, nil
+					})
+			}
+
+			recorder := &recordingLogger{}
+			resolver := NewCredentialResolver(NewCredentialResolverOpts{
+				Logger:      recorder,
+				Git:         mockGit,
+				NetrcPath:   tt.netrcPath,
+				GHHostsPath: tt.ghHostsPath,
+			})
+
+			credential, err := resolver.Resolve("git.example.com", testCredentialEnv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, credential)
+			assert.Equal(t, []string{tt.expectedLog}, recorder.messages)
+		})
+	}
+}
+
+func TestCredentialResolver_Resolve_NotFound(t *testing.T) {
+	t.Setenv(testCredentialEnv, "")
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().CredentialFill(gomock.Any()).Return(nil, git.ErrCredentialNotFound)
+
+	netrcPath := filepath.Join(t.TempDir(), "netrc")
+	resolver := NewCredentialResolver(NewCredentialResolverOpts{
+		Git:         mockGit,
+		NetrcPath:   netrcPath,
+		GHHostsPath: filepath.Join(t.TempDir(), "hosts.yml"),
+	})
+
+	_, err := resolver.Resolve("git.example.com", testCredentialEnv)
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+	assert.Contains(t, err.Error(), testCredentialEnv)
+	assert.Contains(t, err.Error(), "machine git.example.com")
+	assert.Contains(t, err.Error(), netrcPath)
+}
+
+func TestParseNetrc(t *testing.T) {
+	content := `# personal machines
+machine other.example.com login other password other-token
+macdef init
+machine git.example.com login macro password macro-token
+
+machine git.example.com
+	login user
+	account team
+	password secret
+default login anonymous password default-token
+`
+
+	tests := []struct {
+		name     string
+		host     string
+		expected netrcEntry
+	}{
+		{name: "listed host", host: "git.example.com", expected: netrcEntry{login: "user", password: "secret"}},
+		{name: "first entry", host: "other.example.com", expected: netrcEntry{login: "other", password: "other-token"}},
+		{name: "default entry", host: "unknown.example.com",
+			expected: netrcEntry{login: "anonymous", password: "default-token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseNetrc(content, tt.host))
+		})
+	}
+}
+
+func TestGitLab_UnauthorizedWithoutCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("PRIVATE-TOKEN"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	resolver := &stubCredentialResolver{err: ErrCredentialsNotFound}
+	gitlab := NewGitLab(NewGitLabOpts{
+		Host:        "gitlab.example.com",
+		APIURL:      server.URL + "/api/v4",
+		Credentials: resolver,
+	})
+
+	_, err := gitlab.GetIssueInfo("group/project#1")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+}
+
+func TestGitHub_LazyCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer helper-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	resolver := &stubCredentialResolver{
+		credential: Credential{Token: "helper-token", Source: CredentialSourceGitCredential},
+	}
+	github := NewGitHub(NewGitHubOpts{Host: "github.example.com", APIURL: server.URL, Credentials: resolver})
+	assert.Equal(t, 0, resolver.calls, "credentials must not be resolved before the first request")
+
+	for range 2 {
+		_, err := github.GetIssueInfo("owner/repo#1")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Contains(t, err.Error(), "git credential helper")
+	}
+	assert.Equal(t, 1, resolver.calls)
+}
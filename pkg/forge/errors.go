@@ -5,10 +5,15 @@ import "errors"
 
 // Forge-specific errors.
 var (
-	ErrUnsupportedForge = errors.New("unsupported forge")
-	ErrIssueNotFound    = errors.New("issue not found")
-	ErrIssueClosed      = errors.New("issue is closed, only open issues are supported")
-	ErrInvalidIssueRef  = errors.New("invalid issue reference format")
-	ErrRateLimited      = errors.New("rate limited by forge API")
-	ErrUnauthorized     = errors.New("unauthorized access to forge API")
+	ErrUnsupportedForge      = errors.New("unsupported forge")
+	ErrIssueNotFound         = errors.New("issue not found")
+	ErrIssueClosed           = errors.New("issue is closed, only open issues are supported")
+	ErrInvalidIssueRef       = errors.New("invalid issue reference format")
+	ErrPullRequestNotFound   = errors.New("pull request not found")
+	ErrPullRequestClosed     = errors.New("pull request is closed, only open pull requests are supported")
+	ErrInvalidPullRequestRef = errors.New("invalid pull request reference format")
+	ErrPullRequestExists     = errors.New("a pull request already exists for this branch")
+	ErrRateLimited           = errors.New("rate limited by forge API")
+	ErrUnauthorized          = errors.New("unauthorized access to forge API")
+	ErrCredentialsNotFound   = errors.New("no forge credentials found")
 )
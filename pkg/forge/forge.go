@@ -1,12 +1,17 @@
 package forge
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/git"
 	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
 )
 
@@ -18,7 +23,7 @@ type Forge interface {
 	Name() string
 
 	// GetIssueInfo fetches issue information from the forge
-	GetIssueInfo(issueRef string) (*issue.Info, error)
+	GetIssueInfo(issueRef string, opts ...GetIssueInfoOpts) (*issue.Info, error)
 
 	// ValidateForgeRepository validates that repository has supported forge remote origin
 	ValidateForgeRepository(repoPath string) error
@@ -27,28 +32,104 @@ type Forge interface {
 	ParseIssueReference(issueRef string) (*issue.Reference, error)
 
 	// GenerateBranchName generates branch name from issue information
-	GenerateBranchName(issueInfo *issue.Info) string
+	GenerateBranchName(issueInfo *issue.Info, opts ...GenerateBranchNameOpts) (string, error)
+
+	// GetPullRequestInfo fetches pull request information (including its head repository and branch)
+	GetPullRequestInfo(prRef string, opts ...GetPullRequestInfoOpts) (*pullrequest.Info, error)
+
+	// CreatePullRequest opens a pull request on the repository pointed by the origin remote
+	CreatePullRequest(params CreatePullRequestParams) (*pullrequest.Info, error)
+
+	// ListIssues lists the open issues of the repository pointed by the origin remote
+	ListIssues(params ListIssuesParams) ([]issue.Info, error)
+}
+
+// GetIssueInfoOpts contains optional parameters for GetIssueInfo.
+type GetIssueInfoOpts struct {
+	AllowClosed bool // Return closed issues instead of failing with ErrIssueClosed
+	SkipCache   bool // Query the forge even when the issue is freshly cached, caching the new result
+}
+
+// allowClosedIssue returns true when closed issues are accepted by the options.
+func allowClosedIssue(opts []GetIssueInfoOpts) bool {
+	return len(opts) > 0 && opts[0].AllowClosed
+}
+
+// GetPullRequestInfoOpts contains optional parameters for GetPullRequestInfo.
+type GetPullRequestInfoOpts struct {
+	AllowClosed bool // Return closed and merged pull requests instead of failing with ErrPullRequestClosed
+}
+
+// allowClosedPullRequest returns true when closed and merged pull requests are accepted by the options.
+func allowClosedPullRequest(opts []GetPullRequestInfoOpts) bool {
+	return len(opts) > 0 && opts[0].AllowClosed
+}
+
+// DefaultIssueListLimit is the maximum number of issues listed when no limit is given.
+const DefaultIssueListLimit = 50
+
+// ListIssuesParams contains parameters for ListIssues.
+type ListIssuesParams struct {
+	RepoPath     string   // Local repository path, used to resolve the repository from its origin remote
+	AssignedToMe bool     // Only list issues assigned to the authenticated user
+	Labels       []string // Only list issues having all these labels
+	Milestone    string   // Only list issues in this milestone (by title)
+	Limit        int      // Maximum number of issues (defaults to DefaultIssueListLimit)
+}
+
+// limit returns the maximum number of issues to list.
+func (p ListIssuesParams) limit() int {
+	if p.Limit <= 0 {
+		return DefaultIssueListLimit
+	}
+	return p.Limit
+}
+
+// CreatePullRequestParams contains parameters for CreatePullRequest.
+type CreatePullRequestParams struct {
+	RepoPath   string // Local repository path, used to resolve the base repository from its origin remote
+	HeadOwner  string // Owner of the fork holding the head branch (empty when it is on the base repository)
+	HeadBranch string
+	BaseBranch string
+	Title      string
+	Body       string
 }
 
 // ManagerInterface defines the interface for forge management.
 type ManagerInterface interface {
 	// GetForgeForRepository returns the appropriate forge for the given repository
 	GetForgeForRepository(repoName string) (Forge, error)
+
+	// ParseIssueReference parses an issue reference with the first forge that supports its format
+	ParseIssueReference(issueRef string) (*issue.Reference, error)
 }
 
+// ManagerProvider creates forge managers, allowing callers to substitute them in tests.
+type ManagerProvider func(logger logger.Logger, statusManager status.Manager, cfg config.Config) ManagerInterface
+
 // Manager manages forge implementations and provides a unified interface.
 type Manager struct {
-	forges        map[string]Forge
+	forges        map[string]Forge // host -> forge
 	logger        logger.Logger
 	statusManager status.Manager
+	config        config.Config
+	issueCache    *IssueCache // nil when the cache directory cannot be determined
+	git           git.Git
 }
 
 // NewManager creates a new forge manager with registered forge implementations.
-func NewManager(logger logger.Logger, statusManager status.Manager) *Manager {
+func NewManager(logger logger.Logger, statusManager status.Manager, cfg config.Config) *Manager {
 	m := &Manager{
 		forges:        make(map[string]Forge),
 		logger:        logger,
 		statusManager: statusManager,
+		config:        cfg,
+		git:           git.NewGit(),
+	}
+
+	// Issues are cached next to the status file unless configured otherwise
+	if dir := cfg.IssueCacheDir(); dir != "" {
+		m.issueCache = NewIssueCache(dir, cfg.IssueCacheTTL())
 	}
 
 	// Register forge implementations
@@ -59,9 +140,62 @@ func NewManager(logger logger.Logger, statusManager status.Manager) *Manager {
 
 // registerForges registers all available forge implementations.
 func (m *Manager) registerForges() {
+	// All forges share a resolver reporting the credential sources in verbose mode
+	credentials := NewCredentialResolver(NewCredentialResolverOpts{Logger: m.logger})
+	httpOptions := HTTPOptions{
+		Timeout:      m.config.ForgeHTTP.Timeout,
+		Budget:       m.config.ForgeHTTP.Budget,
+		MaxRetries:   m.config.ForgeHTTP.MaxRetries,
+		MaxRetryWait: m.config.ForgeHTTP.MaxRetryWait,
+		CacheDir:     m.config.ForgeHTTPCacheDir(),
+	}
+
 	// Register GitHub forge
-	github := NewGitHub()
-	m.forges[github.Name()] = github
+	m.forges[GitHubDomain] = NewGitHub(NewGitHubOpts{Credentials: credentials, HTTP: httpOptions})
+
+	// Register GitLab forge
+	m.forges[GitLabDomain] = NewGitLab(NewGitLabOpts{Credentials: credentials, HTTP: httpOptions})
+
+	// Register forges declared in configuration, which may also override the default hosts
+	for host, forgeConfig := range m.config.Forges {
+		switch forgeConfig.Type {
+		case GitHubName:
+			m.forges[host] = NewGitHub(NewGitHubOpts{
+				Host:        host,
+				APIURL:      forgeConfig.APIURL,
+				TokenEnv:    forgeConfig.TokenEnv,
+				Credentials: credentials,
+				HTTP:        httpOptions,
+			})
+		case GitLabName:
+			m.forges[host] = NewGitLab(NewGitLabOpts{
+				Host:        host,
+				APIURL:      forgeConfig.APIURL,
+				TokenEnv:    forgeConfig.TokenEnv,
+				Credentials: credentials,
+				HTTP:        httpOptions,
+			})
+		case GiteaName, ForgejoName:
+			m.forges[host] = NewGitea(host, NewGiteaOpts{
+				APIURL:      forgeConfig.APIURL,
+				TokenEnv:    forgeConfig.TokenEnv,
+				Credentials: credentials,
+				HTTP:        httpOptions,
+			})
+		default:
+			m.logger.Logf("Warning: unsupported forge type '%s' for host %s", forgeConfig.Type, host)
+		}
+	}
+}
+
+// sortedHosts returns the registered forge hosts in a deterministic order.
+func (m *Manager) sortedHosts() []string {
+	hosts := make([]string, 0, len(m.forges))
+	for host := range m.forges {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
 }
 
 // GetForgeForRepository returns the appropriate forge for the given repository.
@@ -73,14 +207,35 @@ func (m *Manager) GetForgeForRepository(repoName string) (Forge, error) {
 	}
 
 	// Try each forge to see which one can validate the repository
-	for _, forge := range m.forges {
+	for _, host := range m.sortedHosts() {
+		forge := m.forges[host]
 		if err := forge.ValidateForgeRepository(repoPath); err == nil {
+			if m.issueCache != nil {
+				return newIssueCachingForge(forge, host, m.issueCache, m.git, m.logger), nil
+			}
 			return forge, nil
 		}
 	}
 	return nil, fmt.Errorf("%w: no supported forge found for repository", ErrUnsupportedForge)
 }
 
+// ParseIssueReference parses an issue reference with the first forge that supports its format.
+// Bare issue numbers return issue.ErrIssueNumberRequiresContext as they depend on the repository.
+func (m *Manager) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	var errs []error
+	for _, host := range m.sortedHosts() {
+		ref, err := m.forges[host].ParseIssueReference(issueRef)
+		if err == nil {
+			return ref, nil
+		}
+		if errors.Is(err, issue.ErrIssueNumberRequiresContext) {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
 // resolveRepository resolves a repository name to a path, checking status file first.
 func (m *Manager) resolveRepository(repoName string) (string, error) {
 	// If empty, use current directory
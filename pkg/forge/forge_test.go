@@ -5,6 +5,7 @@ package forge
 import (
 	"testing"
 
+	"github.com/lerenn/code-manager/pkg/config"
 	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
 	statusMocks "github.com/lerenn/code-manager/pkg/status/mocks"
@@ -20,12 +21,59 @@ func TestNewManager(t *testing.T) {
 	loggerInstance := logger.NewNoopLogger()
 	statusManager := statusMocks.NewMockManager(ctrl)
 
-	manager := NewManager(loggerInstance, statusManager)
+	manager := NewManager(loggerInstance, statusManager, config.Config{})
 
 	assert.NotNil(t, manager)
 	assert.NotNil(t, manager.forges)
 }
 
+func TestNewManager_RegistersConfiguredForges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statusManager := statusMocks.NewMockManager(ctrl)
+	cfg := config.Config{
+		Forges: map[string]config.ForgeConfig{
+			"gitlab.example.com":  {Type: GitLabName},
+			"gitea.example.com":   {Type: GiteaName},
+			"codeberg.org":        {Type: ForgejoName},
+			"unknown.example.com": {Type: "unknown"},
+		},
+	}
+
+	manager := NewManager(logger.NewNoopLogger(), statusManager, cfg)
+
+	assert.Contains(t, manager.forges, GitHubDomain)
+	assert.Contains(t, manager.forges, GitLabDomain)
+	require.Contains(t, manager.forges, "gitlab.example.com")
+	assert.Equal(t, GitLabName, manager.forges["gitlab.example.com"].Name())
+	require.Contains(t, manager.forges, "gitea.example.com")
+	assert.Equal(t, GiteaName, manager.forges["gitea.example.com"].Name())
+	require.Contains(t, manager.forges, "codeberg.org")
+	assert.Equal(t, GiteaName, manager.forges["codeberg.org"].Name())
+	assert.NotContains(t, manager.forges, "unknown.example.com")
+}
+
+func TestManager_ParseIssueReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statusManager := statusMocks.NewMockManager(ctrl)
+	manager := NewManager(logger.NewNoopLogger(), statusManager, config.Config{})
+
+	ref, err := manager.ParseIssueReference("https://gitlab.com/group/subgroup/project/-/issues/12")
+	require.NoError(t, err)
+	assert.Equal(t, "group/subgroup", ref.Owner)
+	assert.Equal(t, "project", ref.Repository)
+	assert.Equal(t, 12, ref.IssueNumber)
+
+	_, err = manager.ParseIssueReference("42")
+	assert.ErrorIs(t, err, issue.ErrIssueNumberRequiresContext)
+
+	_, err = manager.ParseIssueReference("invalid-issue-ref")
+	assert.Error(t, err)
+}
+
 func TestGitHub_Name(t *testing.T) {
 	github := NewGitHub()
 	assert.Equal(t, "github", github.Name())
@@ -83,7 +131,8 @@ func TestGitHub_GenerateBranchName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := github.GenerateBranchName(tt.issue)
+			result, err := github.GenerateBranchName(tt.issue)
+			require.NoError(t, err)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -153,9 +202,61 @@ func TestGitHub_ParseIssueReference(t *testing.T) {
 	}
 }
 
-func TestGitHub_sanitizeTitle(t *testing.T) {
+func TestGitHub_parsePullRequestReference(t *testing.T) {
 	github := NewGitHub()
 
+	tests := []struct {
+		name        string
+		prRef       string
+		expectError bool
+		expected    *issue.Reference
+	}{
+		{
+			name:  "GitHub pull request URL format",
+			prRef: "https://github.com/owner/repo/pull/42",
+			expected: &issue.Reference{
+				Owner:       "owner",
+				Repository:  "repo",
+				IssueNumber: 42,
+				URL:         "https://github.com/owner/repo/pull/42",
+			},
+		},
+		{
+			name:  "owner/repo#number format",
+			prRef: "owner/repo#42",
+			expected: &issue.Reference{
+				Owner:       "owner",
+				Repository:  "repo",
+				IssueNumber: 42,
+				URL:         "https://github.com/owner/repo/issues/42",
+			},
+		},
+		{
+			name:        "issue URL",
+			prRef:       "https://github.com/owner/repo/issues/42",
+			expectError: true,
+		},
+		{
+			name:        "invalid pull request number",
+			prRef:       "owner/repo#abc",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := github.parsePullRequestReference(tt.prRef)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidPullRequestRef)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSanitizeTitle(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected string
@@ -174,7 +275,7 @@ func TestGitHub_sanitizeTitle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			result := github.sanitizeTitle(tt.input)
+			result := sanitizeTitle(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,638 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+)
+
+const (
+	// GiteaName is the name identifier for Gitea forge.
+	GiteaName = "gitea"
+	// ForgejoName is the configuration type for Forgejo instances, served by the Gitea forge.
+	ForgejoName = "forgejo"
+	// GiteaTokenEnv is the environment variable holding the Gitea/Forgejo access token.
+	GiteaTokenEnv = "GITEA_TOKEN"
+	// giteaAPIPath is the path of the Gitea REST API v1 on a Gitea host.
+	giteaAPIPath = "/api/v1"
+)
+
+// Gitea represents the Gitea/Forgejo forge implementation for self-hosted instances.
+type Gitea struct {
+	host       string
+	apiURL     string
+	credential *hostCredential
+	// httpOptions bound the API calls, whose requests are retried by the HTTP client
+	httpOptions HTTPOptions
+	httpClient  *http.Client
+	git         git.Git
+}
+
+// NewGiteaOpts contains optional parameters for NewGitea.
+type NewGiteaOpts struct {
+	APIURL      string             // REST API base URL (defaults to https://<host>/api/v1)
+	TokenEnv    string             // Environment variable holding the access token (defaults to GITEA_TOKEN)
+	HTTPClient  *http.Client       // HTTP client used for API calls (defaults to http.DefaultClient)
+	HTTP        HTTPOptions        // Timeouts, retries and caching of API calls
+	Credentials CredentialResolver // Resolver of the access token (defaults to NewCredentialResolver())
+}
+
+// giteaIssue represents the subset of the Gitea issue payload used by CM.
+type giteaIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+}
+
+// giteaTimelineComment represents the subset of the Gitea issue timeline payload used by CM.
+type giteaTimelineComment struct {
+	Type     string `json:"type"`
+	RefIssue *struct {
+		Number      int    `json:"number"`
+		State       string `json:"state"`
+		HTMLURL     string `json:"html_url"`
+		PullRequest *struct {
+			Merged bool `json:"merged"`
+		} `json:"pull_request"`
+	} `json:"ref_issue"`
+}
+
+// giteaPullRequest represents the subset of the Gitea pull request payload used by CM.
+type giteaPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		Repo *struct {
+			Name     string `json:"name"`
+			CloneURL string `json:"clone_url"`
+			SSHURL   string `json:"ssh_url"`
+			Owner    struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// giteaCreatePullRequest represents the payload sent to Gitea to open a pull request.
+type giteaCreatePullRequest struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// NewGitea creates a new Gitea forge instance for the given host.
+func NewGitea(host string, opts ...NewGiteaOpts) *Gitea {
+	g := &Gitea{
+		host:       host,
+		apiURL:     "https://" + host + giteaAPIPath,
+		httpClient: http.DefaultClient,
+		git:        git.NewGit(),
+	}
+
+	tokenEnv := GiteaTokenEnv
+	var credentials CredentialResolver
+	if len(opts) > 0 {
+		if opts[0].APIURL != "" {
+			g.apiURL = strings.TrimSuffix(opts[0].APIURL, "/")
+		}
+		if opts[0].HTTPClient != nil {
+			g.httpClient = opts[0].HTTPClient
+		}
+		if opts[0].TokenEnv != "" {
+			tokenEnv = opts[0].TokenEnv
+		}
+		credentials = opts[0].Credentials
+		g.httpOptions = opts[0].HTTP
+	}
+	g.credential = newHostCredential(host, tokenEnv, credentials)
+	g.httpClient = newHTTPClient(g.httpClient, g.httpOptions)
+
+	return g
+}
+
+// Name returns the name of the forge.
+func (g *Gitea) Name() string {
+	return GiteaName
+}
+
+// GetIssueInfo fetches issue information from Gitea API.
+func (g *Gitea) GetIssueInfo(issueRef string, opts ...GetIssueInfoOpts) (*issue.Info, error) {
+	// Parse the issue reference to get repository and issue number
+	ref, err := g.parseIssueReference(issueRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// Fetch the issue from the repository issues endpoint
+	var giteaIssue giteaIssue
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d",
+		g.apiURL, url.PathEscape(ref.Owner), url.PathEscape(ref.Repository), ref.IssueNumber)
+	notFoundErr := fmt.Errorf("%w: issue #%d", ErrIssueNotFound, ref.IssueNumber)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &giteaIssue); err != nil {
+		return nil, err
+	}
+
+	// Validate issue state
+	if giteaIssue.State != issue.StateOpen && !allowClosedIssue(opts) {
+		return nil, fmt.Errorf("%w: issue #%d", ErrIssueClosed, giteaIssue.Number)
+	}
+
+	linked, err := g.linkedPullRequests(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	info := withLinkedPullRequests(giteaIssue.toInfo(ref), linked)
+	return &info, nil
+}
+
+// linkedPullRequests returns the pull requests referencing the issue in its timeline.
+// Instances older than Gitea 1.17 have no timeline, their issues have no linked pull requests.
+func (g *Gitea) linkedPullRequests(ctx context.Context, issueEndpoint string) ([]issue.LinkedPullRequest, error) {
+	var timeline []giteaTimelineComment
+	errNoTimeline := errors.New("issue timeline not available")
+	if err := g.getJSON(ctx, issueEndpoint+"/timeline", errNoTimeline, &timeline); err != nil {
+		if errors.Is(err, errNoTimeline) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var linked []issue.LinkedPullRequest
+	for _, comment := range timeline {
+		if comment.Type != "pull_ref" || comment.RefIssue == nil || comment.RefIssue.PullRequest == nil {
+			continue
+		}
+		state := comment.RefIssue.State
+		if comment.RefIssue.PullRequest.Merged {
+			state = pullrequest.StateMerged
+		}
+		linked = append(linked, issue.LinkedPullRequest{
+			Number: comment.RefIssue.Number,
+			URL:    comment.RefIssue.HTMLURL,
+			State:  state,
+		})
+	}
+	return linked, nil
+}
+
+// toInfo converts a Gitea issue to issue information.
+func (i giteaIssue) toInfo(ref *issue.Reference) issue.Info {
+	data := issueData{
+		Number:      i.Number,
+		Title:       i.Title,
+		Description: i.Body,
+		State:       i.State,
+		URL:         i.HTMLURL,
+	}
+	for _, label := range i.Labels {
+		data.Labels = append(data.Labels, label.Name)
+	}
+	for _, assignee := range i.Assignees {
+		data.Assignees = append(data.Assignees, assignee.Login)
+	}
+	if i.Milestone != nil {
+		data.Milestone = i.Milestone.Title
+	}
+
+	return newIssueInfo(ref, data)
+}
+
+// ListIssues lists the open issues of the Gitea repository of the origin remote.
+func (g *Gitea) ListIssues(params ListIssuesParams) ([]issue.Info, error) {
+	ref, err := g.originReference(params.RepoPath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("type", "issues")
+	query.Set("limit", strconv.Itoa(params.limit()))
+	if len(params.Labels) > 0 {
+		query.Set("labels", strings.Join(params.Labels, ","))
+	}
+	if params.Milestone != "" {
+		query.Set("milestones", params.Milestone)
+	}
+	if params.AssignedToMe {
+		// Gitea filters assignees by login, resolve the authenticated user first
+		var user struct {
+			Login string `json:"login"`
+		}
+		notFoundErr := fmt.Errorf("%w: authenticated user not found", ErrUnauthorized)
+		if err := g.getJSON(ctx, g.apiURL+"/user", notFoundErr, &user); err != nil {
+			return nil, err
+		}
+		query.Set("assigned_by", user.Login)
+	}
+
+	var giteaIssues []giteaIssue
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues?%s",
+		g.apiURL, url.PathEscape(ref.Owner), url.PathEscape(ref.Repository), query.Encode())
+	notFoundErr := fmt.Errorf("Gitea repository %s/%s not found", ref.Owner, ref.Repository)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &giteaIssues); err != nil {
+		return nil, err
+	}
+
+	issues := make([]issue.Info, 0, len(giteaIssues))
+	for _, item := range giteaIssues {
+		issues = append(issues, item.toInfo(ref))
+	}
+	return issues, nil
+}
+
+// getJSON performs an authenticated GET request on the Gitea API and decodes the JSON response.
+func (g *Gitea) getJSON(ctx context.Context, endpoint string, notFoundErr error, out interface{}) error {
+	return g.doJSON(ctx, http.MethodGet, endpoint, nil, notFoundErr, out)
+}
+
+// doJSON performs an authenticated request on the Gitea API, sending payload as JSON
+// when not nil, and decodes the JSON response.
+func (g *Gitea) doJSON(
+	ctx context.Context, method, endpoint string, payload interface{}, notFoundErr error, out interface{},
+) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode Gitea request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create Gitea request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := g.credential.token(); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Gitea API request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.handleGiteaError(resp, notFoundErr); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Gitea response: %w", err)
+	}
+	return nil
+}
+
+// handleGiteaError maps Gitea API error responses to forge errors.
+// notFoundErr is returned when the requested resource does not exist.
+func (g *Gitea) handleGiteaError(resp *http.Response, notFoundErr error) error {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusNotFound:
+		return notFoundErr
+	case http.StatusConflict:
+		return ErrPullRequestExists
+	case http.StatusUnauthorized:
+		return g.credential.unauthorizedError()
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: access forbidden", ErrUnauthorized)
+	case http.StatusTooManyRequests:
+		return rateLimitedError("Gitea", resp.Header)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Gitea API request failed: unexpected status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// parseIssueReference parses the issue reference and handles context extraction.
+func (g *Gitea) parseIssueReference(issueRef string) (*issue.Reference, error) {
+	ref, err := g.ParseIssueReference(issueRef)
+	if err != nil {
+		// If it's an issue number format error, try to extract repository info from current repo
+		if errors.Is(err, issue.ErrIssueNumberRequiresContext) {
+			ref, err = g.parseIssueNumberWithContext(issueRef)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidIssueRef, err)
+			}
+		} else {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidIssueRef, err)
+		}
+	}
+	return ref, nil
+}
+
+// ValidateForgeRepository validates that repository has this Gitea host as remote origin.
+func (g *Gitea) ValidateForgeRepository(repoPath string) error {
+	// Get the remote origin URL
+	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return fmt.Errorf("failed to get remote origin: %w", err)
+	}
+
+	host, _, err := parseRemoteURL(originURL)
+	if err != nil {
+		return err
+	}
+
+	if host != g.host {
+		return fmt.Errorf("repository does not have %s as remote origin", g.host)
+	}
+
+	return nil
+}
+
+// ParseIssueReference parses various issue reference formats.
+func (g *Gitea) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	// Try different formats
+
+	// 1. Gitea issue URL: https://gitea.example.com/owner/repo/issues/123
+	if strings.Contains(issueRef, "://") && strings.Contains(issueRef, "/issues/") {
+		return g.parseGiteaURL(issueRef)
+	}
+
+	// 2. Owner/repo#issue format: owner/repo#123
+	if strings.Contains(issueRef, "#") {
+		return g.parseOwnerRepoFormat(issueRef)
+	}
+
+	// 3. Issue number only: 123 (requires current repository to be on this Gitea host)
+	if matched, _ := regexp.MatchString(`^\d+$`, issueRef); matched {
+		return nil, issue.ErrIssueNumberRequiresContext
+	}
+
+	return nil, fmt.Errorf("unsupported issue reference format: %s", issueRef)
+}
+
+// parseIssueNumberWithContext parses an issue number and extracts repository info from current repo.
+func (g *Gitea) parseIssueNumberWithContext(issueRef string) (*issue.Reference, error) {
+	issueNumber, err := strconv.Atoi(issueRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", issueRef)
+	}
+
+	return g.originReference(".", issueNumber)
+}
+
+// originReference builds a reference on the repository pointed by the origin remote of the repository.
+func (g *Gitea) originReference(repoPath string, number int) (*issue.Reference, error) {
+	// Get the remote origin URL to extract owner and repository
+	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote origin: %w", err)
+	}
+
+	host, remotePath, err := parseRemoteURL(originURL)
+	if err != nil {
+		return nil, err
+	}
+	if host != g.host {
+		return nil, fmt.Errorf("remote origin %s is not hosted on %s", originURL, g.host)
+	}
+
+	return g.buildReference(remotePath, number)
+}
+
+// parseGiteaURL parses Gitea issue URLs.
+func (g *Gitea) parseGiteaURL(urlStr string) (*issue.Reference, error) {
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Gitea issue URL format: %w", err)
+	}
+	if parsedURL.Hostname() != g.host {
+		return nil, fmt.Errorf("issue URL is not hosted on %s", g.host)
+	}
+
+	// /owner/repo/issues/123
+	re := regexp.MustCompile(`^/([^/]+/[^/]+)/issues/(\d+)/?$`)
+	matches := re.FindStringSubmatch(parsedURL.Path)
+	if len(matches) != 3 {
+		return nil, fmt.Errorf("invalid Gitea issue URL format")
+	}
+
+	issueNumber, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", matches[2])
+	}
+
+	ref, err := g.buildReference(matches[1], issueNumber)
+	if err != nil {
+		return nil, err
+	}
+	ref.URL = urlStr
+	return ref, nil
+}
+
+// parseOwnerRepoFormat parses owner/repo#issue format.
+func (g *Gitea) parseOwnerRepoFormat(ref string) (*issue.Reference, error) {
+	parts := strings.Split(ref, "#")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid owner/repo#issue format")
+	}
+
+	issueNumber, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", parts[1])
+	}
+
+	return g.buildReference(parts[0], issueNumber)
+}
+
+// buildReference builds an issue reference from an owner/repo path.
+func (g *Gitea) buildReference(repoPath string, issueNumber int) (*issue.Reference, error) {
+	parts := strings.Split(strings.Trim(repoPath, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid owner/repo format")
+	}
+
+	return &issue.Reference{
+		Owner:       parts[0],
+		Repository:  parts[1],
+		IssueNumber: issueNumber,
+		URL:         fmt.Sprintf("https://%s/%s/%s/issues/%d", g.host, parts[0], parts[1], issueNumber),
+	}, nil
+}
+
+// GenerateBranchName generates branch name from issue information.
+func (g *Gitea) GenerateBranchName(issueInfo *issue.Info, opts ...GenerateBranchNameOpts) (string, error) {
+	// Format: rendered from the configured template, <issue-nb>-<sanitized-issue-title> by default
+	return generateBranchName(issueInfo, opts)
+}
+
+// GetPullRequestInfo fetches pull request information from Gitea API.
+func (g *Gitea) GetPullRequestInfo(prRef string, opts ...GetPullRequestInfoOpts) (*pullrequest.Info, error) {
+	// Parse the pull request reference to get repository and pull request number
+	ref, err := g.parsePullRequestReference(prRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// Fetch the pull request from the repository pulls endpoint
+	var pr giteaPullRequest
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d",
+		g.apiURL, url.PathEscape(ref.Owner), url.PathEscape(ref.Repository), ref.IssueNumber)
+	notFoundErr := fmt.Errorf("%w: pull request #%d", ErrPullRequestNotFound, ref.IssueNumber)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &pr); err != nil {
+		return nil, err
+	}
+
+	// Validate pull request state
+	state := pr.State
+	if pr.Merged {
+		state = pullrequest.StateMerged
+	}
+	if state != pullrequest.StateOpen && !allowClosedPullRequest(opts) {
+		return nil, fmt.Errorf("%w: pull request #%d", ErrPullRequestClosed, pr.Number)
+	}
+
+	info := &pullrequest.Info{
+		Number:     pr.Number,
+		Title:      pr.Title,
+		State:      state,
+		URL:        pr.HTMLURL,
+		BaseBranch: pr.Base.Ref,
+		HeadBranch: pr.Head.Ref,
+		Repository: ref.Repository,
+		Owner:      ref.Owner,
+	}
+
+	// The head repository is missing when the fork has been deleted, which only matters for open pull requests
+	if pr.Head.Repo == nil {
+		if state == pullrequest.StateOpen {
+			return nil, fmt.Errorf("pull request #%d head repository is no longer available", pr.Number)
+		}
+		return info, nil
+	}
+	info.HeadOwner = pr.Head.Repo.Owner.Login
+	info.HeadRepository = pr.Head.Repo.Name
+	info.HeadCloneURL = pr.Head.Repo.CloneURL
+	info.HeadSSHURL = pr.Head.Repo.SSHURL
+
+	return info, nil
+}
+
+// CreatePullRequest opens a pull request on the Gitea repository of the origin remote.
+func (g *Gitea) CreatePullRequest(params CreatePullRequestParams) (*pullrequest.Info, error) {
+	ref, err := g.originReference(params.RepoPath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// Branches from a fork are referenced as owner:branch
+	head, headOwner := params.HeadBranch, ref.Owner
+	if params.HeadOwner != "" && params.HeadOwner != ref.Owner {
+		head, headOwner = params.HeadOwner+":"+params.HeadBranch, params.HeadOwner
+	}
+
+	var pr giteaPullRequest
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls",
+		g.apiURL, url.PathEscape(ref.Owner), url.PathEscape(ref.Repository))
+	payload := giteaCreatePullRequest{Head: head, Base: params.BaseBranch, Title: params.Title, Body: params.Body}
+	notFoundErr := fmt.Errorf("Gitea repository %s/%s not found", ref.Owner, ref.Repository)
+	if err := g.doJSON(ctx, http.MethodPost, endpoint, payload, notFoundErr, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pullrequest.Info{
+		Number:         pr.Number,
+		Title:          pr.Title,
+		State:          pr.State,
+		URL:            pr.HTMLURL,
+		BaseBranch:     params.BaseBranch,
+		HeadBranch:     params.HeadBranch,
+		HeadOwner:      headOwner,
+		HeadRepository: ref.Repository,
+		Repository:     ref.Repository,
+		Owner:          ref.Owner,
+	}, nil
+}
+
+// parsePullRequestReference parses pull request URLs, owner/repo#number and number formats.
+func (g *Gitea) parsePullRequestReference(prRef string) (*issue.Reference, error) {
+	// Gitea pull request URL: https://gitea.example.com/owner/repo/pulls/123
+	if strings.Contains(prRef, "://") {
+		parsedURL, err := url.Parse(prRef)
+		if err != nil || parsedURL.Hostname() != g.host {
+			return nil, fmt.Errorf("%w: pull request URL is not hosted on %s", ErrInvalidPullRequestRef, g.host)
+		}
+
+		re := regexp.MustCompile(`^/([^/]+/[^/]+)/pulls/(\d+)/?$`)
+		matches := re.FindStringSubmatch(parsedURL.Path)
+		if len(matches) != 3 {
+			return nil, fmt.Errorf("%w: invalid Gitea pull request URL format", ErrInvalidPullRequestRef)
+		}
+
+		number, err := strconv.Atoi(matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid pull request number: %s", ErrInvalidPullRequestRef, matches[2])
+		}
+
+		ref, err := g.buildReference(matches[1], number)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPullRequestRef, err)
+		}
+		ref.URL = prRef
+		return ref, nil
+	}
+
+	// Pull requests share the issue numbering, so the other formats are parsed like issues
+	ref, err := g.parseIssueReference(prRef)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPullRequestRef, err)
+	}
+	return ref, nil
+}
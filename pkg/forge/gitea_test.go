@@ -0,0 +1,401 @@
+//go:build unit
+
+package forge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newGiteaTestServer creates an httptest server that serves a single Gitea issue,
+// on an instance without issue timeline.
+func newGiteaTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		switch r.URL.EscapedPath() {
+		case "/api/v1/repos/owner/repo/issues/42":
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		case "/api/v1/repos/owner/repo/issues/42/timeline":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.EscapedPath())
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitea_Name(t *testing.T) {
+	gitea := NewGitea("gitea.example.com")
+	assert.Equal(t, "gitea", gitea.Name())
+}
+
+func TestGitea_GetIssueInfo(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	server := newGiteaTestServer(t, http.StatusOK, `{
+		"number": 42,
+		"title": "Add Dark Mode",
+		"body": "Users want a dark theme",
+		"state": "open",
+		"html_url": "https://gitea.example.com/owner/repo/issues/42",
+		"labels": [{"name": "enhancement"}]
+	}`)
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{APIURL: server.URL + "/api/v1"})
+
+	info, err := gitea.GetIssueInfo("owner/repo#42")
+	require.NoError(t, err)
+	assert.Equal(t, &issue.Info{
+		Number:      42,
+		Title:       "Add Dark Mode",
+		Description: "Users want a dark theme",
+		State:       "open",
+		URL:         "https://gitea.example.com/owner/repo/issues/42",
+		Repository:  "repo",
+		Owner:       "owner",
+		Labels:      []string{"enhancement"},
+	}, info)
+}
+
+func TestGitea_GetIssueInfo_LinkedPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v1/repos/owner/repo/issues/42":
+			_, _ = w.Write([]byte(`{"number": 42, "title": "Add Dark Mode", "state": "open",
+				"assignees": [{"login": "alice"}], "milestone": {"title": "v2"}}`))
+		case "/api/v1/repos/owner/repo/issues/42/timeline":
+			_, _ = w.Write([]byte(`[
+				{"type": "comment"},
+				{"type": "pull_ref", "ref_issue": {"number": 43, "state": "closed",
+					"html_url": "https://gitea.example.com/owner/repo/pulls/43", "pull_request": {"merged": true}}},
+				{"type": "issue_ref", "ref_issue": {"number": 44, "state": "open"}}
+			]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.EscapedPath())
+		}
+	}))
+	t.Cleanup(server.Close)
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{
+		APIURL:      server.URL + "/api/v1",
+		Credentials: &stubCredentialResolver{credential: Credential{Token: "test-token"}},
+	})
+
+	info, err := gitea.GetIssueInfo("owner/repo#42")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, info.Assignees)
+	assert.Equal(t, "v2", info.Milestone)
+	assert.Equal(t, []issue.LinkedPullRequest{
+		{Number: 43, URL: "https://gitea.example.com/owner/repo/pulls/43", State: "merged"},
+	}, info.LinkedPullRequests)
+}
+
+func TestGitea_GetIssueInfo_Errors(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{name: "closed issue", status: http.StatusOK, body: `{"number": 42, "state": "closed"}`, expected: ErrIssueClosed},
+		{name: "not found", status: http.StatusNotFound, body: `{}`, expected: ErrIssueNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{}`, expected: ErrUnauthorized},
+		{name: "forbidden", status: http.StatusForbidden, body: `{}`, expected: ErrUnauthorized},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{}`, expected: ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGiteaTestServer(t, tt.status, tt.body)
+			gitea := NewGitea("gitea.example.com", NewGiteaOpts{
+				APIURL: server.URL + "/api/v1",
+				HTTP:   HTTPOptions{MaxRetries: -1},
+			})
+
+			_, err := gitea.GetIssueInfo("owner/repo#42")
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestGitea_ParseIssueReference(t *testing.T) {
+	gitea := NewGitea("gitea.example.com")
+
+	tests := []struct {
+		name        string
+		issueRef    string
+		expectError bool
+		expected    *issue.Reference
+	}{
+		{
+			name:     "issue URL",
+			issueRef: "https://gitea.example.com/owner/repo/issues/42",
+			expected: &issue.Reference{
+				Owner:       "owner",
+				Repository:  "repo",
+				IssueNumber: 42,
+				URL:         "https://gitea.example.com/owner/repo/issues/42",
+			},
+		},
+		{
+			name:     "owner/repo format",
+			issueRef: "owner/repo#42",
+			expected: &issue.Reference{
+				Owner:       "owner",
+				Repository:  "repo",
+				IssueNumber: 42,
+				URL:         "https://gitea.example.com/owner/repo/issues/42",
+			},
+		},
+		{
+			name:        "issue number only (requires context)",
+			issueRef:    "42",
+			expectError: true,
+		},
+		{
+			name:        "URL from another host",
+			issueRef:    "https://codeberg.org/owner/repo/issues/42",
+			expectError: true,
+		},
+		{
+			name:        "pull request URL",
+			issueRef:    "https://gitea.example.com/owner/repo/pulls/42",
+			expectError: true,
+		},
+		{
+			name:        "nested path",
+			issueRef:    "group/owner/repo#42",
+			expectError: true,
+		},
+		{
+			name:        "invalid issue number",
+			issueRef:    "owner/repo#abc",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := gitea.ParseIssueReference(tt.issueRef)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGitea_GetPullRequestInfo(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		if r.URL.EscapedPath() != "/api/v1/repos/owner/repo/pulls/5" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"number": 5,
+			"title": "Add feature",
+			"state": "open",
+			"html_url": "https://gitea.example.com/owner/repo/pulls/5",
+			"head": {"ref": "add-feature", "repo": {"name": "repo", "owner": {"login": "owner"},
+				"clone_url": "https://gitea.example.com/owner/repo.git",
+				"ssh_url": "git@gitea.example.com:owner/repo.git"}},
+			"base": {"ref": "main"}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{APIURL: server.URL + "/api/v1"})
+
+	info, err := gitea.GetPullRequestInfo("https://gitea.example.com/owner/repo/pulls/5")
+	require.NoError(t, err)
+	assert.Equal(t, &pullrequest.Info{
+		Number:         5,
+		Title:          "Add feature",
+		State:          "open",
+		URL:            "https://gitea.example.com/owner/repo/pulls/5",
+		BaseBranch:     "main",
+		HeadBranch:     "add-feature",
+		HeadOwner:      "owner",
+		HeadRepository: "repo",
+		HeadCloneURL:   "https://gitea.example.com/owner/repo.git",
+		HeadSSHURL:     "git@gitea.example.com:owner/repo.git",
+		Repository:     "repo",
+		Owner:          "owner",
+	}, info)
+	assert.False(t, info.IsFromFork())
+
+	_, err = gitea.GetPullRequestInfo("owner/repo#6")
+	assert.ErrorIs(t, err, ErrPullRequestNotFound)
+
+	_, err = gitea.GetPullRequestInfo("https://codeberg.org/owner/repo/pulls/5")
+	assert.ErrorIs(t, err, ErrInvalidPullRequestRef)
+}
+
+func TestGitea_AllowClosed(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v1/repos/owner/repo/issues/3":
+			_, _ = w.Write([]byte(`{"number": 3, "title": "Fix login", "state": "closed"}`))
+		case "/api/v1/repos/owner/repo/pulls/5":
+			_, _ = w.Write([]byte(`{
+				"number": 5,
+				"title": "Add feature",
+				"state": "closed",
+				"merged": true,
+				"head": {"ref": "add-feature", "repo": null},
+				"base": {"ref": "main"}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{APIURL: server.URL + "/api/v1"})
+
+	_, err := gitea.GetIssueInfo("owner/repo#3")
+	assert.ErrorIs(t, err, ErrIssueClosed)
+
+	issueInfo, err := gitea.GetIssueInfo("owner/repo#3", GetIssueInfoOpts{AllowClosed: true})
+	require.NoError(t, err)
+	assert.Equal(t, issue.StateClosed, issueInfo.State)
+
+	prInfo, err := gitea.GetPullRequestInfo("owner/repo#5", GetPullRequestInfoOpts{AllowClosed: true})
+	require.NoError(t, err)
+	assert.Equal(t, pullrequest.StateMerged, prInfo.State)
+	assert.Empty(t, prInfo.HeadOwner)
+}
+
+func TestGitea_CreatePullRequest(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/api/v1/repos/owner/repo/pulls" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var payload giteaCreatePullRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, giteaCreatePullRequest{
+			Head:  "contributor:add-feature",
+			Base:  "main",
+			Title: "Add feature",
+			Body:  "Closes #4",
+		}, payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+			"number": 5,
+			"title": "Add feature",
+			"state": "open",
+			"html_url": "https://gitea.example.com/owner/repo/pulls/5"
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("https://gitea.example.com/owner/repo.git", nil)
+
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{APIURL: server.URL + "/api/v1"})
+	gitea.git = mockGit
+
+	info, err := gitea.CreatePullRequest(CreatePullRequestParams{
+		RepoPath:   "/repo",
+		HeadOwner:  "contributor",
+		HeadBranch: "add-feature",
+		BaseBranch: "main",
+		Title:      "Add feature",
+		Body:       "Closes #4",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &pullrequest.Info{
+		Number:         5,
+		Title:          "Add feature",
+		State:          "open",
+		URL:            "https://gitea.example.com/owner/repo/pulls/5",
+		BaseBranch:     "main",
+		HeadBranch:     "add-feature",
+		HeadOwner:      "contributor",
+		HeadRepository: "repo",
+		Repository:     "repo",
+		Owner:          "owner",
+	}, info)
+	assert.True(t, info.IsFromFork())
+}
+
+func TestGitea_ListIssues(t *testing.T) {
+	t.Setenv(GiteaTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		switch r.URL.EscapedPath() {
+		case "/api/v1/user":
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		case "/api/v1/repos/owner/repo/issues":
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			assert.Equal(t, "issues", r.URL.Query().Get("type"))
+			assert.Equal(t, "octocat", r.URL.Query().Get("assigned_by"))
+			assert.Equal(t, "enhancement", r.URL.Query().Get("labels"))
+			assert.Equal(t, "50", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`[{
+				"number": 42,
+				"title": "Add Dark Mode",
+				"state": "open",
+				"html_url": "https://gitea.example.com/owner/repo/issues/42",
+				"labels": [{"name": "enhancement"}]
+			}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("https://gitea.example.com/owner/repo.git", nil)
+
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{APIURL: server.URL + "/api/v1"})
+	gitea.git = mockGit
+
+	issues, err := gitea.ListIssues(ListIssuesParams{
+		RepoPath:     "/repo",
+		AssignedToMe: true,
+		Labels:       []string{"enhancement"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []issue.Info{{
+		Number:     42,
+		Title:      "Add Dark Mode",
+		State:      "open",
+		URL:        "https://gitea.example.com/owner/repo/issues/42",
+		Repository: "repo",
+		Owner:      "owner",
+		Labels:     []string{"enhancement"},
+	}}, issues)
+}
+
+func TestGitea_GenerateBranchName(t *testing.T) {
+	gitea := NewGitea("gitea.example.com")
+
+	result, err := gitea.GenerateBranchName(&issue.Info{Number: 42, Title: "Add Dark Mode"})
+	require.NoError(t, err)
+	assert.Equal(t, "42-add-dark-mode", result)
+}
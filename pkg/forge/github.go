@@ -1,18 +1,18 @@
 package forge
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/go-github/v62/github"
 	"github.com/lerenn/code-manager/pkg/git"
 	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 )
 
 const (
@@ -20,31 +20,77 @@ const (
 	GitHubName = "github"
 	// GitHubDomain is the GitHub domain for URL validation.
 	GitHubDomain = "github.com"
+	// GitHubTokenEnv is the environment variable holding the GitHub access token.
+	GitHubTokenEnv = "GITHUB_TOKEN"
+	// gitHubEnterpriseAPIPath is the path of the REST API on a GitHub Enterprise Server host.
+	gitHubEnterpriseAPIPath = "/api/v3/"
+	// gitHubEnterpriseUploadPath is the path of the upload API on a GitHub Enterprise Server host.
+	gitHubEnterpriseUploadPath = "/api/uploads/"
 	// MaxTitleLength is the maximum length for sanitized issue titles in branch names.
 	MaxTitleLength = 80
 )
 
-// GitHub represents the GitHub forge implementation.
+// GitHub represents the GitHub forge implementation for github.com and GitHub Enterprise Server.
 type GitHub struct {
-	client *github.Client
-	git    git.Git
+	host       string
+	credential *hostCredential
+	// httpOptions bound the API calls, whose requests are retried by the HTTP client
+	httpOptions HTTPOptions
+	client      *github.Client
+	git         git.Git
 }
 
-// NewGitHub creates a new GitHub forge instance.
-func NewGitHub() *GitHub {
-	var client *github.Client
+// NewGitHubOpts contains optional parameters for NewGitHub.
+type NewGitHubOpts struct {
+	Host        string             // GitHub hostname (defaults to github.com)
+	APIURL      string             // REST API base URL (defaults to https://<host>/api/v3/ for GitHub Enterprise)
+	TokenEnv    string             // Environment variable holding the access token (defaults to GITHUB_TOKEN)
+	HTTPClient  *http.Client       // HTTP client used for API calls (defaults to http.DefaultClient)
+	HTTP        HTTPOptions        // Timeouts, retries and caching of API calls
+	Credentials CredentialResolver // Resolver of the access token (defaults to NewCredentialResolver())
+}
 
-	// Add authentication if available
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		client = github.NewTokenClient(context.Background(), token)
-	} else {
-		client = github.NewClient(nil)
+// NewGitHub creates a new GitHub forge instance.
+func NewGitHub(opts ...NewGitHubOpts) *GitHub {
+	g := &GitHub{
+		host: GitHubDomain,
+		git:  git.NewGit(),
 	}
 
-	return &GitHub{
-		client: client,
-		git:    git.NewGit(),
+	var options NewGitHubOpts
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Host != "" {
+		g.host = options.Host
+	}
+	tokenEnv := GitHubTokenEnv
+	if options.TokenEnv != "" {
+		tokenEnv = options.TokenEnv
+	}
+	g.credential = newHostCredential(g.host, tokenEnv, options.Credentials)
+
+	// Credentials are resolved on the first API call rather than on creation
+	// Credentials are set outermost so that retried requests are authenticated the same way
+	g.httpOptions = options.HTTP
+	g.client = github.NewClient(authenticatedHTTPClient(newHTTPClient(options.HTTPClient, g.httpOptions), g.credential))
+
+	// GitHub Enterprise Server hosts its API on the instance itself
+	if g.host != GitHubDomain || options.APIURL != "" {
+		apiURL := options.APIURL
+		if apiURL == "" {
+			apiURL = "https://" + g.host + gitHubEnterpriseAPIPath
+		}
+		client, err := g.client.WithEnterpriseURLs(apiURL, "https://"+g.host+gitHubEnterpriseUploadPath)
+		if err != nil {
+			// The API URL is validated with the configuration, fall back to the default instance URL
+			client, _ = g.client.WithEnterpriseURLs("https://"+g.host+gitHubEnterpriseAPIPath,
+				"https://"+g.host+gitHubEnterpriseUploadPath)
+		}
+		g.client = client
 	}
+
+	return g
 }
 
 // Name returns the name of the forge.
@@ -53,7 +99,7 @@ func (g *GitHub) Name() string {
 }
 
 // GetIssueInfo fetches issue information from GitHub API.
-func (g *GitHub) GetIssueInfo(issueRef string) (*issue.Info, error) {
+func (g *GitHub) GetIssueInfo(issueRef string, opts ...GetIssueInfoOpts) (*issue.Info, error) {
 	// Parse the issue reference to get repository and issue number
 	ref, err := g.parseIssueReference(issueRef)
 	if err != nil {
@@ -61,29 +107,107 @@ func (g *GitHub) GetIssueInfo(issueRef string) (*issue.Info, error) {
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := g.httpOptions.newCallContext()
 	defer cancel()
 
 	// Fetch the issue using the GitHub client
 	githubIssue, resp, err := g.client.Issues.Get(ctx, ref.Owner, ref.Repository, ref.IssueNumber)
 	if err != nil {
-		return nil, g.handleGitHubError(err, resp, ref.IssueNumber)
+		return nil, g.handleGitHubError(err, resp, fmt.Errorf("%w: issue #%d", ErrIssueNotFound, ref.IssueNumber))
 	}
 
 	// Validate issue state
-	if githubIssue.GetState() != "open" {
+	if githubIssue.GetState() != issue.StateOpen && !allowClosedIssue(opts) {
 		return nil, fmt.Errorf("%w: issue #%d", ErrIssueClosed, githubIssue.GetNumber())
 	}
 
-	return &issue.Info{
+	// Pull requests referencing the issue are cross-reference events of its timeline
+	timeline, resp, err := g.client.Issues.ListIssueTimeline(ctx, ref.Owner, ref.Repository, ref.IssueNumber,
+		&github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, g.handleGitHubError(err, resp, fmt.Errorf("%w: issue #%d", ErrIssueNotFound, ref.IssueNumber))
+	}
+
+	info := withLinkedPullRequests(githubIssueToInfo(githubIssue, ref.Owner, ref.Repository),
+		githubLinkedPullRequests(timeline))
+	return &info, nil
+}
+
+// githubIssueToInfo converts a GitHub issue to issue information.
+func githubIssueToInfo(githubIssue *github.Issue, owner, repository string) issue.Info {
+	data := issueData{
 		Number:      githubIssue.GetNumber(),
 		Title:       githubIssue.GetTitle(),
 		Description: githubIssue.GetBody(),
 		State:       githubIssue.GetState(),
 		URL:         githubIssue.GetHTMLURL(),
-		Repository:  ref.Repository,
-		Owner:       ref.Owner,
-	}, nil
+		Milestone:   githubIssue.GetMilestone().GetTitle(),
+	}
+	for _, label := range githubIssue.Labels {
+		data.Labels = append(data.Labels, label.GetName())
+	}
+	for _, assignee := range githubIssue.Assignees {
+		data.Assignees = append(data.Assignees, assignee.GetLogin())
+	}
+
+	return newIssueInfo(&issue.Reference{Owner: owner, Repository: repository}, data)
+}
+
+// githubLinkedPullRequests returns the pull requests cross-referencing an issue in its timeline.
+func githubLinkedPullRequests(timeline []*github.Timeline) []issue.LinkedPullRequest {
+	var linked []issue.LinkedPullRequest
+	for _, event := range timeline {
+		if event.GetEvent() != "cross-referenced" || event.Source == nil || event.Source.Issue == nil ||
+			!event.Source.Issue.IsPullRequest() {
+			continue
+		}
+
+		pr := event.Source.Issue
+		state := pr.GetState()
+		if pr.PullRequestLinks.MergedAt != nil {
+			state = pullrequest.StateMerged
+		}
+		linked = append(linked, issue.LinkedPullRequest{Number: pr.GetNumber(), URL: pr.GetHTMLURL(), State: state})
+	}
+	return linked
+}
+
+// ListIssues lists the open issues of the GitHub repository of the origin remote.
+func (g *GitHub) ListIssues(params ListIssuesParams) ([]issue.Info, error) {
+	owner, repo, err := g.originOwnerRepository(params.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// The search API filters by assignee, label and milestone names in a single request
+	query := fmt.Sprintf("repo:%s/%s is:issue is:open", owner, repo)
+	if params.AssignedToMe {
+		query += " assignee:@me"
+	}
+	for _, label := range params.Labels {
+		query += fmt.Sprintf(" label:%q", label)
+	}
+	if params.Milestone != "" {
+		query += fmt.Sprintf(" milestone:%q", params.Milestone)
+	}
+
+	result, resp, err := g.client.Search.Issues(ctx, query, &github.SearchOptions{
+		Sort:        "updated",
+		ListOptions: github.ListOptions{PerPage: params.limit()},
+	})
+	if err != nil {
+		return nil, g.handleGitHubError(err, resp, fmt.Errorf("GitHub repository %s/%s not found", owner, repo))
+	}
+
+	issues := make([]issue.Info, 0, len(result.Issues))
+	for _, githubIssue := range result.Issues {
+		issues = append(issues, githubIssueToInfo(githubIssue, owner, repo))
+	}
+	return issues, nil
 }
 
 // parseIssueReference parses the issue reference and handles context extraction.
@@ -104,25 +228,31 @@ func (g *GitHub) parseIssueReference(issueRef string) (*issue.Reference, error)
 }
 
 // handleGitHubError handles GitHub API errors and returns appropriate error messages.
-func (g *GitHub) handleGitHubError(err error, resp *github.Response, issueNumber int) error {
+// notFoundErr is returned when the requested resource does not exist.
+func (g *GitHub) handleGitHubError(err error, resp *github.Response, notFoundErr error) error {
 	if resp != nil {
 		switch resp.StatusCode {
 		case http.StatusNotFound:
-			return fmt.Errorf("%w: issue #%d", ErrIssueNotFound, issueNumber)
+			return notFoundErr
 		case http.StatusUnauthorized:
-			return fmt.Errorf("%w: check GITHUB_TOKEN environment variable", ErrUnauthorized)
+			return g.credential.unauthorizedError()
 		case http.StatusForbidden:
 			// Check if it's rate limiting
 			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
-				return fmt.Errorf("%w: GitHub API rate limit exceeded", ErrRateLimited)
+				return rateLimitedError("GitHub", resp.Header)
 			}
 			return fmt.Errorf("%w: access forbidden", ErrUnauthorized)
+		case http.StatusUnprocessableEntity:
+			// Validation errors include pull requests opened twice for the same branch
+			if strings.Contains(err.Error(), "already exists") {
+				return ErrPullRequestExists
+			}
 		}
 	}
-	return fmt.Errorf("failed to fetch issue: %w", err)
+	return fmt.Errorf("GitHub API request failed: %w", err)
 }
 
-// ValidateForgeRepository validates that repository has GitHub remote origin.
+// ValidateForgeRepository validates that repository has this GitHub host as remote origin.
 func (g *GitHub) ValidateForgeRepository(repoPath string) error {
 	// Get the remote origin URL
 	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
@@ -130,10 +260,14 @@ func (g *GitHub) ValidateForgeRepository(repoPath string) error {
 		return fmt.Errorf("failed to get remote origin: %w", err)
 	}
 
-	// Check if it's a GitHub repository by looking for github.com in the URL
 	// This handles both HTTPS (https://github.com/owner/repo.git) and SSH (git@github.com:owner/repo.git) URLs
-	if !strings.Contains(originURL, GitHubDomain) {
-		return fmt.Errorf("repository does not have GitHub as remote origin")
+	host, _, err := parseRemoteURL(originURL)
+	if err != nil {
+		return err
+	}
+
+	if host != g.host {
+		return fmt.Errorf("repository does not have %s as remote origin", g.host)
 	}
 
 	return nil
@@ -144,7 +278,7 @@ func (g *GitHub) ParseIssueReference(issueRef string) (*issue.Reference, error)
 	// Try different formats
 
 	// 1. GitHub issue URL: https://github.com/owner/repo/issues/123
-	if strings.Contains(issueRef, "github.com") && strings.Contains(issueRef, "/issues/") {
+	if strings.Contains(issueRef, "://") && strings.Contains(issueRef, "/issues/") {
 		return g.parseGitHubURL(issueRef)
 	}
 
@@ -169,81 +303,92 @@ func (g *GitHub) parseIssueNumberWithContext(issueRef string) (*issue.Reference,
 		return nil, fmt.Errorf("invalid issue number format: %s", issueRef)
 	}
 
-	// Get the remote origin URL to extract owner and repository
-	originURL, err := g.git.GetRemoteURL(".", "origin")
+	owner, repo, err := g.originOwnerRepository(".")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remote origin: %w", err)
+		return nil, err
 	}
 
-	// Extract owner and repository from the remote URL
-	// Handle both HTTPS and SSH formats
-	var owner, repo string
+	// Convert issue number to int
+	var issueNumber int
+	if _, err := fmt.Sscanf(issueRef, "%d", &issueNumber); err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", issueRef)
+	}
 
-	// Try HTTPS format: https://github.com/owner/repo.git
-	if strings.Contains(originURL, "https://github.com/") {
-		re := regexp.MustCompile(`github\.com/([^/]+)/([^/]+?)(?:\.git)?$`)
-		matches := re.FindStringSubmatch(originURL)
-		if len(matches) == 3 {
-			owner = matches[1]
-			repo = matches[2]
-		}
-	} else if strings.Contains(originURL, "git@github.com:") {
-		// SSH format: git@github.com:owner/repo.git
-		re := regexp.MustCompile(`github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
-		matches := re.FindStringSubmatch(originURL)
-		if len(matches) == 3 {
-			owner = matches[1]
-			repo = matches[2]
-		}
+	return g.buildReference(owner, repo, issueNumber), nil
+}
+
+// originOwnerRepository extracts owner and repository from the origin remote of the repository.
+func (g *GitHub) originOwnerRepository(repoPath string) (string, string, error) {
+	// Get the remote origin URL to extract owner and repository
+	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get remote origin: %w", err)
 	}
 
-	if owner == "" || repo == "" {
-		return nil, fmt.Errorf("failed to extract owner and repository from remote origin: %s", originURL)
+	// Extract owner and repository from the remote URL, in HTTPS or SSH format
+	host, remotePath, err := parseRemoteURL(originURL)
+	if err != nil {
+		return "", "", err
+	}
+	if host != g.host {
+		return "", "", fmt.Errorf("remote origin %s is not hosted on %s", originURL, g.host)
 	}
 
-	// Convert issue number to int
-	var issueNumber int
-	if _, err := fmt.Sscanf(issueRef, "%d", &issueNumber); err != nil {
-		return nil, fmt.Errorf("invalid issue number: %s", issueRef)
+	parts := strings.Split(remotePath, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("failed to extract owner and repository from remote origin: %s", originURL)
 	}
 
-	// Build the URL
-	url := fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, issueNumber)
+	return parts[0], parts[1], nil
+}
 
+// buildReference builds an issue reference on this GitHub host.
+func (g *GitHub) buildReference(owner, repo string, issueNumber int) *issue.Reference {
 	return &issue.Reference{
 		Owner:       owner,
 		Repository:  repo,
 		IssueNumber: issueNumber,
-		URL:         url,
-	}, nil
+		URL:         fmt.Sprintf("https://%s/%s/%s/issues/%d", g.host, owner, repo, issueNumber),
+	}
 }
 
 // parseGitHubURL parses GitHub issue URLs.
 func (g *GitHub) parseGitHubURL(urlStr string) (*issue.Reference, error) {
 	// Extract owner, repo, and issue number from URL
 	// https://github.com/owner/repo/issues/123
-	re := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/issues/(\d+)`)
-	matches := re.FindStringSubmatch(urlStr)
-	if len(matches) != 4 {
-		return nil, fmt.Errorf("invalid GitHub issue URL format")
+	owner, repo, issueNumber, err := g.parseHostURL(urlStr, "issues")
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub issue URL format: %w", err)
 	}
 
-	owner := matches[1]
-	repo := matches[2]
-	issueNum := matches[3]
+	ref := g.buildReference(owner, repo, issueNumber)
+	ref.URL = urlStr
+	return ref, nil
+}
 
-	// Convert issue number to int
-	var issueNumber int
-	if _, err := fmt.Sscanf(issueNum, "%d", &issueNumber); err != nil {
-		return nil, fmt.Errorf("invalid issue number: %s", issueNum)
+// parseHostURL extracts owner, repository and number from a URL of this host such as
+// https://<host>/owner/repo/<kind>/123.
+func (g *GitHub) parseHostURL(urlStr, kind string) (string, string, int, error) {
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if parsedURL.Hostname() != g.host {
+		return "", "", 0, fmt.Errorf("URL is not hosted on %s", g.host)
 	}
 
-	return &issue.Reference{
-		Owner:       owner,
-		Repository:  repo,
-		IssueNumber: issueNumber,
-		URL:         urlStr,
-	}, nil
+	re := regexp.MustCompile(`^/([^/]+)/([^/]+)/` + kind + `/(\d+)/?$`)
+	matches := re.FindStringSubmatch(parsedURL.Path)
+	if len(matches) != 4 {
+		return "", "", 0, fmt.Errorf("expected /owner/repo/%s/<number> path", kind)
+	}
+
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid number: %s", matches[3])
+	}
+
+	return matches[1], matches[2], number, nil
 }
 
 // parseOwnerRepoFormat parses owner/repo#issue format.
@@ -272,53 +417,127 @@ func (g *GitHub) parseOwnerRepoFormat(ref string) (*issue.Reference, error) {
 		return nil, fmt.Errorf("invalid issue number: %s", issueNum)
 	}
 
-	// Build the URL
-	url := fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, issueNumber)
-
-	return &issue.Reference{
-		Owner:       owner,
-		Repository:  repo,
-		IssueNumber: issueNumber,
-		URL:         url,
-	}, nil
+	return g.buildReference(owner, repo, issueNumber), nil
 }
 
 // GenerateBranchName generates branch name from issue information.
-func (g *GitHub) GenerateBranchName(issueInfo *issue.Info) string {
-	// Format: <issue-nb>-<sanitized-issue-title>
+func (g *GitHub) GenerateBranchName(issueInfo *issue.Info, opts ...GenerateBranchNameOpts) (string, error) {
+	// Format: rendered from the configured template, <issue-nb>-<sanitized-issue-title> by default
+	return generateBranchName(issueInfo, opts)
+}
+
+// GetPullRequestInfo fetches pull request information from GitHub API.
+func (g *GitHub) GetPullRequestInfo(prRef string, opts ...GetPullRequestInfoOpts) (*pullrequest.Info, error) {
+	// Parse the pull request reference to get repository and pull request number
+	ref, err := g.parsePullRequestReference(prRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
 
-	// Sanitize the title
-	sanitizedTitle := g.sanitizeTitle(issueInfo.Title)
+	// Fetch the pull request using the GitHub client
+	pr, resp, err := g.client.PullRequests.Get(ctx, ref.Owner, ref.Repository, ref.IssueNumber)
+	if err != nil {
+		return nil, g.handleGitHubError(err, resp,
+			fmt.Errorf("%w: pull request #%d", ErrPullRequestNotFound, ref.IssueNumber))
+	}
 
-	// Limit length to MaxTitleLength
-	if len(sanitizedTitle) > MaxTitleLength {
-		sanitizedTitle = sanitizedTitle[:MaxTitleLength]
+	// Validate pull request state
+	state := pr.GetState()
+	if pr.GetMerged() {
+		state = pullrequest.StateMerged
+	}
+	if state != pullrequest.StateOpen && !allowClosedPullRequest(opts) {
+		return nil, fmt.Errorf("%w: pull request #%d", ErrPullRequestClosed, pr.GetNumber())
 	}
 
-	// Ensure no trailing hyphens
-	sanitizedTitle = strings.Trim(sanitizedTitle, "-")
+	// The head repository is nil when the fork has been deleted, which only matters for open pull requests
+	headRepo := pr.GetHead().GetRepo()
+	if headRepo == nil && state == pullrequest.StateOpen {
+		return nil, fmt.Errorf("pull request #%d head repository is no longer available", pr.GetNumber())
+	}
 
-	return fmt.Sprintf("%d-%s", issueInfo.Number, sanitizedTitle)
+	return &pullrequest.Info{
+		Number:         pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		State:          state,
+		URL:            pr.GetHTMLURL(),
+		BaseBranch:     pr.GetBase().GetRef(),
+		HeadBranch:     pr.GetHead().GetRef(),
+		HeadOwner:      headRepo.GetOwner().GetLogin(),
+		HeadRepository: headRepo.GetName(),
+		HeadCloneURL:   headRepo.GetCloneURL(),
+		HeadSSHURL:     headRepo.GetSSHURL(),
+		Repository:     ref.Repository,
+		Owner:          ref.Owner,
+	}, nil
 }
 
-// sanitizeTitle sanitizes the issue title for use in branch names.
-func (g *GitHub) sanitizeTitle(title string) string {
-	// Convert to lowercase
-	title = strings.ToLower(title)
+// CreatePullRequest opens a pull request on the GitHub repository of the origin remote.
+func (g *GitHub) CreatePullRequest(params CreatePullRequestParams) (*pullrequest.Info, error) {
+	owner, repo, err := g.originOwnerRepository(params.RepoPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// Replace spaces with hyphens
-	title = strings.ReplaceAll(title, " ", "-")
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
 
-	// Replace all non-alphanumeric characters with hyphens
-	re := regexp.MustCompile(`[^a-z0-9-]`)
-	title = re.ReplaceAllString(title, "-")
+	// Branches from a fork are referenced as owner:branch
+	head, headOwner := params.HeadBranch, owner
+	if params.HeadOwner != "" && params.HeadOwner != owner {
+		head, headOwner = params.HeadOwner+":"+params.HeadBranch, params.HeadOwner
+	}
 
-	// Replace multiple consecutive hyphens with single hyphen
-	re = regexp.MustCompile(`-+`)
-	title = re.ReplaceAllString(title, "-")
+	pr, resp, err := g.client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.String(params.Title),
+		Head:  github.String(head),
+		Base:  github.String(params.BaseBranch),
+		Body:  github.String(params.Body),
+	})
+	if err != nil {
+		return nil, g.handleGitHubError(err, resp, fmt.Errorf("GitHub repository %s/%s not found", owner, repo))
+	}
 
-	// Trim leading and trailing hyphens
-	title = strings.Trim(title, "-")
+	return &pullrequest.Info{
+		Number:         pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		State:          pr.GetState(),
+		URL:            pr.GetHTMLURL(),
+		BaseBranch:     params.BaseBranch,
+		HeadBranch:     params.HeadBranch,
+		HeadOwner:      headOwner,
+		HeadRepository: repo,
+		Repository:     repo,
+		Owner:          owner,
+	}, nil
+}
 
-	return title
+// parsePullRequestReference parses pull request URLs, owner/repo#number and number formats.
+func (g *GitHub) parsePullRequestReference(prRef string) (*issue.Reference, error) {
+	// GitHub pull request URL: https://github.com/owner/repo/pull/123
+	if strings.Contains(prRef, "://") {
+		owner, repo, number, err := g.parseHostURL(prRef, "pull")
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid GitHub pull request URL format: %w", ErrInvalidPullRequestRef, err)
+		}
+
+		return &issue.Reference{
+			Owner:       owner,
+			Repository:  repo,
+			IssueNumber: number,
+			URL:         prRef,
+		}, nil
+	}
+
+	// Pull requests share the issue numbering, so the other formats are parsed like issues
+	ref, err := g.parseIssueReference(prRef)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPullRequestRef, err)
+	}
+	return ref, nil
 }
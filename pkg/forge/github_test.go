@@ -0,0 +1,205 @@
+//go:build unit
+
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
+	statusMocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGitHub_Enterprise_GetIssueInfo(t *testing.T) {
+	t.Setenv("GHE_TOKEN", "enterprise-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer enterprise-token", r.Header.Get("Authorization"))
+		switch r.URL.EscapedPath() {
+		case "/api/v3/repos/owner/repo/issues/5":
+			_, _ = w.Write([]byte(`{
+				"number": 5,
+				"title": "Fix login",
+				"state": "open",
+				"html_url": "https://github.example.com/owner/repo/issues/5",
+				"assignees": [{"login": "octocat"}],
+				"milestone": {"title": "Sprint 3"}
+			}`))
+		case "/api/v3/repos/owner/repo/issues/5/timeline":
+			// Only pull requests referencing the issue are linked
+			_, _ = w.Write([]byte(`[
+				{"event": "labeled"},
+				{"event": "cross-referenced", "source": {"issue": {"number": 4,
+					"html_url": "https://github.example.com/owner/repo/issues/4"}}},
+				{"event": "cross-referenced", "source": {"issue": {"number": 6, "state": "closed",
+					"html_url": "https://github.example.com/owner/repo/pull/6",
+					"pull_request": {"merged_at": "2024-01-02T03:04:05Z"}}}}
+			]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.EscapedPath())
+		}
+	}))
+	t.Cleanup(server.Close)
+	github := NewGitHub(NewGitHubOpts{Host: "github.example.com", APIURL: server.URL, TokenEnv: "GHE_TOKEN"})
+
+	info, err := github.GetIssueInfo("https://github.example.com/owner/repo/issues/5")
+	require.NoError(t, err)
+	assert.Equal(t, &issue.Info{
+		Number:     5,
+		Title:      "Fix login",
+		State:      issue.StateOpen,
+		URL:        "https://github.example.com/owner/repo/issues/5",
+		Repository: "repo",
+		Owner:      "owner",
+		Assignees:  []string{"octocat"},
+		Milestone:  "Sprint 3",
+		LinkedPullRequests: []issue.LinkedPullRequest{
+			{Number: 6, URL: "https://github.example.com/owner/repo/pull/6", State: "merged"},
+		},
+	}, info)
+
+	// Issues of other hosts are not handled by this instance
+	_, err = github.GetIssueInfo("https://github.com/owner/repo/issues/5")
+	assert.ErrorIs(t, err, ErrInvalidIssueRef)
+}
+
+func TestGitHub_Enterprise_GetPullRequestInfo_RenamedFork(t *testing.T) {
+	t.Setenv("GHE_TOKEN", "enterprise-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v3/repos/owner/repo/pulls/3" {
+			t.Errorf("unexpected request: %s", r.URL.EscapedPath())
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"number": 3,
+			"title": "Add feature",
+			"state": "open",
+			"html_url": "https://github.example.com/owner/repo/pull/3",
+			"base": {"ref": "main"},
+			"head": {"ref": "add-feature", "repo": {"name": "repo-fork", "owner": {"login": "contributor"},
+				"clone_url": "https://github.example.com/contributor/repo-fork.git",
+				"ssh_url": "git@github.example.com:contributor/repo-fork.git"}}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	github := NewGitHub(NewGitHubOpts{Host: "github.example.com", APIURL: server.URL, TokenEnv: "GHE_TOKEN"})
+
+	info, err := github.GetPullRequestInfo("https://github.example.com/owner/repo/pull/3")
+	require.NoError(t, err)
+	assert.Equal(t, &pullrequest.Info{
+		Number:         3,
+		Title:          "Add feature",
+		State:          pullrequest.StateOpen,
+		URL:            "https://github.example.com/owner/repo/pull/3",
+		BaseBranch:     "main",
+		HeadBranch:     "add-feature",
+		HeadOwner:      "contributor",
+		HeadRepository: "repo-fork",
+		HeadCloneURL:   "https://github.example.com/contributor/repo-fork.git",
+		HeadSSHURL:     "git@github.example.com:contributor/repo-fork.git",
+		Repository:     "repo",
+		Owner:          "owner",
+	}, info)
+	assert.True(t, info.IsFromFork())
+}
+
+func TestGitHub_Enterprise_ParseIssueReference(t *testing.T) {
+	github := NewGitHub(NewGitHubOpts{Host: "github.example.com"})
+
+	ref, err := github.ParseIssueReference("owner/repo#12")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/owner/repo/issues/12", ref.URL)
+
+	prRef, err := github.parsePullRequestReference("https://github.example.com/owner/repo/pull/3")
+	require.NoError(t, err)
+	assert.Equal(t, 3, prRef.IssueNumber)
+
+	_, err = github.parsePullRequestReference("https://github.com/owner/repo/pull/3")
+	assert.ErrorIs(t, err, ErrInvalidPullRequestRef)
+}
+
+func TestGitHub_ValidateForgeRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		originURL string
+		expectErr bool
+	}{
+		{name: "github.com over SSH", host: GitHubDomain, originURL: "git@github.com:owner/repo.git"},
+		{name: "github.com over HTTPS", host: GitHubDomain, originURL: "https://github.com/owner/repo.git"},
+		{name: "enterprise host", host: "github.example.com", originURL: "git@github.example.com:owner/repo.git"},
+		{
+			name:      "enterprise remote on github.com instance",
+			host:      GitHubDomain,
+			originURL: "https://github.example.com/owner/repo.git",
+			expectErr: true,
+		},
+		{
+			name:      "lookalike host",
+			host:      GitHubDomain,
+			originURL: "https://notgithub.com/owner/repo.git",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGit := gitmocks.NewMockGit(ctrl)
+			mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return(tt.originURL, nil)
+
+			github := NewGitHub(NewGitHubOpts{Host: tt.host})
+			github.git = mockGit
+
+			err := github.ValidateForgeRepository("/repo")
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManager_GetForgeForRepository_Enterprise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	statusManager := statusMocks.NewMockManager(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+
+	cfg := config.Config{
+		Forges: map[string]config.ForgeConfig{
+			"github.example.com": {Type: GitHubName, TokenEnv: "GHE_TOKEN"},
+		},
+	}
+	manager := NewManager(logger.NewNoopLogger(), statusManager, cfg)
+	for _, registered := range manager.forges {
+		switch f := registered.(type) {
+		case *GitHub:
+			f.git = mockGit
+		case *GitLab:
+			f.git = mockGit
+		case *Gitea:
+			f.git = mockGit
+		}
+	}
+
+	statusManager.EXPECT().GetRepository("github.example.com/owner/repo").
+		Return(&status.Repository{Path: "/repo"}, nil)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("git@github.example.com:owner/repo.git", nil).AnyTimes()
+
+	selected, err := manager.GetForgeForRepository("github.example.com/owner/repo")
+	require.NoError(t, err)
+	require.IsType(t, &GitHub{}, selected)
+	assert.Equal(t, "github.example.com", selected.(*GitHub).host)
+	assert.Equal(t, "GHE_TOKEN", selected.(*GitHub).credential.tokenEnv)
+}
@@ -0,0 +1,637 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+)
+
+const (
+	// GitLabName is the name identifier for GitLab forge.
+	GitLabName = "gitlab"
+	// GitLabDomain is the GitLab SaaS domain used when no self-hosted host is specified.
+	GitLabDomain = "gitlab.com"
+	// GitLabTokenEnv is the environment variable holding the GitLab personal access token.
+	GitLabTokenEnv = "GITLAB_TOKEN"
+	// gitLabAPIPath is the path of the GitLab REST API v4 on a GitLab host.
+	gitLabAPIPath = "/api/v4"
+	// gitLabOpenedState is the state GitLab reports for open issues and merge requests.
+	gitLabOpenedState = "opened"
+	// gitLabLockedState is the state GitLab reports for open merge requests being merged.
+	gitLabLockedState = "locked"
+)
+
+// GitLab represents the GitLab forge implementation for gitlab.com and self-hosted instances.
+type GitLab struct {
+	host       string
+	apiURL     string
+	credential *hostCredential
+	// httpOptions bound the API calls, whose requests are retried by the HTTP client
+	httpOptions HTTPOptions
+	httpClient  *http.Client
+	git         git.Git
+}
+
+// NewGitLabOpts contains optional parameters for NewGitLab.
+type NewGitLabOpts struct {
+	Host        string             // GitLab hostname (defaults to gitlab.com)
+	APIURL      string             // REST API base URL (defaults to https://<host>/api/v4)
+	TokenEnv    string             // Environment variable holding the access token (defaults to GITLAB_TOKEN)
+	HTTPClient  *http.Client       // HTTP client used for API calls (defaults to http.DefaultClient)
+	HTTP        HTTPOptions        // Timeouts, retries and caching of API calls
+	Credentials CredentialResolver // Resolver of the access token (defaults to NewCredentialResolver())
+}
+
+// gitLabMergeRequest represents the subset of the GitLab merge request payload used by CM.
+type gitLabMergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	State           string `json:"state"`
+	WebURL          string `json:"web_url"`
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+}
+
+// gitLabProject represents the subset of the GitLab project payload used by CM.
+type gitLabProject struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	SSHURLToRepo      string `json:"ssh_url_to_repo"`
+}
+
+// gitLabCreateMergeRequest represents the payload sent to GitLab to open a merge request.
+type gitLabCreateMergeRequest struct {
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	Title           string `json:"title"`
+	Description     string `json:"description,omitempty"`
+	TargetProjectID int    `json:"target_project_id,omitempty"`
+}
+
+// gitLabIssue represents the subset of the GitLab issue payload used by CM.
+type gitLabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	WebURL      string   `json:"web_url"`
+	Labels      []string `json:"labels"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+}
+
+// gitLabRelatedMergeRequest represents the subset of the GitLab related merge request payload used by CM.
+type gitLabRelatedMergeRequest struct {
+	IID    int    `json:"iid"`
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+}
+
+// NewGitLab creates a new GitLab forge instance.
+func NewGitLab(opts ...NewGitLabOpts) *GitLab {
+	g := &GitLab{
+		host:       GitLabDomain,
+		httpClient: http.DefaultClient,
+		git:        git.NewGit(),
+	}
+
+	tokenEnv := GitLabTokenEnv
+	var credentials CredentialResolver
+	if len(opts) > 0 {
+		if opts[0].Host != "" {
+			g.host = opts[0].Host
+		}
+		if opts[0].APIURL != "" {
+			g.apiURL = strings.TrimSuffix(opts[0].APIURL, "/")
+		}
+		if opts[0].HTTPClient != nil {
+			g.httpClient = opts[0].HTTPClient
+		}
+		if opts[0].TokenEnv != "" {
+			tokenEnv = opts[0].TokenEnv
+		}
+		credentials = opts[0].Credentials
+		g.httpOptions = opts[0].HTTP
+	}
+	g.credential = newHostCredential(g.host, tokenEnv, credentials)
+	g.httpClient = newHTTPClient(g.httpClient, g.httpOptions)
+
+	if g.apiURL == "" {
+		g.apiURL = "https://" + g.host + gitLabAPIPath
+	}
+
+	return g
+}
+
+// Name returns the name of the forge.
+func (g *GitLab) Name() string {
+	return GitLabName
+}
+
+// GetIssueInfo fetches issue information from GitLab API.
+func (g *GitLab) GetIssueInfo(issueRef string, opts ...GetIssueInfoOpts) (*issue.Info, error) {
+	// Parse the issue reference to get project and issue number
+	ref, err := g.parseIssueReference(issueRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// Fetch the issue from the project issues endpoint
+	projectPath := ref.Owner + "/" + ref.Repository
+	var gitlabIssue gitLabIssue
+	endpoint := fmt.Sprintf("%s/projects/%s/issues/%d", g.apiURL, url.PathEscape(projectPath), ref.IssueNumber)
+	notFoundErr := fmt.Errorf("%w: issue #%d", ErrIssueNotFound, ref.IssueNumber)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &gitlabIssue); err != nil {
+		return nil, err
+	}
+
+	// Validate issue state
+	if gitlabIssue.State != gitLabOpenedState && !allowClosedIssue(opts) {
+		return nil, fmt.Errorf("%w: issue #%d", ErrIssueClosed, gitlabIssue.IID)
+	}
+
+	// Fetch the merge requests referencing the issue
+	var relatedMergeRequests []gitLabRelatedMergeRequest
+	if err := g.getJSON(ctx, endpoint+"/related_merge_requests", notFoundErr, &relatedMergeRequests); err != nil {
+		return nil, err
+	}
+	linked := make([]issue.LinkedPullRequest, 0, len(relatedMergeRequests))
+	for _, mr := range relatedMergeRequests {
+		linked = append(linked, issue.LinkedPullRequest{Number: mr.IID, URL: mr.WebURL, State: mr.State})
+	}
+
+	info := withLinkedPullRequests(gitlabIssue.toInfo(ref), linked)
+	return &info, nil
+}
+
+// toInfo converts a GitLab issue to issue information.
+func (i gitLabIssue) toInfo(ref *issue.Reference) issue.Info {
+	data := issueData{
+		Number:      i.IID,
+		Title:       i.Title,
+		Description: i.Description,
+		State:       i.State,
+		URL:         i.WebURL,
+		Labels:      i.Labels,
+	}
+	for _, assignee := range i.Assignees {
+		data.Assignees = append(data.Assignees, assignee.Username)
+	}
+	if i.Milestone != nil {
+		data.Milestone = i.Milestone.Title
+	}
+
+	return newIssueInfo(ref, data)
+}
+
+// ListIssues lists the open issues of the GitLab project of the origin remote.
+func (g *GitLab) ListIssues(params ListIssuesParams) ([]issue.Info, error) {
+	ref, err := g.originReference(params.RepoPath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("state", gitLabOpenedState)
+	query.Set("order_by", "updated_at")
+	query.Set("per_page", strconv.Itoa(params.limit()))
+	if params.AssignedToMe {
+		query.Set("scope", "assigned_to_me")
+	}
+	if len(params.Labels) > 0 {
+		query.Set("labels", strings.Join(params.Labels, ","))
+	}
+	if params.Milestone != "" {
+		query.Set("milestone", params.Milestone)
+	}
+
+	projectPath := ref.Owner + "/" + ref.Repository
+	var gitlabIssues []gitLabIssue
+	endpoint := fmt.Sprintf("%s/projects/%s/issues?%s", g.apiURL, url.PathEscape(projectPath), query.Encode())
+	notFoundErr := fmt.Errorf("GitLab project %s not found", projectPath)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &gitlabIssues); err != nil {
+		return nil, err
+	}
+
+	issues := make([]issue.Info, 0, len(gitlabIssues))
+	for _, gitlabIssue := range gitlabIssues {
+		issues = append(issues, gitlabIssue.toInfo(ref))
+	}
+	return issues, nil
+}
+
+// handleGitLabError maps GitLab API error responses to forge errors.
+// notFoundErr is returned when the requested resource does not exist.
+func (g *GitLab) handleGitLabError(resp *http.Response, notFoundErr error) error {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusNotFound:
+		return notFoundErr
+	case http.StatusConflict:
+		return ErrPullRequestExists
+	case http.StatusUnauthorized:
+		return g.credential.unauthorizedError()
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: access forbidden", ErrUnauthorized)
+	case http.StatusTooManyRequests:
+		return rateLimitedError("GitLab", resp.Header)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GitLab API request failed: unexpected status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// parseIssueReference parses the issue reference and handles context extraction.
+func (g *GitLab) parseIssueReference(issueRef string) (*issue.Reference, error) {
+	ref, err := g.ParseIssueReference(issueRef)
+	if err != nil {
+		// If it's an issue number format error, try to extract project info from current repo
+		if errors.Is(err, issue.ErrIssueNumberRequiresContext) {
+			ref, err = g.parseIssueNumberWithContext(issueRef)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidIssueRef, err)
+			}
+		} else {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidIssueRef, err)
+		}
+	}
+	return ref, nil
+}
+
+// ValidateForgeRepository validates that repository has this GitLab host as remote origin.
+func (g *GitLab) ValidateForgeRepository(repoPath string) error {
+	// Get the remote origin URL
+	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return fmt.Errorf("failed to get remote origin: %w", err)
+	}
+
+	host, _, err := parseRemoteURL(originURL)
+	if err != nil {
+		return err
+	}
+
+	if host != g.host {
+		return fmt.Errorf("repository does not have %s as remote origin", g.host)
+	}
+
+	return nil
+}
+
+// ParseIssueReference parses various issue reference formats.
+func (g *GitLab) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	// Try different formats
+
+	// 1. GitLab issue URL: https://gitlab.com/group/subgroup/project/-/issues/123
+	if strings.Contains(issueRef, "://") && strings.Contains(issueRef, "/issues/") {
+		return g.parseGitLabURL(issueRef)
+	}
+
+	// 2. Project path format: group/subgroup/project#123
+	if strings.Contains(issueRef, "#") {
+		return g.parseProjectPathFormat(issueRef)
+	}
+
+	// 3. Issue number only: 123 (requires current repository to be on this GitLab host)
+	if matched, _ := regexp.MatchString(`^\d+$`, issueRef); matched {
+		return nil, issue.ErrIssueNumberRequiresContext
+	}
+
+	return nil, fmt.Errorf("unsupported issue reference format: %s", issueRef)
+}
+
+// parseIssueNumberWithContext parses an issue number and extracts project info from current repo.
+func (g *GitLab) parseIssueNumberWithContext(issueRef string) (*issue.Reference, error) {
+	issueNumber, err := strconv.Atoi(issueRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", issueRef)
+	}
+
+	return g.originReference(".", issueNumber)
+}
+
+// originReference builds a reference on the project pointed by the origin remote of the repository.
+func (g *GitLab) originReference(repoPath string, number int) (*issue.Reference, error) {
+	// Get the remote origin URL to extract the project path
+	originURL, err := g.git.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote origin: %w", err)
+	}
+
+	host, projectPath, err := parseRemoteURL(originURL)
+	if err != nil {
+		return nil, err
+	}
+	if host != g.host {
+		return nil, fmt.Errorf("remote origin %s is not hosted on %s", originURL, g.host)
+	}
+
+	return g.buildReference(projectPath, number)
+}
+
+// parseGitLabURL parses GitLab issue URLs, including nested subgroups.
+func (g *GitLab) parseGitLabURL(urlStr string) (*issue.Reference, error) {
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitLab issue URL format: %w", err)
+	}
+	if parsedURL.Hostname() != g.host {
+		return nil, fmt.Errorf("issue URL is not hosted on %s", g.host)
+	}
+
+	// /group/subgroup/project/-/issues/123 (legacy URLs omit the "/-" separator)
+	re := regexp.MustCompile(`^/(.+?)(?:/-)?/issues/(\d+)/?$`)
+	matches := re.FindStringSubmatch(parsedURL.Path)
+	if len(matches) != 3 {
+		return nil, fmt.Errorf("invalid GitLab issue URL format")
+	}
+
+	issueNumber, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", matches[2])
+	}
+
+	ref, err := g.buildReference(matches[1], issueNumber)
+	if err != nil {
+		return nil, err
+	}
+	ref.URL = urlStr
+	return ref, nil
+}
+
+// parseProjectPathFormat parses group/subgroup/project#issue format.
+func (g *GitLab) parseProjectPathFormat(ref string) (*issue.Reference, error) {
+	parts := strings.Split(ref, "#")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid group/project#issue format")
+	}
+
+	issueNumber, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", parts[1])
+	}
+
+	return g.buildReference(parts[0], issueNumber)
+}
+
+// buildReference builds an issue reference from a project path (namespace/project).
+func (g *GitLab) buildReference(projectPath string, issueNumber int) (*issue.Reference, error) {
+	projectPath = strings.Trim(projectPath, "/")
+	lastSlash := strings.LastIndex(projectPath, "/")
+	if lastSlash <= 0 || lastSlash == len(projectPath)-1 {
+		return nil, fmt.Errorf("invalid group/project format")
+	}
+
+	return &issue.Reference{
+		Owner:       projectPath[:lastSlash],
+		Repository:  projectPath[lastSlash+1:],
+		IssueNumber: issueNumber,
+		URL:         fmt.Sprintf("https://%s/%s/-/issues/%d", g.host, projectPath, issueNumber),
+	}, nil
+}
+
+// GenerateBranchName generates branch name from issue information.
+func (g *GitLab) GenerateBranchName(issueInfo *issue.Info, opts ...GenerateBranchNameOpts) (string, error) {
+	// Format: rendered from the configured template, <issue-nb>-<sanitized-issue-title> by default
+	return generateBranchName(issueInfo, opts)
+}
+
+// GetPullRequestInfo fetches merge request information from GitLab API.
+func (g *GitLab) GetPullRequestInfo(prRef string, opts ...GetPullRequestInfoOpts) (*pullrequest.Info, error) {
+	// Parse the merge request reference to get project and merge request IID
+	ref, err := g.parsePullRequestReference(prRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	// Fetch the merge request from the project merge requests endpoint
+	projectPath := ref.Owner + "/" + ref.Repository
+	var mr gitLabMergeRequest
+	endpoint := fmt.Sprintf("%s/projects/%s/merge_requests/%d", g.apiURL, url.PathEscape(projectPath), ref.IssueNumber)
+	notFoundErr := fmt.Errorf("%w: merge request !%d", ErrPullRequestNotFound, ref.IssueNumber)
+	if err := g.getJSON(ctx, endpoint, notFoundErr, &mr); err != nil {
+		return nil, err
+	}
+
+	// Validate merge request state
+	state := normalizeGitLabState(mr.State)
+	if state != pullrequest.StateOpen && !allowClosedPullRequest(opts) {
+		return nil, fmt.Errorf("%w: merge request !%d", ErrPullRequestClosed, mr.IID)
+	}
+
+	info := &pullrequest.Info{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		State:      state,
+		URL:        mr.WebURL,
+		BaseBranch: mr.TargetBranch,
+		HeadBranch: mr.SourceBranch,
+		Repository: ref.Repository,
+		Owner:      ref.Owner,
+	}
+
+	// Resolve the source project path when the merge request comes from a fork
+	headPath := projectPath
+	if mr.SourceProjectID != mr.TargetProjectID {
+		var project gitLabProject
+		endpoint := fmt.Sprintf("%s/projects/%d", g.apiURL, mr.SourceProjectID)
+		notFoundErr := fmt.Errorf("merge request !%d source project is no longer available", mr.IID)
+		if err := g.getJSON(ctx, endpoint, notFoundErr, &project); err != nil {
+			// A deleted fork only matters for merge requests that can still be checked out
+			if state != pullrequest.StateOpen {
+				return info, nil
+			}
+			return nil, err
+		}
+		headPath = project.PathWithNamespace
+		info.HeadCloneURL = project.HTTPURLToRepo
+		info.HeadSSHURL = project.SSHURLToRepo
+	}
+
+	headRef, err := g.buildReference(headPath, mr.IID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge request source project: %w", err)
+	}
+	info.HeadOwner = headRef.Owner
+	info.HeadRepository = headRef.Repository
+
+	return info, nil
+}
+
+// normalizeGitLabState converts a GitLab issue or merge request state to the states shared by all forges.
+func normalizeGitLabState(state string) string {
+	switch state {
+	case gitLabOpenedState, gitLabLockedState:
+		return issue.StateOpen
+	default:
+		// closed and merged are already shared states
+		return state
+	}
+}
+
+// CreatePullRequest opens a merge request on the GitLab project of the origin remote.
+func (g *GitLab) CreatePullRequest(params CreatePullRequestParams) (*pullrequest.Info, error) {
+	ref, err := g.originReference(params.RepoPath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := g.httpOptions.newCallContext()
+	defer cancel()
+
+	projectPath := ref.Owner + "/" + ref.Repository
+	payload := gitLabCreateMergeRequest{
+		SourceBranch: params.HeadBranch,
+		TargetBranch: params.BaseBranch,
+		Title:        params.Title,
+		Description:  params.Body,
+	}
+
+	// Merge requests from a fork are created on the fork and target the base project
+	sourcePath, headOwner := projectPath, ref.Owner
+	if params.HeadOwner != "" && params.HeadOwner != ref.Owner {
+		var target gitLabProject
+		endpoint := fmt.Sprintf("%s/projects/%s", g.apiURL, url.PathEscape(projectPath))
+		if err := g.getJSON(ctx, endpoint, fmt.Errorf("GitLab project %s not found", projectPath), &target); err != nil {
+			return nil, err
+		}
+		payload.TargetProjectID = target.ID
+		sourcePath, headOwner = params.HeadOwner+"/"+ref.Repository, params.HeadOwner
+	}
+
+	var mr gitLabMergeRequest
+	endpoint := fmt.Sprintf("%s/projects/%s/merge_requests", g.apiURL, url.PathEscape(sourcePath))
+	notFoundErr := fmt.Errorf("GitLab project %s not found", sourcePath)
+	if err := g.doJSON(ctx, http.MethodPost, endpoint, payload, notFoundErr, &mr); err != nil {
+		return nil, err
+	}
+
+	return &pullrequest.Info{
+		Number:         mr.IID,
+		Title:          mr.Title,
+		State:          "open",
+		URL:            mr.WebURL,
+		BaseBranch:     params.BaseBranch,
+		HeadBranch:     params.HeadBranch,
+		HeadOwner:      headOwner,
+		HeadRepository: ref.Repository,
+		Repository:     ref.Repository,
+		Owner:          ref.Owner,
+	}, nil
+}
+
+// getJSON performs an authenticated GET request on the GitLab API and decodes the JSON response.
+func (g *GitLab) getJSON(ctx context.Context, endpoint string, notFoundErr error, out interface{}) error {
+	return g.doJSON(ctx, http.MethodGet, endpoint, nil, notFoundErr, out)
+}
+
+// doJSON performs an authenticated request on the GitLab API, sending payload as JSON
+// when not nil, and decodes the JSON response.
+func (g *GitLab) doJSON(
+	ctx context.Context, method, endpoint string, payload interface{}, notFoundErr error, out interface{},
+) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode GitLab request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := g.credential.token(); token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitLab API request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := g.handleGitLabError(resp, notFoundErr); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitLab response: %w", err)
+	}
+	return nil
+}
+
+// parsePullRequestReference parses merge request URLs, group/project!iid, group/project#iid and IID formats.
+func (g *GitLab) parsePullRequestReference(prRef string) (*issue.Reference, error) {
+	// GitLab merge request URL: https://gitlab.com/group/subgroup/project/-/merge_requests/123
+	if strings.Contains(prRef, "://") {
+		parsedURL, err := url.Parse(prRef)
+		if err != nil || parsedURL.Hostname() != g.host {
+			return nil, fmt.Errorf("%w: merge request URL is not hosted on %s", ErrInvalidPullRequestRef, g.host)
+		}
+
+		re := regexp.MustCompile(`^/(.+?)(?:/-)?/merge_requests/(\d+)/?$`)
+		matches := re.FindStringSubmatch(parsedURL.Path)
+		if len(matches) != 3 {
+			return nil, fmt.Errorf("%w: invalid GitLab merge request URL format", ErrInvalidPullRequestRef)
+		}
+
+		number, err := strconv.Atoi(matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid merge request number: %s", ErrInvalidPullRequestRef, matches[2])
+		}
+
+		ref, err := g.buildReference(matches[1], number)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPullRequestRef, err)
+		}
+		ref.URL = prRef
+		return ref, nil
+	}
+
+	// GitLab references merge requests with "!", other formats are parsed like issues
+	ref, err := g.parseIssueReference(strings.Replace(prRef, "!", "#", 1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPullRequestRef, err)
+	}
+	return ref, nil
+}
@@ -0,0 +1,443 @@
+//go:build unit
+
+package forge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newGitLabTestServer creates an httptest server that serves a single GitLab issue
+// and the merge request referencing it.
+func newGitLabTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fsubgroup%2Fproject/issues/12":
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		case "/api/v4/projects/group%2Fsubgroup%2Fproject/issues/12/related_merge_requests":
+			_, _ = w.Write([]byte(`[{"iid": 3, "state": "opened",
+				"web_url": "https://gitlab.example.com/group/subgroup/project/-/merge_requests/3"}]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.EscapedPath())
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitLab_Name(t *testing.T) {
+	gitlab := NewGitLab()
+	assert.Equal(t, "gitlab", gitlab.Name())
+}
+
+func TestGitLab_GetIssueInfo(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "test-token")
+
+	server := newGitLabTestServer(t, http.StatusOK, `{
+		"iid": 12,
+		"title": "Fix Login Bug",
+		"description": "Login fails on Safari",
+		"state": "opened",
+		"web_url": "https://gitlab.example.com/group/subgroup/project/-/issues/12",
+		"labels": ["bug", "frontend"],
+		"assignees": [{"username": "alice"}, {"username": "bob"}],
+		"milestone": {"title": "v1.2"}
+	}`)
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+
+	info, err := gitlab.GetIssueInfo("group/subgroup/project#12")
+	require.NoError(t, err)
+	assert.Equal(t, &issue.Info{
+		Number:      12,
+		Title:       "Fix Login Bug",
+		Description: "Login fails on Safari",
+		State:       "open",
+		URL:         "https://gitlab.example.com/group/subgroup/project/-/issues/12",
+		Repository:  "project",
+		Owner:       "group/subgroup",
+		Labels:      []string{"bug", "frontend"},
+		Assignees:   []string{"alice", "bob"},
+		Milestone:   "v1.2",
+		LinkedPullRequests: []issue.LinkedPullRequest{{
+			Number: 3,
+			URL:    "https://gitlab.example.com/group/subgroup/project/-/merge_requests/3",
+			State:  "open",
+		}},
+	}, info)
+}
+
+func TestGitLab_GetIssueInfo_Errors(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "test-token")
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{name: "closed issue", status: http.StatusOK, body: `{"iid": 12, "state": "closed"}`, expected: ErrIssueClosed},
+		{name: "not found", status: http.StatusNotFound, body: `{}`, expected: ErrIssueNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{}`, expected: ErrUnauthorized},
+		{name: "forbidden", status: http.StatusForbidden, body: `{}`, expected: ErrUnauthorized},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{}`, expected: ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGitLabTestServer(t, tt.status, tt.body)
+			gitlab := NewGitLab(NewGitLabOpts{
+				Host:   "gitlab.example.com",
+				APIURL: server.URL + "/api/v4",
+				HTTP:   HTTPOptions{MaxRetries: -1},
+			})
+
+			_, err := gitlab.GetIssueInfo("group/subgroup/project#12")
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestGitLab_ParseIssueReference(t *testing.T) {
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com"})
+
+	tests := []struct {
+		name        string
+		issueRef    string
+		expectError bool
+		expected    *issue.Reference
+	}{
+		{
+			name:     "issue URL with nested subgroups",
+			issueRef: "https://gitlab.example.com/group/subgroup/project/-/issues/12",
+			expected: &issue.Reference{
+				Owner:       "group/subgroup",
+				Repository:  "project",
+				IssueNumber: 12,
+				URL:         "https://gitlab.example.com/group/subgroup/project/-/issues/12",
+			},
+		},
+		{
+			name:     "legacy issue URL without separator",
+			issueRef: "https://gitlab.example.com/group/project/issues/7",
+			expected: &issue.Reference{
+				Owner:       "group",
+				Repository:  "project",
+				IssueNumber: 7,
+				URL:         "https://gitlab.example.com/group/project/issues/7",
+			},
+		},
+		{
+			name:     "project path format",
+			issueRef: "group/subgroup/project#12",
+			expected: &issue.Reference{
+				Owner:       "group/subgroup",
+				Repository:  "project",
+				IssueNumber: 12,
+				URL:         "https://gitlab.example.com/group/subgroup/project/-/issues/12",
+			},
+		},
+		{
+			name:        "issue number only (requires context)",
+			issueRef:    "12",
+			expectError: true,
+		},
+		{
+			name:        "URL from another host",
+			issueRef:    "https://gitlab.com/group/project/-/issues/12",
+			expectError: true,
+		},
+		{
+			name:        "merge request URL",
+			issueRef:    "https://gitlab.example.com/group/project/-/merge_requests/12",
+			expectError: true,
+		},
+		{
+			name:        "missing group",
+			issueRef:    "project#12",
+			expectError: true,
+		},
+		{
+			name:        "invalid issue number",
+			issueRef:    "group/project#abc",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := gitlab.ParseIssueReference(tt.issueRef)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGitLab_GetPullRequestInfo(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fproject/merge_requests/7":
+			_, _ = w.Write([]byte(`{
+				"iid": 7,
+				"title": "Fix typo",
+				"state": "opened",
+				"web_url": "https://gitlab.example.com/group/project/-/merge_requests/7",
+				"source_branch": "fix-typo",
+				"target_branch": "main",
+				"source_project_id": 2,
+				"target_project_id": 1
+			}`))
+		case "/api/v4/projects/2":
+			_, _ = w.Write([]byte(`{
+				"path_with_namespace": "contributor/forks/project-fork",
+				"http_url_to_repo": "https://gitlab.example.com/contributor/forks/project-fork.git",
+				"ssh_url_to_repo": "git@gitlab.example.com:contributor/forks/project-fork.git"
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+
+	info, err := gitlab.GetPullRequestInfo("https://gitlab.example.com/group/project/-/merge_requests/7")
+	require.NoError(t, err)
+	assert.Equal(t, &pullrequest.Info{
+		Number:         7,
+		Title:          "Fix typo",
+		State:          "open",
+		URL:            "https://gitlab.example.com/group/project/-/merge_requests/7",
+		BaseBranch:     "main",
+		HeadBranch:     "fix-typo",
+		HeadOwner:      "contributor/forks",
+		HeadRepository: "project-fork",
+		HeadCloneURL:   "https://gitlab.example.com/contributor/forks/project-fork.git",
+		HeadSSHURL:     "git@gitlab.example.com:contributor/forks/project-fork.git",
+		Repository:     "project",
+		Owner:          "group",
+	}, info)
+	assert.True(t, info.IsFromFork())
+
+	_, err = gitlab.GetPullRequestInfo("group/project!8")
+	assert.ErrorIs(t, err, ErrPullRequestNotFound)
+
+	_, err = gitlab.GetPullRequestInfo("https://gitlab.example.com/group/project/-/issues/7")
+	assert.ErrorIs(t, err, ErrInvalidPullRequestRef)
+}
+
+func TestGitLab_AllowClosed(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fproject/issues/12":
+			_, _ = w.Write([]byte(`{"iid": 12, "title": "Fix login", "state": "closed"}`))
+		case "/api/v4/projects/group%2Fproject/issues/12/related_merge_requests":
+			_, _ = w.Write([]byte(`[]`))
+		case "/api/v4/projects/group%2Fproject/merge_requests/7":
+			// The fork of a merged merge request may have been deleted since
+			_, _ = w.Write([]byte(`{
+				"iid": 7,
+				"title": "Fix typo",
+				"state": "merged",
+				"source_branch": "fix-typo",
+				"target_branch": "main",
+				"source_project_id": 2,
+				"target_project_id": 1
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+
+	issueInfo, err := gitlab.GetIssueInfo("group/project#12", GetIssueInfoOpts{AllowClosed: true})
+	require.NoError(t, err)
+	assert.Equal(t, issue.StateClosed, issueInfo.State)
+
+	_, err = gitlab.GetPullRequestInfo("group/project!7")
+	assert.ErrorIs(t, err, ErrPullRequestClosed)
+
+	prInfo, err := gitlab.GetPullRequestInfo("group/project!7", GetPullRequestInfoOpts{AllowClosed: true})
+	require.NoError(t, err)
+	assert.Equal(t, pullrequest.StateMerged, prInfo.State)
+	assert.Empty(t, prInfo.HeadOwner)
+}
+
+func TestGitLab_CreatePullRequest(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "test-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject":
+			_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "group/project"}`))
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/v4/projects/contributor%2Fproject/merge_requests":
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]interface{}{
+				"source_branch":     "12-fix-login",
+				"target_branch":     "main",
+				"title":             "Fix login",
+				"description":       "Closes #12",
+				"target_project_id": float64(1),
+			}, payload)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{
+				"iid": 8,
+				"title": "Fix login",
+				"state": "opened",
+				"web_url": "https://gitlab.example.com/group/project/-/merge_requests/8"
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("git@gitlab.example.com:group/project.git", nil)
+
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+	gitlab.git = mockGit
+
+	info, err := gitlab.CreatePullRequest(CreatePullRequestParams{
+		RepoPath:   "/repo",
+		HeadOwner:  "contributor",
+		HeadBranch: "12-fix-login",
+		BaseBranch: "main",
+		Title:      "Fix login",
+		Body:       "Closes #12",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &pullrequest.Info{
+		Number:         8,
+		Title:          "Fix login",
+		State:          "open",
+		URL:            "https://gitlab.example.com/group/project/-/merge_requests/8",
+		BaseBranch:     "main",
+		HeadBranch:     "12-fix-login",
+		HeadOwner:      "contributor",
+		HeadRepository: "project",
+		Repository:     "project",
+		Owner:          "group",
+	}, info)
+}
+
+func TestGitLab_CreatePullRequest_AlreadyExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message": ["Another open merge request already exists for this source branch"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("https://gitlab.example.com/group/project.git", nil)
+
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+	gitlab.git = mockGit
+
+	_, err := gitlab.CreatePullRequest(CreatePullRequestParams{
+		RepoPath:   "/repo",
+		HeadBranch: "12-fix-login",
+		BaseBranch: "main",
+		Title:      "Fix login",
+	})
+	assert.ErrorIs(t, err, ErrPullRequestExists)
+}
+
+func TestGitLab_ListIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/group%2Fproject/issues", r.URL.EscapedPath())
+		assert.Equal(t, "opened", r.URL.Query().Get("state"))
+		assert.Equal(t, "assigned_to_me", r.URL.Query().Get("scope"))
+		assert.Equal(t, "bug,frontend", r.URL.Query().Get("labels"))
+		assert.Equal(t, "v1.0", r.URL.Query().Get("milestone"))
+		assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+		_, _ = w.Write([]byte(`[
+			{"iid": 3, "title": "Fix login", "state": "opened", "web_url": "https://gitlab.example.com/group/project/-/issues/3", "labels": ["bug", "frontend"]},
+			{"iid": 5, "title": "Fix logout", "state": "opened", "web_url": "https://gitlab.example.com/group/project/-/issues/5", "labels": ["bug", "frontend"]}
+		]`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockGit.EXPECT().GetRemoteURL("/repo", "origin").Return("git@gitlab.example.com:group/project.git", nil)
+
+	gitlab := NewGitLab(NewGitLabOpts{Host: "gitlab.example.com", APIURL: server.URL + "/api/v4"})
+	gitlab.git = mockGit
+
+	issues, err := gitlab.ListIssues(ListIssuesParams{
+		RepoPath:     "/repo",
+		AssignedToMe: true,
+		Labels:       []string{"bug", "frontend"},
+		Milestone:    "v1.0",
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, issue.Info{
+		Number:     3,
+		Title:      "Fix login",
+		State:      "open",
+		URL:        "https://gitlab.example.com/group/project/-/issues/3",
+		Repository: "project",
+		Owner:      "group",
+		Labels:     []string{"bug", "frontend"},
+	}, issues[0])
+	assert.Equal(t, 5, issues[1].Number)
+}
+
+func TestGitLab_GenerateBranchName(t *testing.T) {
+	gitlab := NewGitLab()
+
+	result, err := gitlab.GenerateBranchName(&issue.Info{Number: 12, Title: "Fix Login Bug"})
+	require.NoError(t, err)
+	assert.Equal(t, "12-fix-login-bug", result)
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remoteURL    string
+		expectedHost string
+		expectedPath string
+	}{
+		{"https://gitlab.com/group/subgroup/project.git", "gitlab.com", "group/subgroup/project"},
+		{"git@gitlab.example.com:group/project.git", "gitlab.example.com", "group/project"},
+		{"ssh://git@gitlab.example.com:2222/group/subgroup/project.git", "gitlab.example.com", "group/subgroup/project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteURL, func(t *testing.T) {
+			host, path, err := parseRemoteURL(tt.remoteURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHost, host)
+			assert.Equal(t, tt.expectedPath, path)
+		})
+	}
+
+	_, _, err := parseRemoteURL("not-a-remote")
+	assert.Error(t, err)
+}
@@ -0,0 +1,423 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Defaults of the HTTP layer shared by the forges.
+const (
+	DefaultHTTPTimeout      = 10 * time.Second
+	DefaultHTTPBudget       = time.Minute
+	DefaultHTTPMaxRetries   = 3
+	DefaultHTTPMaxRetryWait = time.Minute
+	// httpBackoffBase is the delay before the first retry when the forge does not say how long to wait.
+	httpBackoffBase = 500 * time.Millisecond
+	// httpBackoffMax caps the exponential backoff between retries.
+	httpBackoffMax = 30 * time.Second
+)
+
+// HTTPOptions configures the HTTP layer shared by the forges. Zero values use the defaults.
+type HTTPOptions struct {
+	Timeout      time.Duration // Timeout of a single request attempt (defaults to DefaultHTTPTimeout)
+	Budget       time.Duration // Time allowed to a forge call, retries included (defaults to DefaultHTTPBudget)
+	MaxRetries   int           // Retries of failed requests (defaults to DefaultHTTPMaxRetries, negative disables them)
+	MaxRetryWait time.Duration // Longest wait honoured before a retry (defaults to DefaultHTTPMaxRetryWait)
+	CacheDir     string        // Directory of the ETag response cache (responses are cached in memory if empty)
+}
+
+// withDefaults returns the options with the defaults applied.
+func (o HTTPOptions) withDefaults() HTTPOptions {
+	if o.Timeout == 0 {
+		o.Timeout = DefaultHTTPTimeout
+	}
+	if o.Budget == 0 {
+		o.Budget = DefaultHTTPBudget
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultHTTPMaxRetries
+	} else if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	}
+	if o.MaxRetryWait == 0 {
+		o.MaxRetryWait = DefaultHTTPMaxRetryWait
+	}
+	return o
+}
+
+// newCallContext returns the context of a forge call, bounded by the request budget.
+func (o HTTPOptions) newCallContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), o.withDefaults().Budget)
+}
+
+// newHTTPClient returns a copy of the HTTP client whose requests are retried with backoff
+// and revalidated with their ETag.
+func newHTTPClient(client *http.Client, opts HTTPOptions) *http.Client {
+	resilient := &http.Client{}
+	if client != nil {
+		*resilient = *client
+	}
+
+	base := resilient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	opts = opts.withDefaults()
+	resilient.Transport = &resilientTransport{
+		base:    base,
+		opts:    opts,
+		etags:   newETagCache(opts.CacheDir),
+		backoff: httpBackoffBase,
+		sleep:   sleepContext,
+		now:     time.Now,
+	}
+
+	return resilient
+}
+
+// resilientTransport retries failed requests with jittered exponential backoff, waiting as told by
+// the Retry-After and rate limit reset headers, and revalidates cached GET responses with their ETag.
+type resilientTransport struct {
+	base    http.RoundTripper
+	opts    HTTPOptions
+	etags   *etagCache
+	backoff time.Duration
+	sleep   func(ctx context.Context, d time.Duration) error
+	now     func() time.Time
+}
+
+// RoundTrip sends the request, retrying it while the forge reports a transient failure.
+func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cacheKey, cached := t.conditional(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil && attemptReq.Header.Get("If-None-Match") == "" {
+			attemptReq.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		resp, err := t.roundTripAttempt(attemptReq)
+		delay, retry := t.retryDelay(req, resp, err, attempt)
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			return t.cacheResponse(cacheKey, cached, resp)
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTripAttempt sends a single attempt of the request, bounded by the request timeout.
+func (t *resilientTransport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.opts.Timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The attempt context must outlive the response body
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay returns how long to wait before retrying the request, and whether it should be retried.
+func (t *resilientTransport) retryDelay(
+	req *http.Request, resp *http.Response, err error, attempt int,
+) (time.Duration, bool) {
+	if attempt >= t.opts.MaxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	switch {
+	case err != nil:
+		// Non idempotent requests may have been processed before failing
+		if !idempotent || isOfflineError(err) {
+			return 0, false
+		}
+	case isRateLimited(resp):
+		// Rate limited requests are rejected before being processed, whatever their method
+	case idempotent && resp.StatusCode >= http.StatusInternalServerError:
+	default:
+		return 0, false
+	}
+
+	delay := t.backoffDelay(attempt)
+	if resp != nil {
+		if wait, ok := rateLimitWait(resp, t.now()); ok {
+			delay = wait
+		}
+	}
+
+	// Give up rather than waiting past the maximum wait or the call budget
+	if delay > t.opts.MaxRetryWait {
+		return 0, false
+	}
+	if deadline, ok := req.Context().Deadline(); ok && t.now().Add(delay).After(deadline) {
+		return 0, false
+	}
+	return delay, true
+}
+
+// backoffDelay returns the exponential backoff of the attempt, with jitter to spread concurrent retries.
+func (t *resilientTransport) backoffDelay(attempt int) time.Duration {
+	delay := t.backoff << attempt
+	if delay > httpBackoffMax || delay <= 0 {
+		delay = httpBackoffMax
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// conditional returns the cache key and the cached response to revalidate for GET requests.
+func (t *resilientTransport) conditional(req *http.Request) (string, *cachedResponse) {
+	if req.Method != http.MethodGet {
+		return "", nil
+	}
+	key := etagCacheKey(req)
+	return key, t.etags.get(key)
+}
+
+// cacheResponse stores responses carrying an ETag, and serves the cached response
+// when the forge reports that it has not been modified.
+func (t *resilientTransport) cacheResponse(
+	key string, cached *cachedResponse, resp *http.Response,
+) (*http.Response, error) {
+	if key == "" {
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		notModified := *resp
+		notModified.StatusCode = http.StatusOK
+		notModified.Status = http.StatusText(http.StatusOK)
+		notModified.Header = resp.Header.Clone()
+		notModified.Header.Set("Content-Type", cached.ContentType)
+		notModified.Body = io.NopCloser(bytes.NewReader(cached.Body))
+		notModified.ContentLength = int64(len(cached.Body))
+		return &notModified, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.etags.put(key, cachedResponse{ETag: etag, ContentType: resp.Header.Get("Content-Type"), Body: body})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// isRateLimited returns true when the response reports that the rate limit is exceeded.
+func isRateLimited(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		// GitHub answers 403 to exceeded primary and secondary rate limits
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+	default:
+		return false
+	}
+}
+
+// isOfflineError returns true when the forge cannot be reached at all, which retrying will not fix
+// and which should fall back to cached data without delay.
+func isOfflineError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsTemporary
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// rateLimitWait returns how long the forge asks to wait before retrying, from the Retry-After header
+// or the rate limit reset time (X-RateLimit-Reset for GitHub and Gitea, RateLimit-Reset for GitLab).
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if !isRateLimited(resp) {
+		return 0, false
+	}
+	if reset, ok := rateLimitReset(resp); ok {
+		return max(reset.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rateLimitReset returns the time at which the rate limit is reset, when the forge reports it.
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	for _, header := range []string{"X-RateLimit-Reset", "RateLimit-Reset"} {
+		if epoch, err := strconv.ParseInt(resp.Header.Get(header), 10, 64); err == nil {
+			return time.Unix(epoch, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// rateLimitedError returns the error of a rate limited response, telling when the limit is reset if known.
+func rateLimitedError(forgeName string, header http.Header) error {
+	if reset, ok := rateLimitReset(&http.Response{Header: header}); ok {
+		return fmt.Errorf("%w: %s API rate limit exceeded, resets at %s",
+			ErrRateLimited, forgeName, reset.Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: %s API rate limit exceeded", ErrRateLimited, forgeName)
+}
+
+// rewindRequest returns the request to send for the attempt, with a fresh body for retries.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	attemptReq := req.Clone(req.Context())
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return attemptReq, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("cannot retry request: body cannot be rewound")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("cannot retry request: %w", err)
+	}
+	attemptReq.Body = body
+	return attemptReq, nil
+}
+
+// sleepContext waits for the duration, returning early with the context error when it is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnCloseBody cancels the context of a request attempt once its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the attempt context.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// cachedResponse is a GET response stored with its ETag.
+type cachedResponse struct {
+	ETag        string `json:"etag"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// etagCache stores GET responses in memory, and on disk when a directory is configured,
+// so that they can be revalidated with conditional requests which do not count against rate limits.
+type etagCache struct {
+	dir     string
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// newETagCache creates an ETag cache stored in dir, or only in memory when dir is empty.
+func newETagCache(dir string) *etagCache {
+	return &etagCache{dir: dir, entries: make(map[string]cachedResponse)}
+}
+
+// etagCacheKey identifies a request in the cache. Credentials are part of the key, hashed,
+// so that responses are never served to another user, and so is the requested media type.
+func etagCacheKey(req *http.Request) string {
+	hash := sha256.New()
+	for _, part := range []string{
+		req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization"), req.Header.Get("PRIVATE-TOKEN"),
+	} {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns the cached response, or nil when the request is not cached.
+func (c *etagCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.entries[key]; ok {
+		return &cached
+	}
+	if c.dir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.ETag == "" {
+		return nil
+	}
+	c.entries[key] = cached
+	return &cached
+}
+
+// put stores the response. Failing to write it on disk only loses the revalidation.
+func (c *etagCache) put(key string, cached cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cached
+	if c.dir == "" {
+		return
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil || os.MkdirAll(c.dir, 0700) != nil {
+		return
+	}
+	path := filepath.Join(c.dir, key+".json")
+	if os.WriteFile(path+".tmp", data, 0600) == nil {
+		_ = os.Rename(path+".tmp", path)
+	}
+}
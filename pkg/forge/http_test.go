@@ -0,0 +1,244 @@
+//go:build unit
+
+package forge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHTTPClient creates a resilient HTTP client recording its waits instead of sleeping.
+func newTestHTTPClient(t *testing.T, opts HTTPOptions, now time.Time) (*http.Client, *[]time.Duration) {
+	t.Helper()
+	client := newHTTPClient(nil, opts)
+	transport, ok := client.Transport.(*resilientTransport)
+	require.True(t, ok)
+
+	waits := &[]time.Duration{}
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	transport.now = func() time.Time { return now }
+	return client, waits
+}
+
+// newSequenceServer creates a server answering each request with the next handler, repeating the last one.
+func newSequenceServer(t *testing.T, handlers ...http.HandlerFunc) (*httptest.Server, *int) {
+	t.Helper()
+	requests := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler := handlers[min(*requests, len(handlers)-1)]
+		*requests++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+// respond returns a handler answering with the status, headers and body.
+func respond(status int, body string, headers ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// get sends a GET request and returns the response status and body.
+func get(t *testing.T, client *http.Client, url string, headers ...string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestHTTPClient_RetriesRateLimitedRequests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		limited  http.HandlerFunc
+		expected time.Duration
+	}{
+		{
+			name:     "Retry-After seconds",
+			limited:  respond(http.StatusTooManyRequests, "", "Retry-After", "2"),
+			expected: 2 * time.Second,
+		},
+		{
+			name: "Retry-After date",
+			limited: respond(http.StatusTooManyRequests, "",
+				"Retry-After", now.Add(5*time.Second).UTC().Format(http.TimeFormat)),
+			expected: 5 * time.Second,
+		},
+		{
+			name: "GitHub rate limit reset",
+			limited: respond(http.StatusForbidden, "", "X-RateLimit-Remaining", "0",
+				"X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)),
+			expected: 30 * time.Second,
+		},
+		{
+			name: "GitLab rate limit reset",
+			limited: respond(http.StatusTooManyRequests, "",
+				"RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)),
+			expected: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newSequenceServer(t, tt.limited, respond(http.StatusOK, "ok"))
+			client, waits := newTestHTTPClient(t, HTTPOptions{}, now)
+
+			status, body := get(t, client, server.URL)
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, "ok", body)
+			assert.Equal(t, 2, *requests)
+			assert.Equal(t, []time.Duration{tt.expected}, *waits)
+		})
+	}
+}
+
+func TestHTTPClient_RetriesServerErrorsWithBackoff(t *testing.T) {
+	server, requests := newSequenceServer(t, respond(http.StatusServiceUnavailable, "down"))
+	client, waits := newTestHTTPClient(t, HTTPOptions{MaxRetries: 2}, time.Now())
+
+	status, body := get(t, client, server.URL)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "down", body)
+	assert.Equal(t, 3, *requests)
+
+	// Backoff doubles with each attempt, with up to half of it removed by the jitter
+	require.Len(t, *waits, 2)
+	assert.GreaterOrEqual(t, (*waits)[0], httpBackoffBase/2)
+	assert.LessOrEqual(t, (*waits)[0], httpBackoffBase)
+	assert.GreaterOrEqual(t, (*waits)[1], httpBackoffBase)
+	assert.LessOrEqual(t, (*waits)[1], 2*httpBackoffBase)
+}
+
+func TestHTTPClient_DoesNotRetry(t *testing.T) {
+	t.Run("disabled retries", func(t *testing.T) {
+		server, requests := newSequenceServer(t, respond(http.StatusTooManyRequests, "", "Retry-After", "1"))
+		client, _ := newTestHTTPClient(t, HTTPOptions{MaxRetries: -1}, time.Now())
+
+		status, _ := get(t, client, server.URL)
+		assert.Equal(t, http.StatusTooManyRequests, status)
+		assert.Equal(t, 1, *requests)
+	})
+
+	t.Run("wait beyond the maximum", func(t *testing.T) {
+		server, requests := newSequenceServer(t, respond(http.StatusTooManyRequests, "", "Retry-After", "3600"))
+		client, waits := newTestHTTPClient(t, HTTPOptions{MaxRetryWait: time.Minute}, time.Now())
+
+		status, _ := get(t, client, server.URL)
+		assert.Equal(t, http.StatusTooManyRequests, status)
+		assert.Equal(t, 1, *requests)
+		assert.Empty(t, *waits)
+	})
+
+	t.Run("client errors", func(t *testing.T) {
+		server, requests := newSequenceServer(t, respond(http.StatusNotFound, ""))
+		client, _ := newTestHTTPClient(t, HTTPOptions{}, time.Now())
+
+		status, _ := get(t, client, server.URL)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, 1, *requests)
+	})
+}
+
+func TestHTTPClient_RetriesOnlyRateLimitedWrites(t *testing.T) {
+	var bodies []string
+	record := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			handler(w, r)
+		}
+	}
+	server, requests := newSequenceServer(t,
+		record(respond(http.StatusTooManyRequests, "", "Retry-After", "1")),
+		record(respond(http.StatusInternalServerError, "")),
+		record(respond(http.StatusCreated, "")))
+	client, _ := newTestHTTPClient(t, HTTPOptions{}, time.Now())
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"title":"Fix"}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	// The server error may come from a processed request, which must not be sent twice
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 2, *requests)
+	assert.Equal(t, []string{`{"title":"Fix"}`, `{"title":"Fix"}`}, bodies)
+}
+
+func TestHTTPClient_RevalidatesWithETag(t *testing.T) {
+	cacheDir := t.TempDir()
+	notModified := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != `"v1"` {
+			respond(http.StatusOK, "fresh", "ETag", `"v2"`)(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}
+	server, requests := newSequenceServer(t,
+		respond(http.StatusOK, "cached", "ETag", `"v1"`, "Content-Type", "application/json"), notModified)
+
+	client, _ := newTestHTTPClient(t, HTTPOptions{CacheDir: cacheDir}, time.Now())
+	status, body := get(t, client, server.URL, "Authorization", "Bearer token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "cached", body)
+
+	// The cached response is served when the forge reports it has not been modified
+	status, body = get(t, client, server.URL, "Authorization", "Bearer token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "cached", body)
+
+	// The cache is shared with later clients through the cache directory
+	otherClient, _ := newTestHTTPClient(t, HTTPOptions{CacheDir: cacheDir}, time.Now())
+	status, body = get(t, otherClient, server.URL, "Authorization", "Bearer token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "cached", body)
+
+	// Responses are not shared between credentials
+	_, body = get(t, otherClient, server.URL, "Authorization", "Bearer other-token")
+	assert.Equal(t, "fresh", body)
+
+	// Nor between media types
+	_, body = get(t, otherClient, server.URL, "Authorization", "Bearer token",
+		"Accept", "application/vnd.github.mockingbird-preview+json")
+	assert.Equal(t, "fresh", body)
+	assert.Equal(t, 5, *requests)
+}
+
+func TestRateLimitedError(t *testing.T) {
+	header := http.Header{}
+	err := rateLimitedError("GitLab", header)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, "rate limited by forge API: GitLab API rate limit exceeded", err.Error())
+
+	reset := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	header.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	err = rateLimitedError("GitLab", header)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Contains(t, err.Error(), "resets at "+reset.Local().Format(time.RFC3339))
+}
@@ -0,0 +1,220 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+)
+
+// IssueCache stores the issues fetched from forges on disk, keyed by forge host, repository and number.
+// Cached issues are served without querying the forge while younger than the TTL,
+// and as stale issues when the forge is unreachable.
+type IssueCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewIssueCache creates an issue cache stored in dir.
+func NewIssueCache(dir string, ttl time.Duration) *IssueCache {
+	return &IssueCache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// issueCacheKey identifies an issue in the cache.
+type issueCacheKey struct {
+	Host       string
+	Repository string // Repository path on the forge (e.g. owner/repo or group/subgroup/project)
+	Number     int
+}
+
+// cachedIssue is the on-disk representation of a cached issue.
+type cachedIssue struct {
+	FetchedAt time.Time  `json:"fetched_at"`
+	Issue     issue.Info `json:"issue"`
+}
+
+// path returns the file holding the cached issue.
+func (c *IssueCache) path(key issueCacheKey) (string, error) {
+	parts := append([]string{key.Host}, strings.Split(key.Repository, "/")...)
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `\:`) {
+			return "", fmt.Errorf("invalid issue cache key: %s/%s", key.Host, key.Repository)
+		}
+	}
+	return filepath.Join(append([]string{c.dir}, append(parts, strconv.Itoa(key.Number)+".json")...)...), nil
+}
+
+// get returns the cached issue, or nil when the issue is not cached.
+func (c *IssueCache) get(key issueCacheKey) (*cachedIssue, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached issue: %w", err)
+	}
+
+	var cached cachedIssue
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode cached issue %s: %w", path, err)
+	}
+	return &cached, nil
+}
+
+// put stores the issue in the cache.
+func (c *IssueCache) put(key issueCacheKey, info issue.Info) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	info.Stale = false
+	data, err := json.MarshalIndent(cachedIssue{FetchedAt: c.now(), Issue: info}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cached issue: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create issue cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a partial issue
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cached issue: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write cached issue: %w", err)
+	}
+	return nil
+}
+
+// fresh returns true when the cached issue can be used without querying the forge.
+func (c *IssueCache) fresh(cached *cachedIssue) bool {
+	return c.now().Sub(cached.FetchedAt) < c.ttl
+}
+
+// issueCachingForge decorates a forge so that issues are read through the issue cache.
+type issueCachingForge struct {
+	Forge
+	host   string
+	cache  *IssueCache
+	git    git.Git
+	logger logger.Logger
+}
+
+// newIssueCachingForge wraps the forge serving host with the issue cache.
+func newIssueCachingForge(f Forge, host string, cache *IssueCache, g git.Git, l logger.Logger) *issueCachingForge {
+	return &issueCachingForge{
+		Forge:  f,
+		host:   host,
+		cache:  cache,
+		git:    g,
+		logger: l,
+	}
+}
+
+// GetIssueInfo returns the issue from the cache when fresh and SkipCache is not set, from the forge otherwise,
+// falling back to the cached issue marked as stale when the forge is unreachable.
+func (f *issueCachingForge) GetIssueInfo(issueRef string, opts ...GetIssueInfoOpts) (*issue.Info, error) {
+	key, err := f.cacheKey(issueRef)
+	if err != nil {
+		f.logger.Logf("Not caching issue %s: %v", issueRef, err)
+		return f.Forge.GetIssueInfo(issueRef, opts...)
+	}
+
+	cached, err := f.cache.get(key)
+	if err != nil {
+		f.logger.Logf("Warning: %v", err)
+	}
+	if cached != nil && f.cache.fresh(cached) && !skipIssueCache(opts) {
+		f.logger.Logf("Using cached issue #%d fetched at %s", key.Number, cached.FetchedAt.Format(time.RFC3339))
+		return checkIssueState(cached.Issue, opts)
+	}
+
+	// Closed issues are fetched too so that their state is cached
+	info, err := f.Forge.GetIssueInfo(issueRef, GetIssueInfoOpts{AllowClosed: true})
+	if err != nil {
+		if cached == nil || !isUnreachable(err) {
+			return nil, err
+		}
+		f.logger.Logf("Warning: %s is unreachable, using issue #%d cached at %s: %v",
+			f.host, key.Number, cached.FetchedAt.Format(time.RFC3339), err)
+		stale := cached.Issue
+		stale.Stale = true
+		return checkIssueState(stale, opts)
+	}
+
+	if err := f.cache.put(key, *info); err != nil {
+		f.logger.Logf("Warning: failed to cache issue #%d: %v", key.Number, err)
+	}
+	return checkIssueState(*info, opts)
+}
+
+// cacheKey returns the cache key of an issue reference.
+// Bare issue numbers refer to the repository pointed by the origin remote of the current directory.
+func (f *issueCachingForge) cacheKey(issueRef string) (issueCacheKey, error) {
+	ref, err := f.ParseIssueReference(issueRef)
+	if err == nil {
+		return issueCacheKey{Host: f.host, Repository: ref.Owner + "/" + ref.Repository, Number: ref.IssueNumber}, nil
+	}
+	if !errors.Is(err, issue.ErrIssueNumberRequiresContext) {
+		return issueCacheKey{}, err
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(issueRef), "#"))
+	if err != nil {
+		return issueCacheKey{}, fmt.Errorf("invalid issue number: %s", issueRef)
+	}
+
+	originURL, err := f.git.GetRemoteURL(".", "origin")
+	if err != nil {
+		return issueCacheKey{}, fmt.Errorf("failed to get remote origin: %w", err)
+	}
+	_, repository, err := parseRemoteURL(originURL)
+	if err != nil {
+		return issueCacheKey{}, err
+	}
+
+	return issueCacheKey{Host: f.host, Repository: repository, Number: number}, nil
+}
+
+// checkIssueState returns the issue, or ErrIssueClosed when it is closed and closed issues are not allowed.
+func checkIssueState(info issue.Info, opts []GetIssueInfoOpts) (*issue.Info, error) {
+	if info.State != issue.StateOpen && !allowClosedIssue(opts) {
+		return nil, fmt.Errorf("%w: issue #%d", ErrIssueClosed, info.Number)
+	}
+	return &info, nil
+}
+
+// skipIssueCache returns true when the options require the issue to be fetched from the forge.
+func skipIssueCache(opts []GetIssueInfoOpts) bool {
+	return len(opts) > 0 && opts[0].SkipCache
+}
+
+// isUnreachable returns true when the error comes from the forge not being reachable,
+// rather than from the forge answering the request.
+func isUnreachable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
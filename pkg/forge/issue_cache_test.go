@@ -0,0 +1,153 @@
+//go:build unit
+
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// issueCacheTestServer serves a Gitea issue whose state can be changed, counting the requests.
+type issueCacheTestServer struct {
+	*httptest.Server
+	state    string
+	requests int
+}
+
+func newIssueCacheTestServer(t *testing.T) *issueCacheTestServer {
+	t.Helper()
+	server := &issueCacheTestServer{state: "open"}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/api/v1/repos/owner/repo/issues/42/timeline" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		server.requests++
+		assert.Equal(t, "/api/v1/repos/owner/repo/issues/42", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"number": 42, "title": "Add Dark Mode", "state": "` + server.state + `",
+			"html_url": "https://gitea.example.com/owner/repo/issues/42"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newIssueCacheTestForge creates a Gitea forge for the test server wrapped with an issue cache.
+func newIssueCacheTestForge(
+	t *testing.T, server *issueCacheTestServer, cache *IssueCache,
+) (*issueCachingForge, *gitmocks.MockGit) {
+	t.Helper()
+	gitea := NewGitea("gitea.example.com", NewGiteaOpts{
+		APIURL:      server.URL + "/api/v1",
+		Credentials: &stubCredentialResolver{credential: Credential{Token: "test-token"}},
+	})
+	mockGit := gitmocks.NewMockGit(gomock.NewController(t))
+	gitea.git = mockGit
+	return newIssueCachingForge(gitea, "gitea.example.com", cache, mockGit, logger.NewNoopLogger()), mockGit
+}
+
+func TestIssueCachingForge_GetIssueInfo_FreshCache(t *testing.T) {
+	server := newIssueCacheTestServer(t)
+	cachingForge, _ := newIssueCacheTestForge(t, server, NewIssueCache(t.TempDir(), time.Hour))
+
+	for range 2 {
+		info, err := cachingForge.GetIssueInfo("owner/repo#42")
+		require.NoError(t, err)
+		assert.Equal(t, "Add Dark Mode", info.Title)
+		assert.False(t, info.Stale)
+	}
+	assert.Equal(t, 1, server.requests, "fresh cached issues must not be fetched again")
+}
+
+func TestIssueCachingForge_GetIssueInfo_ExpiredCache(t *testing.T) {
+	server := newIssueCacheTestServer(t)
+	cache := NewIssueCache(t.TempDir(), time.Hour)
+	cachingForge, _ := newIssueCacheTestForge(t, server, cache)
+
+	_, err := cachingForge.GetIssueInfo("owner/repo#42")
+	require.NoError(t, err)
+
+	// Once expired, the issue is fetched again and its new state is cached
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	server.state = "closed"
+	_, err = cachingForge.GetIssueInfo("owner/repo#42")
+	assert.ErrorIs(t, err, ErrIssueClosed)
+
+	info, err := cachingForge.GetIssueInfo("owner/repo#42", GetIssueInfoOpts{AllowClosed: true})
+	require.NoError(t, err)
+	assert.Equal(t, issue.StateClosed, info.State)
+	assert.Equal(t, 2, server.requests)
+}
+
+func TestIssueCachingForge_GetIssueInfo_SkipCache(t *testing.T) {
+	server := newIssueCacheTestServer(t)
+	cachingForge, _ := newIssueCacheTestForge(t, server, NewIssueCache(t.TempDir(), time.Hour))
+
+	_, err := cachingForge.GetIssueInfo("owner/repo#42")
+	require.NoError(t, err)
+
+	// The fresh cached issue is bypassed, and the new state replaces it
+	server.state = "closed"
+	info, err := cachingForge.GetIssueInfo("owner/repo#42", GetIssueInfoOpts{AllowClosed: true, SkipCache: true})
+	require.NoError(t, err)
+	assert.Equal(t, issue.StateClosed, info.State)
+	assert.Equal(t, 2, server.requests)
+
+	_, err = cachingForge.GetIssueInfo("owner/repo#42")
+	assert.ErrorIs(t, err, ErrIssueClosed)
+	assert.Equal(t, 2, server.requests)
+}
+
+func TestIssueCachingForge_GetIssueInfo_Unreachable(t *testing.T) {
+	server := newIssueCacheTestServer(t)
+	cache := NewIssueCache(t.TempDir(), 0)
+	cachingForge, mockGit := newIssueCacheTestForge(t, server, cache)
+	mockGit.EXPECT().GetRemoteURL(".", "origin").Return("git@gitea.example.com:owner/repo.git", nil).AnyTimes()
+
+	_, err := cachingForge.GetIssueInfo("owner/repo#42")
+	require.NoError(t, err)
+
+	// The forge goes offline: the cached issue is returned as stale, also for bare issue numbers
+	server.Close()
+	for _, ref := range []string{"owner/repo#42", "https://gitea.example.com/owner/repo/issues/42", "42"} {
+		info, err := cachingForge.GetIssueInfo(ref)
+		require.NoError(t, err, ref)
+		assert.True(t, info.Stale, ref)
+		assert.Equal(t, 42, info.Number, ref)
+	}
+
+	// Issues that were never cached still fail
+	_, err = cachingForge.GetIssueInfo("owner/repo#7")
+	assert.Error(t, err)
+}
+
+func TestIssueCachingForge_GetIssueInfo_NotFoundIsNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	cache := NewIssueCache(t.TempDir(), time.Hour)
+	cachingForge, _ := newIssueCacheTestForge(t, &issueCacheTestServer{Server: server}, cache)
+
+	_, err := cachingForge.GetIssueInfo("owner/repo#42")
+	assert.ErrorIs(t, err, ErrIssueNotFound)
+
+	cached, err := cache.get(issueCacheKey{Host: "gitea.example.com", Repository: "owner/repo", Number: 42})
+	require.NoError(t, err)
+	assert.Nil(t, cached)
+}
+
+func TestIssueCache_InvalidKey(t *testing.T) {
+	cache := NewIssueCache(t.TempDir(), time.Hour)
+	err := cache.put(issueCacheKey{Host: "gitea.example.com", Repository: "../escape", Number: 1}, issue.Info{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package forge
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/lerenn/code-manager/pkg/issue"
+)
+
+// issueData holds the fields read from the issue payload of any forge, before normalization.
+type issueData struct {
+	Number      int
+	Title       string
+	Description string
+	State       string
+	URL         string
+	Labels      []string
+	Assignees   []string // Logins of the assigned users
+	Milestone   string   // Milestone title
+}
+
+// newIssueInfo maps issue data to issue information the same way for every forge:
+// the state is normalized and labels and assignees are deduplicated, keeping their order.
+func newIssueInfo(ref *issue.Reference, data issueData) issue.Info {
+	return issue.Info{
+		Number:      data.Number,
+		Title:       data.Title,
+		Description: data.Description,
+		State:       normalizeIssueState(data.State),
+		URL:         data.URL,
+		Repository:  ref.Repository,
+		Owner:       ref.Owner,
+		Labels:      uniqueNonEmpty(data.Labels),
+		Assignees:   uniqueNonEmpty(data.Assignees),
+		Milestone:   data.Milestone,
+	}
+}
+
+// withLinkedPullRequests returns the issue information with the linked pull requests,
+// deduplicated by number and sorted, and with their state normalized.
+func withLinkedPullRequests(info issue.Info, pullRequests []issue.LinkedPullRequest) issue.Info {
+	linked := make([]issue.LinkedPullRequest, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		if slices.ContainsFunc(linked, func(l issue.LinkedPullRequest) bool { return l.Number == pr.Number }) {
+			continue
+		}
+		pr.State = normalizeGitLabState(strings.ToLower(pr.State))
+		linked = append(linked, pr)
+	}
+	slices.SortFunc(linked, func(a, b issue.LinkedPullRequest) int { return a.Number - b.Number })
+
+	if len(linked) == 0 {
+		linked = nil
+	}
+	info.LinkedPullRequests = linked
+	return info
+}
+
+// normalizeIssueState maps the issue states of every forge to the shared issue states.
+func normalizeIssueState(state string) string {
+	switch strings.ToLower(state) {
+	case issue.StateOpen, gitLabOpenedState, gitLabLockedState:
+		return issue.StateOpen
+	case issue.StateClosed:
+		return issue.StateClosed
+	default:
+		return state
+	}
+}
+
+// uniqueNonEmpty returns the non-empty values without duplicates, in their original order.
+func uniqueNonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
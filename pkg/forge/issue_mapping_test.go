@@ -0,0 +1,45 @@
+//go:build unit
+
+package forge
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssueInfo(t *testing.T) {
+	ref := &issue.Reference{Owner: "owner", Repository: "repo"}
+
+	info := newIssueInfo(ref, issueData{
+		Number:    1,
+		Title:     "Fix login",
+		State:     "opened",
+		Labels:    []string{"bug", "", "bug", "ui"},
+		Assignees: []string{"alice", "alice", "bob"},
+		Milestone: "v1.0",
+	})
+	assert.Equal(t, issue.Info{
+		Number:     1,
+		Title:      "Fix login",
+		State:      issue.StateOpen,
+		Repository: "repo",
+		Owner:      "owner",
+		Labels:     []string{"bug", "ui"},
+		Assignees:  []string{"alice", "bob"},
+		Milestone:  "v1.0",
+	}, info)
+}
+
+func TestWithLinkedPullRequests(t *testing.T) {
+	info := withLinkedPullRequests(issue.Info{Number: 1}, []issue.LinkedPullRequest{
+		{Number: 9, State: "opened"},
+		{Number: 3, State: "merged"},
+		{Number: 9, State: "opened"},
+	})
+	assert.Equal(t, []issue.LinkedPullRequest{{Number: 3, State: "merged"}, {Number: 9, State: "open"}},
+		info.LinkedPullRequests)
+
+	assert.Nil(t, withLinkedPullRequests(issue.Info{Number: 1}, nil).LinkedPullRequests)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: credentials.go
+//
+// Generated by this command:
+//
+//	mockgen -source=credentials.go -destination=mocks/credentials.gen.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	forge "github.com/lerenn/code-manager/pkg/forge"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialResolver is a mock of CredentialResolver interface.
+type MockCredentialResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialResolverMockRecorder is the mock recorder for MockCredentialResolver.
+type MockCredentialResolverMockRecorder struct {
+	mock *MockCredentialResolver
+}
+
+// NewMockCredentialResolver creates a new mock instance.
+func NewMockCredentialResolver(ctrl *gomock.Controller) *MockCredentialResolver {
+	mock := &MockCredentialResolver{ctrl: ctrl}
+	mock.recorder = &MockCredentialResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialResolver) EXPECT() *MockCredentialResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockCredentialResolver) Resolve(host, tokenEnv string) (forge.Credential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", host, tokenEnv)
+	ret0, _ := ret[0].(forge.Credential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockCredentialResolverMockRecorder) Resolve(host, tokenEnv any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockCredentialResolver)(nil).Resolve), host, tokenEnv)
+}
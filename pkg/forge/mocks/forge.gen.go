@@ -14,6 +14,7 @@ import (
 
 	forge "github.com/lerenn/code-manager/pkg/forge"
 	issue "github.com/lerenn/code-manager/pkg/issue"
+	pullrequest "github.com/lerenn/code-manager/pkg/pullrequest"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,33 +42,94 @@ func (m *MockForge) EXPECT() *MockForgeMockRecorder {
 	return m.recorder
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockForge) CreatePullRequest(params forge.CreatePullRequestParams) (*pullrequest.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", params)
+	ret0, _ := ret[0].(*pullrequest.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockForgeMockRecorder) CreatePullRequest(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockForge)(nil).CreatePullRequest), params)
+}
+
 // GenerateBranchName mocks base method.
-func (m *MockForge) GenerateBranchName(issueInfo *issue.Info) string {
+func (m *MockForge) GenerateBranchName(issueInfo *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateBranchName", issueInfo)
+	varargs := []any{issueInfo}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateBranchName", varargs...)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateBranchName indicates an expected call of GenerateBranchName.
-func (mr *MockForgeMockRecorder) GenerateBranchName(issueInfo any) *gomock.Call {
+func (mr *MockForgeMockRecorder) GenerateBranchName(issueInfo any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBranchName", reflect.TypeOf((*MockForge)(nil).GenerateBranchName), issueInfo)
+	varargs := append([]any{issueInfo}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBranchName", reflect.TypeOf((*MockForge)(nil).GenerateBranchName), varargs...)
 }
 
 // GetIssueInfo mocks base method.
-func (m *MockForge) GetIssueInfo(issueRef string) (*issue.Info, error) {
+func (m *MockForge) GetIssueInfo(issueRef string, opts ...forge.GetIssueInfoOpts) (*issue.Info, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetIssueInfo", issueRef)
+	varargs := []any{issueRef}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetIssueInfo", varargs...)
 	ret0, _ := ret[0].(*issue.Info)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetIssueInfo indicates an expected call of GetIssueInfo.
-func (mr *MockForgeMockRecorder) GetIssueInfo(issueRef any) *gomock.Call {
+func (mr *MockForgeMockRecorder) GetIssueInfo(issueRef any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{issueRef}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueInfo", reflect.TypeOf((*MockForge)(nil).GetIssueInfo), varargs...)
+}
+
+// GetPullRequestInfo mocks base method.
+func (m *MockForge) GetPullRequestInfo(prRef string, opts ...forge.GetPullRequestInfoOpts) (*pullrequest.Info, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{prRef}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPullRequestInfo", varargs...)
+	ret0, _ := ret[0].(*pullrequest.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestInfo indicates an expected call of GetPullRequestInfo.
+func (mr *MockForgeMockRecorder) GetPullRequestInfo(prRef any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueInfo", reflect.TypeOf((*MockForge)(nil).GetIssueInfo), issueRef)
+	varargs := append([]any{prRef}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestInfo", reflect.TypeOf((*MockForge)(nil).GetPullRequestInfo), varargs...)
+}
+
+// ListIssues mocks base method.
+func (m *MockForge) ListIssues(params forge.ListIssuesParams) ([]issue.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssues", params)
+	ret0, _ := ret[0].([]issue.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssues indicates an expected call of ListIssues.
+func (mr *MockForgeMockRecorder) ListIssues(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssues", reflect.TypeOf((*MockForge)(nil).ListIssues), params)
 }
 
 // Name mocks base method.
@@ -151,3 +213,18 @@ func (mr *MockManagerInterfaceMockRecorder) GetForgeForRepository(repoName any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForgeForRepository", reflect.TypeOf((*MockManagerInterface)(nil).GetForgeForRepository), repoName)
 }
+
+// ParseIssueReference mocks base method.
+func (m *MockManagerInterface) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIssueReference", issueRef)
+	ret0, _ := ret[0].(*issue.Reference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIssueReference indicates an expected call of ParseIssueReference.
+func (mr *MockManagerInterfaceMockRecorder) ParseIssueReference(issueRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIssueReference", reflect.TypeOf((*MockManagerInterface)(nil).ParseIssueReference), issueRef)
+}
@@ -0,0 +1,35 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parseRemoteURL extracts the host and the repository path from a Git remote URL.
+// It handles HTTPS (https://host/group/repo.git), ssh:// (ssh://git@host:22/group/repo.git)
+// and SCP-like SSH (git@host:group/repo.git) formats. The returned path has no ".git" suffix.
+func parseRemoteURL(remoteURL string) (host, path string, err error) {
+	remoteURL = strings.TrimSuffix(strings.TrimSpace(remoteURL), ".git")
+
+	switch {
+	case strings.Contains(remoteURL, "://"):
+		parsedURL, parseErr := url.Parse(remoteURL)
+		if parseErr != nil {
+			return "", "", fmt.Errorf("invalid remote URL %s: %w", remoteURL, parseErr)
+		}
+		host = parsedURL.Hostname()
+		path = strings.Trim(parsedURL.Path, "/")
+	case strings.Contains(remoteURL, "@") && strings.Contains(remoteURL, ":"):
+		// SCP-like format: git@host:group/repo
+		parts := strings.SplitN(remoteURL, ":", 2)
+		host = parts[0][strings.LastIndex(parts[0], "@")+1:]
+		path = strings.Trim(parts[1], "/")
+	}
+
+	if host == "" || path == "" {
+		return "", "", fmt.Errorf("unsupported remote URL format: %s", remoteURL)
+	}
+
+	return host, path, nil
+}
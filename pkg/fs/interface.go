@@ -53,6 +53,9 @@ type FS interface {
 	// ExecuteCommand executes a command with arguments in the background.
 	ExecuteCommand(command string, args ...string) error
 
+	// RunCommand runs a command and waits for it to finish, returning its exit code.
+	RunCommand(params RunCommandParams) (int, error)
+
 	// CreateDirectory creates a directory with permissions.
 	CreateDirectory(path string, perm os.FileMode) error
 
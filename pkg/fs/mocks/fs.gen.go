@@ -13,6 +13,7 @@ import (
 	os "os"
 	reflect "reflect"
 
+	fs "github.com/lerenn/code-manager/pkg/fs"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -322,6 +323,21 @@ func (mr *MockFSMockRecorder) ResolvePath(repositoriesDir, relativePath any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePath", reflect.TypeOf((*MockFS)(nil).ResolvePath), repositoriesDir, relativePath)
 }
 
+// RunCommand mocks base method.
+func (m *MockFS) RunCommand(params fs.RunCommandParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCommand", params)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommand indicates an expected call of RunCommand.
+func (mr *MockFSMockRecorder) RunCommand(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockFS)(nil).RunCommand), params)
+}
+
 // ValidateRepositoryPath mocks base method.
 func (m *MockFS) ValidateRepositoryPath(path string) (bool, error) {
 	m.ctrl.T.Helper()
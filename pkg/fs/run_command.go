@@ -0,0 +1,34 @@
+package fs
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// RunCommandParams contains parameters for RunCommand.
+type RunCommandParams struct {
+	Dir     string    // Directory the command runs in
+	Command string    // Command to run, looked up in PATH
+	Args    []string  // Arguments of the command
+	Output  io.Writer // Receives the standard output and error of the command
+}
+
+// RunCommand runs a command and waits for it to finish. It returns the exit code of the command,
+// and an error only when the command could not be started.
+func (f *realFS) RunCommand(params RunCommandParams) (int, error) {
+	cmd := exec.Command(params.Command, params.Args...)
+	cmd.Dir = params.Dir
+	cmd.Stdout = params.Output
+	cmd.Stderr = params.Output
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package fs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS_RunCommand(t *testing.T) {
+	fs := NewFS()
+	dir := t.TempDir()
+
+	// Test running a command in a directory, with its output and errors captured
+	var output bytes.Buffer
+	code, err := fs.RunCommand(RunCommandParams{
+		Dir:     dir,
+		Command: "sh",
+		Args:    []string{"-c", "pwd && echo oops >&2"},
+		Output:  &output,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, output.String(), dir)
+	assert.Contains(t, output.String(), "oops")
+
+	// Test the exit code of a failing command
+	code, err = fs.RunCommand(RunCommandParams{Dir: dir, Command: "sh", Args: []string{"-c", "exit 3"}, Output: &output})
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+
+	// Test running a non-existing command (should fail)
+	_, err = fs.RunCommand(RunCommandParams{Dir: dir, Command: "non-existing-command-xyz123", Output: &output})
+	assert.Error(t, err)
+}
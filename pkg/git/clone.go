@@ -15,6 +15,11 @@ func (g *realGit) Clone(params CloneParams) error {
 		args = append(args, "--no-recursive")
 	}
 
+	// Add --no-checkout flag if the working tree is checked out later
+	if params.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
+
 	// Add repository URL and target path
 	args = append(args, params.RepoURL, params.TargetPath)
 
@@ -0,0 +1,35 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// CountAheadBehind counts the commits of ref that are not in base (ahead) and of base that are not in ref (behind).
+func (g *realGit) CountAheadBehind(repoPath, base, ref string) (int, int, error) {
+	cmd := exec.Command("git", "rev-list", "--left-right", "--count", base+"..."+ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, 0, fmt.Errorf("git rev-list failed: %w (command: git rev-list --left-right --count %s...%s, output: %s)",
+			err, base, ref, string(output))
+	}
+
+	// The left side counts the commits only in base, the right side the ones only in ref
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected git rev-list output: %q", string(output))
+	}
+	behind, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected git rev-list output: %q", string(output))
+	}
+	ahead, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected git rev-list output: %q", string(output))
+	}
+
+	return ahead, behind, nil
+}
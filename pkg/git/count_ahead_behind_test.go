@@ -0,0 +1,28 @@
+//go:build integration
+
+package git
+
+import (
+	"testing"
+)
+
+func TestGit_CountAheadBehind(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "feature\n")
+
+	ahead, behind, err := git.CountAheadBehind(".", "base", "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error counting commits: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("Expected 2 ahead and 1 behind, got: %d ahead and %d behind", ahead, behind)
+	}
+
+	// Unknown refs are reported as errors
+	if _, _, err := git.CountAheadBehind(".", "non-existent-ref-12345", "HEAD"); err == nil {
+		t.Error("Expected error for an unknown ref")
+	}
+}
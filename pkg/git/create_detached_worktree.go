@@ -0,0 +1,18 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// CreateDetachedWorktree creates a new worktree with its HEAD detached at the given commit.
+func (g *realGit) CreateDetachedWorktree(repoPath, worktreePath, commit string) error {
+	cmd := exec.Command("git", "worktree", "add", "--detach", worktreePath, commit)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree add --detach failed: %w (command: git worktree add --detach %s %s, output: %s)",
+			err, worktreePath, commit, string(output))
+	}
+	return nil
+}
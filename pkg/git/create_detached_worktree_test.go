@@ -0,0 +1,58 @@
+//go:build integration
+
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGit_CreateDetachedWorktree(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	commitFile(t, "release.txt", "v1\n")
+	runGitCommand(t, "tag", "v1.0.0")
+	commitFile(t, "release.txt", "v2\n")
+
+	tagCommit, err := git.ResolveRef(".", "v1.0.0")
+	if err != nil {
+		t.Fatalf("Expected no error resolving tag: %v", err)
+	}
+
+	worktreePath := filepath.Join(tmpDir, "worktrees", "detached", "v1.0.0")
+	if err := git.CreateDetachedWorktree(".", worktreePath, tagCommit); err != nil {
+		t.Fatalf("Expected no error creating detached worktree: %v", err)
+	}
+
+	// HEAD points at the commit instead of a branch
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "HEAD")
+	cmd.Dir = worktreePath
+	if err := cmd.Run(); err == nil {
+		t.Errorf("Expected HEAD to be detached")
+	}
+	head, err := git.ResolveRef(worktreePath, "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error resolving HEAD: %v", err)
+	}
+	if head != tagCommit {
+		t.Errorf("Expected HEAD at %s, got %s", tagCommit, head)
+	}
+
+	// The worktree is known by git
+	output, err := exec.Command("git", "-C", tmpDir, "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		t.Fatalf("Failed to list worktrees: %v", err)
+	}
+	if !strings.Contains(string(output), "detached") {
+		t.Errorf("Expected a detached worktree in %q", output)
+	}
+
+	// Unknown commits are rejected
+	if err := git.CreateDetachedWorktree(".", filepath.Join(tmpDir, "worktrees", "other"), "unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown commit")
+	}
+}
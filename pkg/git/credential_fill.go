@@ -0,0 +1,48 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CredentialFill asks the configured credential helpers for the credentials of a host.
+// Terminal and askpass prompts are disabled so that it never blocks waiting for the user.
+func (g *realGit) CredentialFill(params CredentialFillParams) (*Credential, error) {
+	protocol := params.Protocol
+	if protocol == "" {
+		protocol = "https"
+	}
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\n\n", protocol, params.Host))
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	output, err := cmd.Output()
+	if err != nil {
+		// Git fails when no helper provides the credential and prompting is disabled
+		return nil, fmt.Errorf("%w: %s", ErrCredentialNotFound, params.Host)
+	}
+
+	credential := &Credential{}
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "username":
+			credential.Username = value
+		case "password":
+			credential.Password = value
+		}
+	}
+
+	if credential.Password == "" {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialNotFound, params.Host)
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_CredentialFill(t *testing.T) {
+	git := NewGit()
+
+	// Use an isolated global configuration with a helper answering for a single host
+	configPath := filepath.Join(t.TempDir(), "gitconfig")
+	config := "[credential \"https://git.example.com\"]\n" +
+		"\thelper = \"!f() { echo username=user; echo password=secret; }; f\"\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write git config: %v", err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", configPath)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	credential, err := git.CredentialFill(CredentialFillParams{Host: "git.example.com"})
+	if err != nil {
+		t.Fatalf("Expected no error filling credential: %v", err)
+	}
+	if credential.Username != "user" || credential.Password != "secret" {
+		t.Errorf("Expected user/secret credential, got %s/%s", credential.Username, credential.Password)
+	}
+
+	// Hosts without helper fail instead of prompting
+	_, err = git.CredentialFill(CredentialFillParams{Host: "other.example.com"})
+	if !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected ErrCredentialNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGit_DeleteBranch(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, "branch", "feature")
+
+	if err := git.DeleteBranch(".", "feature"); err != nil {
+		t.Fatalf("Expected no error deleting the branch: %v", err)
+	}
+	if _, err := git.ResolveRef(".", "refs/heads/feature"); !errors.Is(err, ErrReferenceNotFound) {
+		t.Errorf("Expected the branch to be deleted, got: %v", err)
+	}
+
+	if err := git.DeleteBranch(".", "feature"); err == nil {
+		t.Error("Expected an error deleting a deleted branch")
+	}
+}
@@ -13,6 +13,10 @@ var (
 	ErrRemoteAddFailed        = errors.New("failed to add remote")
 	ErrFetchFailed            = errors.New("failed to fetch from remote")
 	ErrBranchNotFoundOnRemote = errors.New("branch not found on remote")
+	ErrCredentialNotFound     = errors.New("no credential found by git credential helpers")
+	ErrReferenceNotFound      = errors.New("reference not found")
+	ErrConflict               = errors.New("conflicting changes")
+	ErrStashNotFound          = errors.New("stash not found")
 
 	// Specific reference conflict error types for testing.
 	ErrBranchParentExists = errors.New("cannot create branch: reference already exists")
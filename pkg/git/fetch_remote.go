@@ -3,16 +3,27 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
+// FetchRemoteOpts contains optional parameters for FetchRemote.
+type FetchRemoteOpts struct {
+	Prune bool // Remove the remote-tracking branches deleted on the remote
+}
+
 // FetchRemote fetches from a specific remote.
-func (g *realGit) FetchRemote(repoPath, remoteName string) error {
-	cmd := exec.Command("git", "fetch", remoteName)
+func (g *realGit) FetchRemote(repoPath, remoteName string, opts ...FetchRemoteOpts) error {
+	args := []string{"fetch", remoteName}
+	if len(opts) > 0 && opts[0].Prune {
+		args = append(args, "--prune")
+	}
+
+	cmd := exec.Command("git", args...)
 	cmd.Dir = repoPath
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git fetch failed: %w (command: git fetch %s, output: %s)",
-			err, remoteName, string(output))
+		return fmt.Errorf("git fetch failed: %w (command: git %s, output: %s)",
+			err, strings.Join(args, " "), string(output))
 	}
 	return nil
 }
@@ -0,0 +1,28 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetLastCommit gets the subject and date of the last commit of the current branch.
+func (g *realGit) GetLastCommit(repoPath string) (*CommitInfo, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%ct %s")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w (command: git log -1 --format=%%ct %%s, output: %s)",
+			err, string(output))
+	}
+
+	timestamp, subject, _ := strings.Cut(strings.TrimSpace(string(output)), " ")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected git log output: %q", string(output))
+	}
+
+	return &CommitInfo{Subject: subject, Date: time.Unix(seconds, 0)}, nil
+}
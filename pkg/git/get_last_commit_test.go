@@ -0,0 +1,27 @@
+//go:build integration
+
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGit_GetLastCommit(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	commitFile(t, "file.txt", "content\n")
+
+	commit, err := git.GetLastCommit(".")
+	if err != nil {
+		t.Fatalf("Expected no error getting last commit: %v", err)
+	}
+	if commit.Subject != "Update file.txt" {
+		t.Errorf("Expected subject 'Update file.txt', got: %q", commit.Subject)
+	}
+	if time.Since(commit.Date) > time.Hour {
+		t.Errorf("Expected a recent commit date, got: %v", commit.Date)
+	}
+}
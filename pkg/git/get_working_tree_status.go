@@ -0,0 +1,58 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GetWorkingTreeStatus counts the staged, modified and untracked files and compares the branch with its upstream.
+func (g *realGit) GetWorkingTreeStatus(repoPath string) (*WorkingTreeStatus, error) {
+	cmd := exec.Command("git", "status", "--porcelain=v2", "--branch")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w (command: git status --porcelain=v2 --branch)", err)
+	}
+
+	return parseWorkingTreeStatus(string(output)), nil
+}
+
+// parseWorkingTreeStatus parses the output of `git status --porcelain=v2 --branch`.
+func parseWorkingTreeStatus(output string) *WorkingTreeStatus {
+	result := &WorkingTreeStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "#":
+			parseBranchHeader(result, fields[1:])
+		case "1", "2", "u":
+			// Changed entries start with a two-letter XY code: X for the index, Y for the working tree
+			if fields[1][0] != '.' {
+				result.Staged++
+			}
+			if len(fields[1]) > 1 && fields[1][1] != '.' {
+				result.Modified++
+			}
+		case "?":
+			result.Untracked++
+		}
+	}
+	return result
+}
+
+// parseBranchHeader parses a `# branch.*` header of the porcelain v2 status.
+func parseBranchHeader(result *WorkingTreeStatus, fields []string) {
+	switch {
+	case fields[0] == "branch.upstream" && len(fields) > 1:
+		result.Upstream = fields[1]
+	case fields[0] == "branch.ab" && len(fields) > 2:
+		result.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "+"))
+		result.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "-"))
+	}
+}
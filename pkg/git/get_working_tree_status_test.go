@@ -0,0 +1,75 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGit_GetWorkingTreeStatus(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	commitFile(t, "tracked.txt", "initial\n")
+	commitFile(t, "other.txt", "initial\n")
+
+	// A staged file, a modified file and an untracked file
+	if err := os.WriteFile("tracked.txt", []byte("staged\n"), 0644); err != nil {
+		t.Fatalf("Failed to write tracked.txt: %v", err)
+	}
+	runGitCommand(t, "add", "tracked.txt")
+	if err := os.WriteFile("other.txt", []byte("modified\n"), 0644); err != nil {
+		t.Fatalf("Failed to write other.txt: %v", err)
+	}
+	if err := os.WriteFile("untracked.txt", []byte("untracked\n"), 0644); err != nil {
+		t.Fatalf("Failed to write untracked.txt: %v", err)
+	}
+
+	result, err := git.GetWorkingTreeStatus(".")
+	if err != nil {
+		t.Fatalf("Expected no error getting working tree status: %v", err)
+	}
+	expected := WorkingTreeStatus{Staged: 1, Modified: 1, Untracked: 1}
+	if *result != expected {
+		t.Errorf("Expected %+v, got: %+v", expected, *result)
+	}
+}
+
+func TestGit_GetWorkingTreeStatus_Upstream(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "")
+	runGitCommand(t, "branch", "--set-upstream-to=base")
+
+	result, err := git.GetWorkingTreeStatus(".")
+	if err != nil {
+		t.Fatalf("Expected no error getting working tree status: %v", err)
+	}
+	expected := WorkingTreeStatus{Upstream: "base", Ahead: 1, Behind: 1}
+	if *result != expected {
+		t.Errorf("Expected %+v, got: %+v", expected, *result)
+	}
+}
+
+func TestParseWorkingTreeStatus(t *testing.T) {
+	output := `# branch.oid 0123456789abcdef0123456789abcdef01234567
+# branch.head feature
+# branch.upstream origin/feature
+# branch.ab +2 -3
+1 M. N... 100644 100644 100644 0123 4567 staged.txt
+1 .M N... 100644 100644 100644 0123 4567 modified.txt
+1 MM N... 100644 100644 100644 0123 4567 both.txt
+2 R. N... 100644 100644 100644 0123 4567 R100 renamed.txt	old.txt
+? untracked.txt
+! ignored.txt
+`
+	result := parseWorkingTreeStatus(output)
+	expected := WorkingTreeStatus{Staged: 3, Modified: 2, Untracked: 1, Upstream: "origin/feature", Ahead: 2, Behind: 3}
+	if *result != expected {
+		t.Errorf("Expected %+v, got: %+v", expected, *result)
+	}
+}
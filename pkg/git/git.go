@@ -16,9 +16,15 @@ type Git interface {
 	// CreateWorktreeWithNoCheckout creates a new worktree without checking out files.
 	CreateWorktreeWithNoCheckout(repoPath, worktreePath, branch string) error
 
+	// CreateDetachedWorktree creates a new worktree with its HEAD detached at the given commit.
+	CreateDetachedWorktree(repoPath, worktreePath, commit string) error
+
 	// CloneToPath clones a local repository to a target path with a specific branch.
 	CloneToPath(sourceRepoPath, targetPath, branch string) error
 
+	// SparseCheckoutSet restricts the working tree of a worktree to the given directories.
+	SparseCheckoutSet(worktreePath string, patterns []string) error
+
 	// CheckoutBranch checks out a branch in the specified worktree.
 	CheckoutBranch(worktreePath, branch string) error
 
@@ -31,12 +37,54 @@ type Git interface {
 	// IsClean checks if the repository is in a clean state (placeholder for future validation).
 	IsClean(repoPath string) (bool, error)
 
+	// GetWorkingTreeStatus counts the staged, modified and untracked files and compares the branch with its upstream.
+	GetWorkingTreeStatus(repoPath string) (*WorkingTreeStatus, error)
+
+	// CountAheadBehind counts the commits of ref that are not in base (ahead) and of base that are not in ref (behind).
+	CountAheadBehind(repoPath, base, ref string) (int, int, error)
+
+	// GetLastCommit gets the subject and date of the last commit of the current branch.
+	GetLastCommit(repoPath string) (*CommitInfo, error)
+
+	// IsAncestor checks if the ancestor commit is reachable from ref.
+	IsAncestor(repoPath, ancestor, ref string) (bool, error)
+
+	// IsSquashMerged checks if the changes of ref were applied to base as a single commit.
+	IsSquashMerged(repoPath, base, ref string) (bool, error)
+
+	// IsUpstreamGone checks if the upstream branch of a local branch was deleted from its remote.
+	IsUpstreamGone(repoPath, branch string) (bool, error)
+
+	// HasBranchCommits checks if commits were made on a local branch since it was created, according to its reflog.
+	HasBranchCommits(repoPath, branch string) (bool, error)
+
+	// Rebase rebases the current branch onto the given ref, aborting it on conflicts.
+	Rebase(repoPath, onto string) error
+
+	// Merge merges the given ref into the current branch, aborting it on conflicts.
+	Merge(repoPath, ref string) error
+
+	// StashPush stashes the staged, unstaged and untracked changes and returns the stash commit.
+	StashPush(repoPath, message string) (string, error)
+
+	// StashApply applies the stash, restoring staged changes to the index, and keeps it.
+	StashApply(repoPath, stash string) error
+
+	// StashDrop removes the stash with the given commit from the stash list.
+	StashDrop(repoPath, stash string) error
+
+	// DeleteBranch deletes a local branch, even when it is not merged.
+	DeleteBranch(repoPath, branch string) error
+
 	// BranchExists checks if a branch exists locally or remotely.
 	BranchExists(repoPath, branch string) (bool, error)
 
 	// CreateBranchFrom creates a new branch from a specific branch.
 	CreateBranchFrom(params CreateBranchFromParams) error
 
+	// ResolveRef resolves a branch, tag, remote branch or commit SHA to the commit it points to.
+	ResolveRef(repoPath, ref string) (string, error)
+
 	// CheckReferenceConflict checks if creating a branch would conflict with existing references.
 	CheckReferenceConflict(repoPath, branch string) error
 
@@ -44,8 +92,21 @@ type Git interface {
 	WorktreeExists(repoPath, branch string) (bool, error)
 
 	// RemoveWorktree removes a worktree from Git's tracking.
+	// When forced, worktrees with uncommitted changes and locked worktrees are removed as well.
 	RemoveWorktree(repoPath, worktreePath string, force bool) error
 
+	// LockWorktree locks a worktree, so that git refuses to remove, move or prune it.
+	LockWorktree(repoPath, worktreePath, reason string) error
+
+	// UnlockWorktree unlocks a worktree locked with LockWorktree.
+	UnlockWorktree(repoPath, worktreePath string) error
+
+	// ListWorktrees lists the worktrees known to git, starting with the main worktree.
+	ListWorktrees(repoPath string) ([]WorktreeEntry, error)
+
+	// PruneWorktreeInfo removes the administrative files of worktrees whose directory is gone.
+	PruneWorktreeInfo(repoPath string) error
+
 	// GetWorktreePath gets the path of a worktree for a branch.
 	GetWorktreePath(repoPath, branch string) (string, error)
 
@@ -53,7 +114,7 @@ type Git interface {
 	AddRemote(repoPath, remoteName, remoteURL string) error
 
 	// FetchRemote fetches from a specific remote.
-	FetchRemote(repoPath, remoteName string) error
+	FetchRemote(repoPath, remoteName string, opts ...FetchRemoteOpts) error
 
 	// BranchExistsOnRemote checks if a branch exists on a specific remote.
 	BranchExistsOnRemote(params BranchExistsOnRemoteParams) (bool, error)
@@ -85,6 +146,13 @@ type Git interface {
 	// SetUpstreamBranch sets the upstream branch for the current branch.
 	SetUpstreamBranch(repoPath, remote, branch string) error
 
+	// Push pushes a branch to a remote, optionally setting it as the upstream branch.
+	Push(params PushParams) error
+
+	// CredentialFill asks the configured credential helpers for the credentials of a host,
+	// without prompting the user.
+	CredentialFill(params CredentialFillParams) (*Credential, error)
+
 	// GetMainRepositoryPath gets the main repository path from a worktree path.
 	// If the path is already a main repository, it returns the same path.
 	// If the path is a worktree, it returns the main repository path.
@@ -0,0 +1,27 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HasBranchCommits checks if commits were made on a local branch since it was created, according to its reflog.
+// Branches whose reflog expired are reported as having no commits.
+func (g *realGit) HasBranchCommits(repoPath, branch string) (bool, error) {
+	cmd := exec.Command("git", "reflog", "show", "--format=%gs", "refs/heads/"+branch, "--")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("git reflog failed: %w (command: git reflog show refs/heads/%s, output: %s)",
+			err, branch, string(output))
+	}
+
+	// Commits, amends, merges and cherry-picks are recorded as "commit...: <subject>" or "cherry-pick: <subject>"
+	for _, subject := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(subject, "commit") || strings.HasPrefix(subject, "cherry-pick") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
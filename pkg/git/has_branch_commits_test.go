@@ -0,0 +1,37 @@
+//go:build integration
+
+package git
+
+import (
+	"testing"
+)
+
+func TestGit_HasBranchCommits(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	// Freshly created branches have no commits of their own
+	runGitCommand(t, "checkout", "-b", "feature")
+	hasCommits, err := git.HasBranchCommits(".", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking branch commits: %v", err)
+	}
+	if hasCommits {
+		t.Error("Expected a fresh branch to have no commits")
+	}
+
+	runGitCommand(t, "commit", "--allow-empty", "-m", "Add feature")
+	hasCommits, err = git.HasBranchCommits(".", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking branch commits: %v", err)
+	}
+	if !hasCommits {
+		t.Error("Expected the branch to have commits")
+	}
+
+	// Unknown branches fail
+	if _, err := git.HasBranchCommits(".", "unknown"); err == nil {
+		t.Error("Expected an error for an unknown branch")
+	}
+}
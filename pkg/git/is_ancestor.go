@@ -0,0 +1,23 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// IsAncestor checks if the ancestor commit is reachable from ref.
+func (g *realGit) IsAncestor(repoPath, ancestor, ref string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("git merge-base failed: %w (command: git merge-base --is-ancestor %s %s, output: %s)",
+			err, ancestor, ref, string(output))
+	}
+	return true, nil
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package git
+
+import (
+	"testing"
+)
+
+func TestGit_IsAncestor(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "")
+
+	// Diverged branches are not ancestors of each other
+	isAncestor, err := git.IsAncestor(".", "base", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking ancestry: %v", err)
+	}
+	if isAncestor {
+		t.Error("Expected base not to be an ancestor of feature")
+	}
+
+	// A commit is an ancestor of its descendants
+	isAncestor, err = git.IsAncestor(".", "feature~1", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking ancestry: %v", err)
+	}
+	if !isAncestor {
+		t.Error("Expected feature~1 to be an ancestor of feature")
+	}
+
+	// Unknown refs are reported as errors
+	if _, err := git.IsAncestor(".", "non-existent-ref-12345", "HEAD"); err == nil {
+		t.Error("Expected error for an unknown ref")
+	}
+}
@@ -0,0 +1,28 @@
+package git
+
+import "strings"
+
+// IsSquashMerged checks if the changes of ref since it diverged from base were applied to base as a single
+// commit, as done by squash merges. The changes are squashed into a temporary commit whose patch-id is
+// looked up in base with `git cherry`.
+func (g *realGit) IsSquashMerged(repoPath, base, ref string) (bool, error) {
+	mergeBase, err := g.runForOutput(repoPath, "merge-base", base, ref)
+	if err != nil {
+		return false, err
+	}
+	tree, err := g.runForOutput(repoPath, "rev-parse", ref+"^{tree}")
+	if err != nil {
+		return false, err
+	}
+	squashed, err := g.runForOutput(repoPath, "commit-tree", tree, "-p", mergeBase, "-m", "squash of "+ref)
+	if err != nil {
+		return false, err
+	}
+
+	// Commits whose changes are already in base are prefixed with "-"
+	cherry, err := g.runForOutput(repoPath, "cherry", base, squashed)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(cherry, "-"), nil
+}
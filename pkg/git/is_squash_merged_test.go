@@ -0,0 +1,40 @@
+//go:build integration
+
+package git
+
+import (
+	"testing"
+)
+
+func TestGit_IsSquashMerged(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	// A feature branch with two commits
+	runGitCommand(t, "checkout", "-b", "feature")
+	commitFile(t, "first.txt", "first\n")
+	commitFile(t, "second.txt", "second\n")
+
+	// Unmerged branches are not reported
+	runGitCommand(t, "checkout", "-b", "base", "feature~2")
+	commitFile(t, "unrelated.txt", "unrelated\n")
+	isSquashMerged, err := git.IsSquashMerged(".", "base", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking squash merge: %v", err)
+	}
+	if isSquashMerged {
+		t.Error("Expected feature not to be squash-merged")
+	}
+
+	// Squash-merging the branch applies its changes as a single commit
+	runGitCommand(t, "merge", "--squash", "feature")
+	runGitCommand(t, "commit", "-m", "Feature (#1)")
+	isSquashMerged, err = git.IsSquashMerged(".", "base", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking squash merge: %v", err)
+	}
+	if !isSquashMerged {
+		t.Error("Expected feature to be squash-merged")
+	}
+}
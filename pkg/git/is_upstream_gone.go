@@ -0,0 +1,20 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// IsUpstreamGone checks if the upstream branch of a local branch was deleted from its remote.
+// Deletions are only known once the remote-tracking branches have been pruned.
+func (g *realGit) IsUpstreamGone(repoPath, branch string) (bool, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(upstream:track)", "refs/heads/"+branch)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("git for-each-ref failed: %w (command: git for-each-ref refs/heads/%s, output: %s)",
+			err, branch, string(output))
+	}
+	return strings.TrimSpace(string(output)) == "[gone]", nil
+}
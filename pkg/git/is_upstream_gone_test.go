@@ -0,0 +1,52 @@
+//go:build integration
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_IsUpstreamGone(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	// Use a local bare repository as origin
+	remotePath := filepath.Join(tmpDir, "remote.git")
+	runGitCommand(t, "init", "--bare", remotePath)
+	runGitCommand(t, "remote", "set-url", "origin", remotePath)
+	runGitCommand(t, "checkout", "-b", "feature")
+	runGitCommand(t, "push", "--set-upstream", "origin", "feature")
+
+	isGone, err := git.IsUpstreamGone(".", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking upstream: %v", err)
+	}
+	if isGone {
+		t.Error("Expected upstream to exist")
+	}
+
+	// The deletion is known once the remote-tracking branches are pruned
+	runGitCommand(t, "--git-dir", remotePath, "branch", "-D", "feature")
+	if err := git.FetchRemote(".", "origin", FetchRemoteOpts{Prune: true}); err != nil {
+		t.Fatalf("Expected no error fetching with prune: %v", err)
+	}
+	isGone, err = git.IsUpstreamGone(".", "feature")
+	if err != nil {
+		t.Fatalf("Expected no error checking upstream: %v", err)
+	}
+	if !isGone {
+		t.Error("Expected upstream to be gone")
+	}
+
+	// Branches without upstream are not reported
+	runGitCommand(t, "branch", "local-only")
+	isGone, err = git.IsUpstreamGone(".", "local-only")
+	if err != nil {
+		t.Fatalf("Expected no error checking upstream: %v", err)
+	}
+	if isGone {
+		t.Error("Expected a branch without upstream not to be reported")
+	}
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ListWorktrees lists the worktrees known to git, starting with the main worktree.
+func (g *realGit) ListWorktrees(repoPath string) ([]WorktreeEntry, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git worktree list failed: %w (command: git worktree list --porcelain, output: %s)",
+			err, string(output))
+	}
+
+	return parseWorktreeList(string(output)), nil
+}
+
+// parseWorktreeList parses the output of `git worktree list --porcelain`, made of blank-line separated
+// blocks of attributes, each block starting with the worktree path.
+func parseWorktreeList(output string) []WorktreeEntry {
+	var entries []WorktreeEntry
+	for _, line := range strings.Split(output, "\n") {
+		attribute, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if attribute == "worktree" {
+			entries = append(entries, WorktreeEntry{Path: value})
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		entry := &entries[len(entries)-1]
+		switch attribute {
+		case "HEAD":
+			entry.Head = value
+		case "branch":
+			entry.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			entry.Bare = true
+		case "detached":
+			entry.Detached = true
+		case "locked":
+			entry.Locked = true
+		case "prunable":
+			entry.Prunable = true
+		}
+	}
+	return entries
+}
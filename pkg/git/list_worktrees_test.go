@@ -0,0 +1,82 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_ListWorktrees(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	worktreePath := filepath.Join(tmpDir, "worktrees", "feature")
+	runGitCommand(t, "worktree", "add", "-b", "feature", worktreePath)
+	gonePath := filepath.Join(tmpDir, "worktrees", "gone")
+	runGitCommand(t, "worktree", "add", "-b", "gone", gonePath)
+	if err := os.RemoveAll(gonePath); err != nil {
+		t.Fatalf("Failed to remove worktree directory: %v", err)
+	}
+
+	entries, err := git.ListWorktrees(".")
+	if err != nil {
+		t.Fatalf("Expected no error listing worktrees: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 worktrees, got: %+v", entries)
+	}
+
+	// The main worktree comes first
+	if entries[1].Branch != "feature" || entries[1].Prunable || filepath.Base(entries[1].Path) != "feature" {
+		t.Errorf("Unexpected feature worktree: %+v", entries[1])
+	}
+	if entries[2].Branch != "gone" || !entries[2].Prunable {
+		t.Errorf("Expected the gone worktree to be prunable: %+v", entries[2])
+	}
+
+	// Pruning removes the worktrees whose directory is gone
+	if err := git.PruneWorktreeInfo("."); err != nil {
+		t.Fatalf("Expected no error pruning worktrees: %v", err)
+	}
+	entries, err = git.ListWorktrees(".")
+	if err != nil {
+		t.Fatalf("Expected no error listing worktrees: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 worktrees after pruning, got: %+v", entries)
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /repo
+HEAD 0123456789abcdef0123456789abcdef01234567
+branch refs/heads/main
+
+worktree /worktrees/detached
+HEAD 0123456789abcdef0123456789abcdef01234567
+detached
+locked reason
+
+worktree /worktrees/gone
+HEAD 0123456789abcdef0123456789abcdef01234567
+branch refs/heads/feature/gone
+prunable gitdir file points to non-existent location
+`
+	entries := parseWorktreeList(output)
+	expected := []WorktreeEntry{
+		{Path: "/repo", Head: "0123456789abcdef0123456789abcdef01234567", Branch: "main"},
+		{Path: "/worktrees/detached", Head: "0123456789abcdef0123456789abcdef01234567", Detached: true, Locked: true},
+		{Path: "/worktrees/gone", Head: "0123456789abcdef0123456789abcdef01234567", Branch: "feature/gone", Prunable: true},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got: %+v", len(expected), entries)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("Expected %+v, got: %+v", expected[i], entries[i])
+		}
+	}
+}
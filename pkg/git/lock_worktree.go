@@ -0,0 +1,26 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// LockWorktree locks a worktree, so that git refuses to remove, move or prune it.
+// The reason is optional and shown by `git worktree list`.
+func (g *realGit) LockWorktree(repoPath, worktreePath, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, worktreePath)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree lock failed: %w (command: git %s, output: %s)",
+			err, strings.Join(args, " "), string(output))
+	}
+	return nil
+}
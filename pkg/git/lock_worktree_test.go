@@ -0,0 +1,43 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_LockWorktree(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	worktreePath := filepath.Join(tmpDir, "worktrees", "release")
+	runGitCommand(t, "worktree", "add", "-b", "release", worktreePath)
+
+	if err := git.LockWorktree(".", worktreePath, "long-lived release worktree"); err != nil {
+		t.Fatalf("Expected no error locking worktree: %v", err)
+	}
+	entries, err := git.ListWorktrees(".")
+	if err != nil {
+		t.Fatalf("Expected no error listing worktrees: %v", err)
+	}
+	if len(entries) != 2 || !entries[1].Locked {
+		t.Errorf("Expected the worktree to be locked: %+v", entries)
+	}
+
+	// Locked worktrees are only removed when forced
+	if err := git.RemoveWorktree(".", worktreePath, false); err == nil {
+		t.Error("Expected an error removing a locked worktree without force")
+	}
+	if err := git.LockWorktree(".", worktreePath, ""); err == nil {
+		t.Error("Expected an error locking a locked worktree")
+	}
+	if err := git.RemoveWorktree(".", worktreePath, true); err != nil {
+		t.Fatalf("Expected no error removing a locked worktree with force: %v", err)
+	}
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Errorf("Expected worktree directory %s to be removed", worktreePath)
+	}
+}
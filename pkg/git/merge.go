@@ -0,0 +1,26 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Merge merges the given ref into the current branch.
+// When the merge stops on conflicts it is aborted, leaving the branch untouched, and ErrConflict is returned.
+func (g *realGit) Merge(repoPath, ref string) error {
+	cmd := exec.Command("git", "merge", "--no-edit", ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	// Aborting only succeeds when the merge stopped half-way
+	abortCmd := exec.Command("git", "merge", "--abort")
+	abortCmd.Dir = repoPath
+	if abortErr := abortCmd.Run(); abortErr == nil {
+		return fmt.Errorf("%w: merge of %s aborted (output: %s)", ErrConflict, ref, string(output))
+	}
+
+	return fmt.Errorf("git merge failed: %w (command: git merge --no-edit %s, output: %s)", err, ref, string(output))
+}
@@ -0,0 +1,50 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGit_Merge(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "")
+
+	if err := git.Merge(".", "base"); err != nil {
+		t.Fatalf("Expected no error merging base: %v", err)
+	}
+
+	isAncestor, err := git.IsAncestor(".", "base", "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error checking ancestry: %v", err)
+	}
+	if !isAncestor {
+		t.Error("Expected base to be an ancestor of the merged branch")
+	}
+}
+
+func TestGit_Merge_Conflict(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "feature\n")
+	head := runGitCommand(t, "rev-parse", "HEAD")
+
+	err := git.Merge(".", "base")
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got: %v", err)
+	}
+
+	// The merge is aborted, leaving the branch where it was
+	if current := runGitCommand(t, "rev-parse", "HEAD"); current != head {
+		t.Errorf("Expected HEAD to stay at %s, got: %s", head, current)
+	}
+	if isClean, _ := git.IsClean("."); !isClean {
+		t.Error("Expected a clean working tree after the aborted merge")
+	}
+}
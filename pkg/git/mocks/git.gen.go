@@ -188,6 +188,22 @@ func (mr *MockGitMockRecorder) ConfigGet(workDir, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigGet", reflect.TypeOf((*MockGit)(nil).ConfigGet), workDir, key)
 }
 
+// CountAheadBehind mocks base method.
+func (m *MockGit) CountAheadBehind(repoPath, base, ref string) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAheadBehind", repoPath, base, ref)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountAheadBehind indicates an expected call of CountAheadBehind.
+func (mr *MockGitMockRecorder) CountAheadBehind(repoPath, base, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAheadBehind", reflect.TypeOf((*MockGit)(nil).CountAheadBehind), repoPath, base, ref)
+}
+
 // CreateBranch mocks base method.
 func (m *MockGit) CreateBranch(repoPath, branch string) error {
 	m.ctrl.T.Helper()
@@ -216,6 +232,20 @@ func (mr *MockGitMockRecorder) CreateBranchFrom(params any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranchFrom", reflect.TypeOf((*MockGit)(nil).CreateBranchFrom), params)
 }
 
+// CreateDetachedWorktree mocks base method.
+func (m *MockGit) CreateDetachedWorktree(repoPath, worktreePath, commit string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDetachedWorktree", repoPath, worktreePath, commit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDetachedWorktree indicates an expected call of CreateDetachedWorktree.
+func (mr *MockGitMockRecorder) CreateDetachedWorktree(repoPath, worktreePath, commit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDetachedWorktree", reflect.TypeOf((*MockGit)(nil).CreateDetachedWorktree), repoPath, worktreePath, commit)
+}
+
 // CreateWorktree mocks base method.
 func (m *MockGit) CreateWorktree(repoPath, worktreePath, branch string) error {
 	m.ctrl.T.Helper()
@@ -244,18 +274,52 @@ func (mr *MockGitMockRecorder) CreateWorktreeWithNoCheckout(repoPath, worktreePa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorktreeWithNoCheckout", reflect.TypeOf((*MockGit)(nil).CreateWorktreeWithNoCheckout), repoPath, worktreePath, branch)
 }
 
+// CredentialFill mocks base method.
+func (m *MockGit) CredentialFill(params git.CredentialFillParams) (*git.Credential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CredentialFill", params)
+	ret0, _ := ret[0].(*git.Credential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CredentialFill indicates an expected call of CredentialFill.
+func (mr *MockGitMockRecorder) CredentialFill(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CredentialFill", reflect.TypeOf((*MockGit)(nil).CredentialFill), params)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockGit) DeleteBranch(repoPath, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", repoPath, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockGitMockRecorder) DeleteBranch(repoPath, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockGit)(nil).DeleteBranch), repoPath, branch)
+}
+
 // FetchRemote mocks base method.
-func (m *MockGit) FetchRemote(repoPath, remoteName string) error {
+func (m *MockGit) FetchRemote(repoPath, remoteName string, opts ...git.FetchRemoteOpts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchRemote", repoPath, remoteName)
+	varargs := []any{repoPath, remoteName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchRemote", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FetchRemote indicates an expected call of FetchRemote.
-func (mr *MockGitMockRecorder) FetchRemote(repoPath, remoteName any) *gomock.Call {
+func (mr *MockGitMockRecorder) FetchRemote(repoPath, remoteName any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRemote", reflect.TypeOf((*MockGit)(nil).FetchRemote), repoPath, remoteName)
+	varargs := append([]any{repoPath, remoteName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRemote", reflect.TypeOf((*MockGit)(nil).FetchRemote), varargs...)
 }
 
 // GetBranchRemote mocks base method.
@@ -303,6 +367,21 @@ func (mr *MockGitMockRecorder) GetDefaultBranch(remoteURL any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultBranch", reflect.TypeOf((*MockGit)(nil).GetDefaultBranch), remoteURL)
 }
 
+// GetLastCommit mocks base method.
+func (m *MockGit) GetLastCommit(repoPath string) (*git.CommitInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCommit", repoPath)
+	ret0, _ := ret[0].(*git.CommitInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCommit indicates an expected call of GetLastCommit.
+func (mr *MockGitMockRecorder) GetLastCommit(repoPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCommit", reflect.TypeOf((*MockGit)(nil).GetLastCommit), repoPath)
+}
+
 // GetMainRepositoryPath mocks base method.
 func (m *MockGit) GetMainRepositoryPath(worktreePath string) (string, error) {
 	m.ctrl.T.Helper()
@@ -348,6 +427,21 @@ func (mr *MockGitMockRecorder) GetRepositoryName(repoPath any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryName", reflect.TypeOf((*MockGit)(nil).GetRepositoryName), repoPath)
 }
 
+// GetWorkingTreeStatus mocks base method.
+func (m *MockGit) GetWorkingTreeStatus(repoPath string) (*git.WorkingTreeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkingTreeStatus", repoPath)
+	ret0, _ := ret[0].(*git.WorkingTreeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkingTreeStatus indicates an expected call of GetWorkingTreeStatus.
+func (mr *MockGitMockRecorder) GetWorkingTreeStatus(repoPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkingTreeStatus", reflect.TypeOf((*MockGit)(nil).GetWorkingTreeStatus), repoPath)
+}
+
 // GetWorktreePath mocks base method.
 func (m *MockGit) GetWorktreePath(repoPath, branch string) (string, error) {
 	m.ctrl.T.Helper()
@@ -363,6 +457,36 @@ func (mr *MockGitMockRecorder) GetWorktreePath(repoPath, branch any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorktreePath", reflect.TypeOf((*MockGit)(nil).GetWorktreePath), repoPath, branch)
 }
 
+// HasBranchCommits mocks base method.
+func (m *MockGit) HasBranchCommits(repoPath, branch string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasBranchCommits", repoPath, branch)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasBranchCommits indicates an expected call of HasBranchCommits.
+func (mr *MockGitMockRecorder) HasBranchCommits(repoPath, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBranchCommits", reflect.TypeOf((*MockGit)(nil).HasBranchCommits), repoPath, branch)
+}
+
+// IsAncestor mocks base method.
+func (m *MockGit) IsAncestor(repoPath, ancestor, ref string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAncestor", repoPath, ancestor, ref)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAncestor indicates an expected call of IsAncestor.
+func (mr *MockGitMockRecorder) IsAncestor(repoPath, ancestor, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAncestor", reflect.TypeOf((*MockGit)(nil).IsAncestor), repoPath, ancestor, ref)
+}
+
 // IsClean mocks base method.
 func (m *MockGit) IsClean(repoPath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -378,6 +502,121 @@ func (mr *MockGitMockRecorder) IsClean(repoPath any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClean", reflect.TypeOf((*MockGit)(nil).IsClean), repoPath)
 }
 
+// IsSquashMerged mocks base method.
+func (m *MockGit) IsSquashMerged(repoPath, base, ref string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSquashMerged", repoPath, base, ref)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSquashMerged indicates an expected call of IsSquashMerged.
+func (mr *MockGitMockRecorder) IsSquashMerged(repoPath, base, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSquashMerged", reflect.TypeOf((*MockGit)(nil).IsSquashMerged), repoPath, base, ref)
+}
+
+// IsUpstreamGone mocks base method.
+func (m *MockGit) IsUpstreamGone(repoPath, branch string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUpstreamGone", repoPath, branch)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUpstreamGone indicates an expected call of IsUpstreamGone.
+func (mr *MockGitMockRecorder) IsUpstreamGone(repoPath, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpstreamGone", reflect.TypeOf((*MockGit)(nil).IsUpstreamGone), repoPath, branch)
+}
+
+// ListWorktrees mocks base method.
+func (m *MockGit) ListWorktrees(repoPath string) ([]git.WorktreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorktrees", repoPath)
+	ret0, _ := ret[0].([]git.WorktreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorktrees indicates an expected call of ListWorktrees.
+func (mr *MockGitMockRecorder) ListWorktrees(repoPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorktrees", reflect.TypeOf((*MockGit)(nil).ListWorktrees), repoPath)
+}
+
+// LockWorktree mocks base method.
+func (m *MockGit) LockWorktree(repoPath, worktreePath, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWorktree", repoPath, worktreePath, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockWorktree indicates an expected call of LockWorktree.
+func (mr *MockGitMockRecorder) LockWorktree(repoPath, worktreePath, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWorktree", reflect.TypeOf((*MockGit)(nil).LockWorktree), repoPath, worktreePath, reason)
+}
+
+// Merge mocks base method.
+func (m *MockGit) Merge(repoPath, ref string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", repoPath, ref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockGitMockRecorder) Merge(repoPath, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockGit)(nil).Merge), repoPath, ref)
+}
+
+// PruneWorktreeInfo mocks base method.
+func (m *MockGit) PruneWorktreeInfo(repoPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneWorktreeInfo", repoPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneWorktreeInfo indicates an expected call of PruneWorktreeInfo.
+func (mr *MockGitMockRecorder) PruneWorktreeInfo(repoPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneWorktreeInfo", reflect.TypeOf((*MockGit)(nil).PruneWorktreeInfo), repoPath)
+}
+
+// Push mocks base method.
+func (m *MockGit) Push(params git.PushParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockGitMockRecorder) Push(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGit)(nil).Push), params)
+}
+
+// Rebase mocks base method.
+func (m *MockGit) Rebase(repoPath, onto string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebase", repoPath, onto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebase indicates an expected call of Rebase.
+func (mr *MockGitMockRecorder) Rebase(repoPath, onto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockGit)(nil).Rebase), repoPath, onto)
+}
+
 // RemoteExists mocks base method.
 func (m *MockGit) RemoteExists(repoPath, remoteName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -407,6 +646,21 @@ func (mr *MockGitMockRecorder) RemoveWorktree(repoPath, worktreePath, force any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWorktree", reflect.TypeOf((*MockGit)(nil).RemoveWorktree), repoPath, worktreePath, force)
 }
 
+// ResolveRef mocks base method.
+func (m *MockGit) ResolveRef(repoPath, ref string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRef", repoPath, ref)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRef indicates an expected call of ResolveRef.
+func (mr *MockGitMockRecorder) ResolveRef(repoPath, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRef", reflect.TypeOf((*MockGit)(nil).ResolveRef), repoPath, ref)
+}
+
 // SetUpstreamBranch mocks base method.
 func (m *MockGit) SetUpstreamBranch(repoPath, remote, branch string) error {
 	m.ctrl.T.Helper()
@@ -421,6 +675,63 @@ func (mr *MockGitMockRecorder) SetUpstreamBranch(repoPath, remote, branch any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUpstreamBranch", reflect.TypeOf((*MockGit)(nil).SetUpstreamBranch), repoPath, remote, branch)
 }
 
+// SparseCheckoutSet mocks base method.
+func (m *MockGit) SparseCheckoutSet(worktreePath string, patterns []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SparseCheckoutSet", worktreePath, patterns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SparseCheckoutSet indicates an expected call of SparseCheckoutSet.
+func (mr *MockGitMockRecorder) SparseCheckoutSet(worktreePath, patterns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SparseCheckoutSet", reflect.TypeOf((*MockGit)(nil).SparseCheckoutSet), worktreePath, patterns)
+}
+
+// StashApply mocks base method.
+func (m *MockGit) StashApply(repoPath, stash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashApply", repoPath, stash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StashApply indicates an expected call of StashApply.
+func (mr *MockGitMockRecorder) StashApply(repoPath, stash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashApply", reflect.TypeOf((*MockGit)(nil).StashApply), repoPath, stash)
+}
+
+// StashDrop mocks base method.
+func (m *MockGit) StashDrop(repoPath, stash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashDrop", repoPath, stash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StashDrop indicates an expected call of StashDrop.
+func (mr *MockGitMockRecorder) StashDrop(repoPath, stash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashDrop", reflect.TypeOf((*MockGit)(nil).StashDrop), repoPath, stash)
+}
+
+// StashPush mocks base method.
+func (m *MockGit) StashPush(repoPath, message string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashPush", repoPath, message)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StashPush indicates an expected call of StashPush.
+func (mr *MockGitMockRecorder) StashPush(repoPath, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashPush", reflect.TypeOf((*MockGit)(nil).StashPush), repoPath, message)
+}
+
 // Status mocks base method.
 func (m *MockGit) Status(workDir string) (string, error) {
 	m.ctrl.T.Helper()
@@ -436,6 +747,20 @@ func (mr *MockGitMockRecorder) Status(workDir any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockGit)(nil).Status), workDir)
 }
 
+// UnlockWorktree mocks base method.
+func (m *MockGit) UnlockWorktree(repoPath, worktreePath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockWorktree", repoPath, worktreePath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockWorktree indicates an expected call of UnlockWorktree.
+func (mr *MockGitMockRecorder) UnlockWorktree(repoPath, worktreePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockWorktree", reflect.TypeOf((*MockGit)(nil).UnlockWorktree), repoPath, worktreePath)
+}
+
 // WorktreeExists mocks base method.
 func (m *MockGit) WorktreeExists(repoPath, branch string) (bool, error) {
 	m.ctrl.T.Helper()
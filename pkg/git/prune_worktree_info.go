@@ -0,0 +1,18 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// PruneWorktreeInfo removes the administrative files of worktrees whose directory is gone.
+func (g *realGit) PruneWorktreeInfo(repoPath string) error {
+	cmd := exec.Command("git", "worktree", "prune")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree prune failed: %w (command: git worktree prune, output: %s)",
+			err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Push pushes a branch to a remote, optionally setting it as the upstream branch.
+func (g *realGit) Push(params PushParams) error {
+	args := []string{"push"}
+	if params.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, params.RemoteName, params.Branch)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = params.RepoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git push failed: %w (command: git %v, output: %s)", err, args, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestGit_Push(t *testing.T) {
+	git := NewGit()
+	repoPath, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	// Create a bare repository to act as the remote
+	remotePath, err := os.MkdirTemp("", "git-push-remote-*")
+	if err != nil {
+		t.Fatalf("Failed to create remote directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(remotePath) }()
+
+	cmd := exec.Command("git", "init", "--bare", remotePath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to initialize bare repository: %v (output: %s)", err, string(output))
+	}
+	if err := git.AddRemote(repoPath, "local", remotePath); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+
+	// Push a new branch with upstream set
+	branchName := "test-push-branch"
+	if err := git.CreateBranch(repoPath, branchName); err != nil {
+		t.Fatalf("Expected no error creating branch: %v", err)
+	}
+	err = git.Push(PushParams{RepoPath: repoPath, RemoteName: "local", Branch: branchName, SetUpstream: true})
+	if err != nil {
+		t.Fatalf("Expected no error pushing branch: %v", err)
+	}
+
+	// Verify the branch exists on the remote
+	cmd = exec.Command("git", "show-ref", "--verify", "refs/heads/"+branchName)
+	cmd.Dir = remotePath
+	if err := cmd.Run(); err != nil {
+		t.Errorf("Expected branch %s to exist on remote: %v", branchName, err)
+	}
+
+	// Verify upstream tracking is configured
+	cmd = exec.Command("git", "config", "--get", "branch."+branchName+".remote")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		t.Errorf("Expected no error getting branch remote config: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "local" {
+		t.Errorf("Expected upstream remote to be local, got %s", strings.TrimSpace(string(output)))
+	}
+
+	// Pushing to a non-existent remote fails
+	err = git.Push(PushParams{RepoPath: repoPath, RemoteName: "non-existent-remote", Branch: branchName})
+	if err == nil {
+		t.Error("Expected error when pushing to non-existent remote")
+	}
+}
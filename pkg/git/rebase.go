@@ -0,0 +1,26 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Rebase rebases the current branch onto the given ref.
+// When the rebase stops on conflicts it is aborted, leaving the branch untouched, and ErrConflict is returned.
+func (g *realGit) Rebase(repoPath, onto string) error {
+	cmd := exec.Command("git", "rebase", onto)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	// Aborting only succeeds when the rebase stopped half-way
+	abortCmd := exec.Command("git", "rebase", "--abort")
+	abortCmd.Dir = repoPath
+	if abortErr := abortCmd.Run(); abortErr == nil {
+		return fmt.Errorf("%w: rebase onto %s aborted (output: %s)", ErrConflict, onto, string(output))
+	}
+
+	return fmt.Errorf("git rebase failed: %w (command: git rebase %s, output: %s)", err, onto, string(output))
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// runGitCommand runs a git command in the current directory and fails the test on error.
+func runGitCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v (output: %s)", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// commitFile writes a file and commits it in the current directory.
+func commitFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	runGitCommand(t, "add", name)
+	runGitCommand(t, "commit", "-m", "Update "+name)
+}
+
+// setupDivergedBranches creates a base and a feature branch that both changed shared.txt since they diverged,
+// with the given feature content, and leaves the feature branch checked out.
+func setupDivergedBranches(t *testing.T, featureContent string) {
+	t.Helper()
+	commitFile(t, "shared.txt", "initial\n")
+	runGitCommand(t, "branch", "base")
+	runGitCommand(t, "checkout", "-b", "feature")
+	commitFile(t, "feature.txt", "feature\n")
+	if featureContent != "" {
+		commitFile(t, "shared.txt", featureContent)
+	}
+	runGitCommand(t, "checkout", "base")
+	commitFile(t, "shared.txt", "base\n")
+	runGitCommand(t, "checkout", "feature")
+}
+
+func TestGit_Rebase(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "")
+
+	if err := git.Rebase(".", "base"); err != nil {
+		t.Fatalf("Expected no error rebasing onto base: %v", err)
+	}
+
+	isAncestor, err := git.IsAncestor(".", "base", "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error checking ancestry: %v", err)
+	}
+	if !isAncestor {
+		t.Error("Expected base to be an ancestor of the rebased branch")
+	}
+}
+
+func TestGit_Rebase_Conflict(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	setupDivergedBranches(t, "feature\n")
+	head := runGitCommand(t, "rev-parse", "HEAD")
+
+	err := git.Rebase(".", "base")
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got: %v", err)
+	}
+
+	// The rebase is aborted, leaving the branch where it was
+	if current := runGitCommand(t, "rev-parse", "HEAD"); current != head {
+		t.Errorf("Expected HEAD to stay at %s, got: %s", head, current)
+	}
+	if branch := runGitCommand(t, "branch", "--show-current"); branch != "feature" {
+		t.Errorf("Expected feature branch to be checked out, got: %q", branch)
+	}
+	if isClean, _ := git.IsClean("."); !isClean {
+		t.Error("Expected a clean working tree after the aborted rebase")
+	}
+}
+
+func TestGit_Rebase_UnknownRef(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	err := git.Rebase(".", "non-existent-ref-12345")
+	if err == nil {
+		t.Fatal("Expected error rebasing onto an unknown ref")
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Errorf("Expected an unknown ref not to be reported as a conflict: %v", err)
+	}
+}
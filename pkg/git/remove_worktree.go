@@ -7,10 +7,12 @@ import (
 )
 
 // RemoveWorktree removes a worktree from Git's tracking.
+// When forced, worktrees with uncommitted changes and locked worktrees are removed as well.
 func (g *realGit) RemoveWorktree(repoPath, worktreePath string, force bool) error {
 	args := []string{"worktree", "remove"}
 	if force {
-		args = append(args, "--force")
+		// Giving --force twice is required to remove locked worktrees
+		args = append(args, "--force", "--force")
 	}
 	args = append(args, worktreePath)
 
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ResolveRef resolves a branch, tag, remote branch or commit SHA to the commit it points to.
+func (g *realGit) ResolveRef(repoPath, ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", fmt.Errorf("%w: %s", ErrReferenceNotFound, ref)
+		}
+		return "", fmt.Errorf("git rev-parse failed: %w (command: git rev-parse --verify %s^{commit})", err, ref)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,43 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestGit_ResolveRef(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	head, err := git.ResolveRef(".", "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error resolving HEAD: %v", err)
+	}
+	if len(head) != 40 {
+		t.Errorf("Expected a full commit SHA, got: %q", head)
+	}
+
+	// Tags and abbreviated SHAs resolve to the same commit
+	if output, err := exec.Command("git", "tag", "v1.0.0").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to create tag: %v (output: %s)", err, output)
+	}
+	for _, ref := range []string{"v1.0.0", head[:7]} {
+		sha, err := git.ResolveRef(".", ref)
+		if err != nil {
+			t.Errorf("Expected no error resolving %s: %v", ref, err)
+		}
+		if sha != head {
+			t.Errorf("Expected %s to resolve to %s, got: %s", ref, head, sha)
+		}
+	}
+
+	// Unknown references are reported as not found
+	_, err = git.ResolveRef(".", "non-existent-ref-12345")
+	if !errors.Is(err, ErrReferenceNotFound) {
+		t.Errorf("Expected ErrReferenceNotFound, got: %v", err)
+	}
+}
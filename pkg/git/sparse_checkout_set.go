@@ -0,0 +1,23 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SparseCheckoutSet restricts the working tree of a worktree to the given directories (cone mode).
+// The patterns are stored in the configuration of the worktree only, other worktrees are not affected.
+// Called before the branch is checked out, it avoids writing the files outside of the directories.
+func (g *realGit) SparseCheckoutSet(worktreePath string, patterns []string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, patterns...)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git sparse-checkout set failed: %w (command: git %s, output: %s)",
+			err, strings.Join(args, " "), string(output))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_SparseCheckoutSet(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	for _, dir := range []string{"api", "web", "docs"} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		commitFile(t, filepath.Join(dir, "file.txt"), dir+"\n")
+	}
+	runGitCommand(t, "branch", "feature")
+
+	// Sparse checkout is set up between the creation of the worktree and the checkout
+	worktreePath := filepath.Join(tmpDir, "worktrees", "feature")
+	if err := git.CreateWorktreeWithNoCheckout(".", worktreePath, "feature"); err != nil {
+		t.Fatalf("Expected no error creating worktree: %v", err)
+	}
+	if err := git.SparseCheckoutSet(worktreePath, []string{"api", "web"}); err != nil {
+		t.Fatalf("Expected no error setting sparse checkout: %v", err)
+	}
+	if err := git.CheckoutBranch(worktreePath, "feature"); err != nil {
+		t.Fatalf("Expected no error checking out branch: %v", err)
+	}
+
+	for _, dir := range []string{"api", "web"} {
+		if _, err := os.Stat(filepath.Join(worktreePath, dir, "file.txt")); err != nil {
+			t.Errorf("Expected %s to be checked out: %v", dir, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "docs")); !os.IsNotExist(err) {
+		t.Errorf("Expected docs not to be checked out")
+	}
+
+	// The repository itself keeps its full working tree
+	if _, err := os.Stat(filepath.Join(tmpDir, "docs", "file.txt")); err != nil {
+		t.Errorf("Expected docs to stay in the repository: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// StashApply applies the stash in the repository, restoring staged changes to the index.
+// The stash is kept, so that it can be applied elsewhere when this fails. Conflicts return ErrConflict.
+func (g *realGit) StashApply(repoPath, stash string) error {
+	cmd := exec.Command("git", "stash", "apply", "--index", stash)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	conflict := strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "conflicts in index")
+	if err != nil && conflict {
+		return fmt.Errorf("%w: stash %s does not apply cleanly (output: %s)", ErrConflict, stash, string(output))
+	}
+	if err != nil {
+		return fmt.Errorf("git stash apply failed: %w (command: git stash apply --index %s, output: %s)",
+			err, stash, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_StashApply(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	commitFile(t, "tracked.txt", "initial\n")
+	if err := os.WriteFile("tracked.txt", []byte("staged\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify tracked file: %v", err)
+	}
+	runGitCommand(t, "add", "tracked.txt")
+	if err := os.WriteFile("untracked.txt", []byte("untracked\n"), 0644); err != nil {
+		t.Fatalf("Failed to create untracked file: %v", err)
+	}
+	runGitCommand(t, "stash", "push", "--include-untracked")
+	stash := runGitCommand(t, "rev-parse", "stash@{0}")
+
+	// Stashes are shared with the other worktrees of the repository
+	worktreePath := filepath.Join(tmpDir, "worktrees", "feature")
+	runGitCommand(t, "worktree", "add", "-b", "feature", worktreePath)
+	if err := git.StashApply(worktreePath, stash); err != nil {
+		t.Fatalf("Expected no error applying the stash: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(worktreePath, "untracked.txt"))
+	if err != nil || string(content) != "untracked\n" {
+		t.Errorf("Expected the untracked file in the worktree, got: %q (%v)", content, err)
+	}
+	if err := os.Chdir(worktreePath); err != nil {
+		t.Fatalf("Failed to change to worktree: %v", err)
+	}
+	if staged := runGitCommand(t, "diff", "--cached", "--name-only"); staged != "tracked.txt" {
+		t.Errorf("Expected tracked.txt to stay staged, got: %q", staged)
+	}
+
+	// The stash is kept
+	if runGitCommand(t, "rev-parse", "stash@{0}") != stash {
+		t.Error("Expected the stash to be kept after applying it")
+	}
+
+	// Applying on top of conflicting commits fails
+	runGitCommand(t, "reset", "--hard")
+	runGitCommand(t, "clean", "-fd")
+	commitFile(t, "tracked.txt", "conflict\n")
+	if err := git.StashApply(".", stash); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict applying the stash over conflicting commits, got: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// StashDrop removes the stash with the given commit from the stash list.
+func (g *realGit) StashDrop(repoPath, stash string) error {
+	// Stashes can only be dropped through their position in the stash list
+	list, err := g.runForOutput(repoPath, "stash", "list", "--format=%H")
+	if err != nil {
+		return err
+	}
+
+	for i, commit := range strings.Fields(list) {
+		if commit != stash {
+			continue
+		}
+		entry := fmt.Sprintf("stash@{%d}", i)
+		cmd := exec.Command("git", "stash", "drop", entry)
+		cmd.Dir = repoPath
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("git stash drop failed: %w (command: git stash drop %s, output: %s)",
+				err, entry, string(output))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: stash %s", ErrStashNotFound, stash)
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package git
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGit_StashDrop(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	var stashes []string
+	for _, name := range []string{"first.txt", "second.txt"} {
+		if err := os.WriteFile(name, []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		runGitCommand(t, "stash", "push", "--include-untracked")
+		stashes = append(stashes, runGitCommand(t, "rev-parse", "stash@{0}"))
+	}
+
+	// The first stash is no longer at the top of the stash list
+	if err := git.StashDrop(".", stashes[0]); err != nil {
+		t.Fatalf("Expected no error dropping the stash: %v", err)
+	}
+	if list := runGitCommand(t, "stash", "list", "--format=%H"); list != stashes[1] {
+		t.Errorf("Expected only the second stash to remain, got: %s", list)
+	}
+
+	if err := git.StashDrop(".", stashes[0]); !errors.Is(err, ErrStashNotFound) {
+		t.Errorf("Expected ErrStashNotFound dropping a dropped stash, got: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// StashPush stashes the staged, unstaged and untracked changes of the repository
+// and returns the commit of the stash, which stays valid when other stashes are pushed.
+func (g *realGit) StashPush(repoPath, message string) (string, error) {
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git stash push failed: %w (command: git stash push --include-untracked -m %s, output: %s)",
+			err, message, string(output))
+	}
+
+	return g.runForOutput(repoPath, "rev-parse", "--verify", "refs/stash")
+}
@@ -0,0 +1,35 @@
+//go:build integration
+
+package git
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGit_StashPush(t *testing.T) {
+	git := NewGit()
+	_, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	commitFile(t, "tracked.txt", "initial\n")
+	if err := os.WriteFile("tracked.txt", []byte("modified\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify tracked file: %v", err)
+	}
+	if err := os.WriteFile("untracked.txt", []byte("untracked\n"), 0644); err != nil {
+		t.Fatalf("Failed to create untracked file: %v", err)
+	}
+
+	stash, err := git.StashPush(".", "carry changes")
+	if err != nil {
+		t.Fatalf("Expected no error stashing changes: %v", err)
+	}
+	if stash != runGitCommand(t, "rev-parse", "stash@{0}") {
+		t.Errorf("Expected the stash commit to be returned, got: %s", stash)
+	}
+
+	// Both tracked and untracked changes are stashed
+	if output := runGitCommand(t, "status", "--porcelain"); output != "" {
+		t.Errorf("Expected a clean repository after stashing, got: %s", output)
+	}
+}
@@ -1,5 +1,7 @@
 package git
 
+import "time"
+
 // BranchExistsOnRemoteParams contains parameters for BranchExistsOnRemote.
 type BranchExistsOnRemoteParams struct {
 	RepoPath   string
@@ -19,4 +21,57 @@ type CloneParams struct {
 	RepoURL    string
 	TargetPath string
 	Recursive  bool
+	NoCheckout bool // Clone without checking out the default branch
+}
+
+// CredentialFillParams contains parameters for CredentialFill.
+type CredentialFillParams struct {
+	Protocol string // Protocol of the host (defaults to https)
+	Host     string
+}
+
+// Credential represents credentials returned by a Git credential helper.
+type Credential struct {
+	Username string
+	Password string
+}
+
+// PushParams contains parameters for Push.
+type PushParams struct {
+	RepoPath    string
+	RemoteName  string
+	Branch      string
+	SetUpstream bool
+}
+
+// WorkingTreeStatus summarizes the changes of a working tree and its position against its upstream.
+type WorkingTreeStatus struct {
+	Staged    int    // Files with staged changes
+	Modified  int    // Files with unstaged changes
+	Untracked int    // Untracked files
+	Upstream  string // Upstream branch, empty when the branch has none
+	Ahead     int    // Commits ahead of the upstream
+	Behind    int    // Commits behind the upstream
+}
+
+// HasChanges reports whether the working tree has staged, unstaged or untracked changes.
+func (s WorkingTreeStatus) HasChanges() bool {
+	return s.Staged+s.Modified+s.Untracked > 0
+}
+
+// CommitInfo describes a commit.
+type CommitInfo struct {
+	Subject string
+	Date    time.Time
+}
+
+// WorktreeEntry describes a worktree as listed by `git worktree list --porcelain`.
+type WorktreeEntry struct {
+	Path     string
+	Head     string
+	Branch   string // Branch checked out, empty when detached
+	Bare     bool
+	Detached bool
+	Locked   bool
+	Prunable bool // The worktree directory is gone and its administrative files can be pruned
 }
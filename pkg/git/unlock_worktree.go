@@ -0,0 +1,18 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// UnlockWorktree unlocks a worktree locked with LockWorktree.
+func (g *realGit) UnlockWorktree(repoPath, worktreePath string) error {
+	cmd := exec.Command("git", "worktree", "unlock", worktreePath)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree unlock failed: %w (command: git worktree unlock %s, output: %s)",
+			err, worktreePath, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build integration
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGit_UnlockWorktree(t *testing.T) {
+	git := NewGit()
+	tmpDir, cleanup := SetupTestRepo(t)
+	defer cleanup()
+
+	worktreePath := filepath.Join(tmpDir, "worktrees", "release")
+	runGitCommand(t, "worktree", "add", "-b", "release", worktreePath)
+
+	// Unlocking a worktree that is not locked fails
+	if err := git.UnlockWorktree(".", worktreePath); err == nil {
+		t.Error("Expected an error unlocking a worktree that is not locked")
+	}
+
+	if err := git.LockWorktree(".", worktreePath, ""); err != nil {
+		t.Fatalf("Expected no error locking worktree: %v", err)
+	}
+	if err := git.UnlockWorktree(".", worktreePath); err != nil {
+		t.Fatalf("Expected no error unlocking worktree: %v", err)
+	}
+
+	// Unlocked worktrees are removed without force
+	if err := git.RemoveWorktree(".", worktreePath, false); err != nil {
+		t.Errorf("Expected no error removing the unlocked worktree: %v", err)
+	}
+}
@@ -139,3 +139,14 @@ func (g *realGit) findRemoteFromBranchList(repoPath, branch string) (string, err
 	// If we can't find the remote, return "origin" as default
 	return defaultRemote, nil
 }
+
+// runForOutput runs a git command and returns its trimmed output.
+func (g *realGit) runForOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w (command: git %s)", args[0], err, strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
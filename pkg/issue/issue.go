@@ -1,15 +1,60 @@
 // Package issue provides data structures and error types for handling forge issues.
 package issue
 
-// Info represents information about a forge issue.
+import (
+	"strconv"
+	"strings"
+)
+
+// Issue states, normalized across forges.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// Info represents information about a forge or issue tracker issue.
 type Info struct {
-	Number      int    `yaml:"number"`
-	Title       string `yaml:"title"`
-	Description string `yaml:"description,omitempty"`
-	State       string `yaml:"state,omitempty"`
-	URL         string `yaml:"url,omitempty"`
-	Repository  string `yaml:"repository,omitempty"`
-	Owner       string `yaml:"owner,omitempty"`
+	Number      int      `yaml:"number" json:"number"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	State       string   `yaml:"state,omitempty" json:"state,omitempty"`
+	URL         string   `yaml:"url,omitempty" json:"url,omitempty"`
+	Repository  string   `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Owner       string   `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Labels      []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Assignees   []string `yaml:"assignees,omitempty" json:"assignees,omitempty"`
+	Milestone   string   `yaml:"milestone,omitempty" json:"milestone,omitempty"`
+	// LinkedPullRequests are the pull requests referencing the issue on its forge
+	LinkedPullRequests []LinkedPullRequest `yaml:"linked_pull_requests,omitempty" json:"linked_pull_requests,omitempty"`
+	// Key is the issue tracker key (e.g. PROJ-123), empty for forge issues
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+	// Stale is set when the forge was unreachable and the information comes from the issue cache
+	Stale bool `yaml:"-" json:"stale,omitempty"`
+}
+
+// LinkedPullRequest represents a pull request linked to an issue.
+type LinkedPullRequest struct {
+	Number int    `yaml:"number" json:"number"`
+	URL    string `yaml:"url,omitempty" json:"url,omitempty"`
+	State  string `yaml:"state,omitempty" json:"state,omitempty"`
+}
+
+// HasLabel returns true when the issue has the label, compared case-insensitively.
+func (i Info) HasLabel(label string) bool {
+	for _, l := range i.Labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayID returns the identifier of the issue for display: its issue tracker key, or its number.
+func (i Info) DisplayID() string {
+	if i.Key != "" {
+		return i.Key
+	}
+	return "#" + strconv.Itoa(i.Number)
 }
 
 // Reference represents a parsed issue reference.
@@ -18,4 +63,5 @@ type Reference struct {
 	Repository  string
 	IssueNumber int
 	URL         string
+	Key         string // Issue tracker key (e.g. PROJ-123), empty for forge issues
 }
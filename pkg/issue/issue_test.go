@@ -40,6 +40,11 @@ func TestIssueReference_Fields(t *testing.T) {
 	assert.Equal(t, "https://github.com/octocat/Hello-World/issues/123", ref.URL)
 }
 
+func TestIssueInfo_DisplayID(t *testing.T) {
+	assert.Equal(t, "#123", Info{Number: 123}.DisplayID())
+	assert.Equal(t, "PROJ-123", Info{Number: 123, Key: "PROJ-123"}.DisplayID())
+}
+
 func TestErrorTypes(t *testing.T) {
 	assert.Equal(t, "issue not found", ErrIssueNotFound.Error())
 	assert.Equal(t, "issue is closed, only open issues are supported", ErrIssueClosed.Error())
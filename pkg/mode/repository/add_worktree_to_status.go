@@ -35,7 +35,13 @@ func (r *realRepository) AddWorktreeToStatus(params StatusParams) error {
 		WorkspacePath: params.WorkspacePath,
 		Remote:        params.Remote,
 		IssueInfo:     params.IssueInfo,
+		PullRequest:   params.PullRequest,
 		Detached:      params.Detached,
+		BaseRef:       params.BaseRef,
+		Ephemeral:     params.Ephemeral,
+		Sparse:        params.Sparse,
+		DetachedHead:  params.DetachedHead,
+		Commit:        params.Commit,
 	}); err != nil {
 		return r.handleStatusAddError(err, params)
 	}
@@ -89,7 +95,13 @@ func (r *realRepository) handleRepositoryNotFoundError(params StatusParams) erro
 		WorkspacePath: params.WorkspacePath,
 		Remote:        params.Remote,
 		IssueInfo:     params.IssueInfo,
+		PullRequest:   params.PullRequest,
 		Detached:      params.Detached,
+		BaseRef:       params.BaseRef,
+		Ephemeral:     params.Ephemeral,
+		Sparse:        params.Sparse,
+		DetachedHead:  params.DetachedHead,
+		Commit:        params.Commit,
 	}); err != nil {
 		// Clean up created directory on status update failure
 		r.cleanupWorktreeDirectory(params.WorktreePath)
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/worktree"
+)
+
+// carryChangesParams contains parameters for carryChanges.
+type carryChangesParams struct {
+	RepoURL      string
+	Branch       string
+	RepoPath     string
+	WorktreePath string
+	NewBranch    bool // The branch was created with the worktree, and is deleted with it on failure
+}
+
+// carryChanges moves the uncommitted changes of the repository into the new worktree through a stash.
+// The worktree is already checked out and in the status, so when the changes cannot be stashed or applied,
+// the worktree is deleted along with its status entry and new branch, and the changes are restored in the
+// repository. The stash is only dropped once applied, so that the changes are never lost.
+func (r *realRepository) carryChanges(worktreeInstance worktree.Worktree, params carryChangesParams) error {
+	changes, err := r.deps.Git.GetWorkingTreeStatus(params.RepoPath)
+	if err != nil {
+		return r.rollbackCarriedWorktree(worktreeInstance, params, fmt.Errorf("failed to get changes: %w", err))
+	}
+	if !changes.HasChanges() {
+		r.deps.Logger.Logf("No uncommitted changes to carry into worktree %s", params.Branch)
+		return nil
+	}
+
+	stash, err := r.deps.Git.StashPush(params.RepoPath, "cm: carry changes to "+params.Branch)
+	if err != nil {
+		return r.rollbackCarriedWorktree(worktreeInstance, params, fmt.Errorf("failed to stash changes: %w", err))
+	}
+	r.deps.Logger.Logf("Stashed uncommitted changes as %s", stash)
+
+	if err := r.deps.Git.StashApply(params.WorktreePath, stash); err != nil {
+		applyErr := r.rollbackCarriedWorktree(worktreeInstance, params,
+			fmt.Errorf("failed to apply changes in the new worktree: %w", err))
+		if restoreErr := r.deps.Git.StashApply(params.RepoPath, stash); restoreErr != nil {
+			return fmt.Errorf("%w; changes could not be restored and are kept in stash %s: %w",
+				applyErr, stash, restoreErr)
+		}
+		r.dropCarriedStash(params.RepoPath, stash)
+		return applyErr
+	}
+
+	r.dropCarriedStash(params.RepoPath, stash)
+	r.deps.Logger.Logf("Carried uncommitted changes into worktree %s", params.Branch)
+	return nil
+}
+
+// rollbackCarriedWorktree deletes the new worktree, its status entry and its branch when it was created with it,
+// after the changes failed to be carried into it, so that the creation can be retried.
+func (r *realRepository) rollbackCarriedWorktree(
+	worktreeInstance worktree.Worktree, params carryChangesParams, carryErr error,
+) error {
+	if err := worktreeInstance.Delete(worktree.DeleteParams{
+		RepoURL:      params.RepoURL,
+		Branch:       params.Branch,
+		WorktreePath: params.WorktreePath,
+		RepoPath:     params.RepoPath,
+		Force:        true,
+	}); err != nil {
+		r.deps.Logger.Logf("Warning: failed to delete worktree after failing to carry changes: %v", err)
+	}
+	if params.NewBranch {
+		if err := r.deps.Git.DeleteBranch(params.RepoPath, params.Branch); err != nil {
+			r.deps.Logger.Logf("Warning: failed to delete branch %s after failing to carry changes: %v", params.Branch, err)
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrCarryChangesFailed, carryErr)
+}
+
+// isNewLocalBranch checks that the branch does not exist locally yet, and is created with the worktree.
+func (r *realRepository) isNewLocalBranch(repoPath, branch string) (bool, error) {
+	_, err := r.deps.Git.ResolveRef(repoPath, "refs/heads/"+branch)
+	if errors.Is(err, git.ErrReferenceNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check if branch %s exists: %w", branch, err)
+	}
+	return false, nil
+}
+
+// dropCarriedStash drops the stash once its changes were applied, keeping it when this fails.
+func (r *realRepository) dropCarriedStash(repoPath, stash string) {
+	if err := r.deps.Git.StashDrop(repoPath, stash); err != nil {
+		r.deps.Logger.Logf("Warning: failed to drop stash %s: %v", stash, err)
+	}
+}
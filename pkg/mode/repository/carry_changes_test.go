@@ -0,0 +1,136 @@
+//go:build unit
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/worktree"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const carryWorktreePath = "/test/repos/github.com/test/repo/origin/feature"
+
+// expectCarryWorktreeCreation expects the feature worktree to be created and added to the status,
+// up to carrying the changes into it.
+func expectCarryWorktreeCreation(mocks testMocks) {
+	expectValidRepository(mocks)
+	mocks.status.EXPECT().GetWorktree("github.com/test/repo", "feature").Return(nil, status.ErrWorktreeNotFound)
+	mocks.git.EXPECT().IsClean("/test/repo").Return(true, nil)
+	mocks.worktree.EXPECT().BuildPath("github.com/test/repo", "origin", "feature").Return(carryWorktreePath)
+	mocks.worktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+	mocks.worktree.EXPECT().Create(gomock.Any()).Return(nil)
+	mocks.worktree.EXPECT().CheckoutBranch(carryWorktreePath, "feature").Return(nil)
+	mocks.git.EXPECT().SetUpstreamBranch(carryWorktreePath, "origin", "feature").Return(nil)
+	mocks.worktree.EXPECT().AddToStatus(gomock.Any()).Return(nil)
+}
+
+// expectNewFeatureBranch expects the feature branch not to exist yet, so that it is created with the worktree.
+func expectNewFeatureBranch(mocks testMocks) {
+	mocks.git.EXPECT().ResolveRef("/test/repo", "refs/heads/feature").
+		Return("", fmt.Errorf("%w: refs/heads/feature", git.ErrReferenceNotFound))
+}
+
+func TestCreateWorktree_CarryChanges(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectCarryWorktreeCreation(mocks)
+	expectNewFeatureBranch(mocks)
+
+	// The changes are stashed in the repository, applied in the worktree, then dropped
+	mocks.git.EXPECT().GetWorkingTreeStatus("/test/repo").Return(&git.WorkingTreeStatus{Modified: 1, Untracked: 2}, nil)
+	gomock.InOrder(
+		mocks.git.EXPECT().StashPush("/test/repo", "cm: carry changes to feature").Return("abc123", nil),
+		mocks.git.EXPECT().StashApply(carryWorktreePath, "abc123").Return(nil),
+		mocks.git.EXPECT().StashDrop("/test/repo", "abc123").Return(nil),
+	)
+	mocks.worktree.EXPECT().Delete(gomock.Any()).Times(0)
+	mocks.git.EXPECT().DeleteBranch(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := repository.CreateWorktree("feature", CreateWorktreeOpts{CarryChanges: true})
+	assert.NoError(t, err)
+	assert.Equal(t, carryWorktreePath, result)
+}
+
+func TestCreateWorktree_CarryChanges_NoChanges(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectCarryWorktreeCreation(mocks)
+	expectNewFeatureBranch(mocks)
+
+	mocks.git.EXPECT().GetWorkingTreeStatus("/test/repo").Return(&git.WorkingTreeStatus{}, nil)
+	mocks.git.EXPECT().StashPush(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := repository.CreateWorktree("feature", CreateWorktreeOpts{CarryChanges: true})
+	assert.NoError(t, err)
+}
+
+func TestCreateWorktree_CarryChanges_StashFailure(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectCarryWorktreeCreation(mocks)
+	expectNewFeatureBranch(mocks)
+
+	// The worktree, its status entry and the branch created with it are deleted, the changes stay in place
+	mocks.git.EXPECT().GetWorkingTreeStatus("/test/repo").Return(&git.WorkingTreeStatus{Modified: 1}, nil)
+	gomock.InOrder(
+		mocks.git.EXPECT().StashPush("/test/repo", gomock.Any()).Return("", errors.New("stash failed")),
+		mocks.worktree.EXPECT().Delete(worktree.DeleteParams{
+			RepoURL:      "github.com/test/repo",
+			Branch:       "feature",
+			WorktreePath: carryWorktreePath,
+			RepoPath:     "/test/repo",
+			Force:        true,
+		}).Return(nil),
+		mocks.git.EXPECT().DeleteBranch("/test/repo", "feature").Return(nil),
+	)
+	mocks.git.EXPECT().StashApply(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := repository.CreateWorktree("feature", CreateWorktreeOpts{CarryChanges: true})
+	assert.ErrorIs(t, err, ErrCarryChangesFailed)
+	assert.ErrorContains(t, err, "failed to stash changes")
+}
+
+func TestCreateWorktree_CarryChanges_Conflict(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectCarryWorktreeCreation(mocks)
+	expectNewFeatureBranch(mocks)
+
+	// The worktree and its new branch are deleted, and the changes go back to the repository
+	// before the stash is dropped
+	mocks.git.EXPECT().GetWorkingTreeStatus("/test/repo").Return(&git.WorkingTreeStatus{Staged: 1}, nil)
+	gomock.InOrder(
+		mocks.git.EXPECT().StashPush("/test/repo", gomock.Any()).Return("abc123", nil),
+		mocks.git.EXPECT().StashApply(carryWorktreePath, "abc123").Return(errors.New("conflict")),
+		mocks.worktree.EXPECT().Delete(gomock.Any()).Return(nil),
+		mocks.git.EXPECT().DeleteBranch("/test/repo", "feature").Return(nil),
+		mocks.git.EXPECT().StashApply("/test/repo", "abc123").Return(nil),
+		mocks.git.EXPECT().StashDrop("/test/repo", "abc123").Return(nil),
+	)
+
+	_, err := repository.CreateWorktree("feature", CreateWorktreeOpts{CarryChanges: true})
+	assert.ErrorIs(t, err, ErrCarryChangesFailed)
+}
+
+func TestCreateWorktree_CarryChanges_RestoreFailure(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectCarryWorktreeCreation(mocks)
+
+	// A branch that existed before is kept
+	mocks.git.EXPECT().ResolveRef("/test/repo", "refs/heads/feature").Return("1a2b3c4d5e6f", nil)
+	mocks.git.EXPECT().DeleteBranch(gomock.Any(), gomock.Any()).Times(0)
+
+	// The stash is kept when the changes cannot be restored either
+	mocks.git.EXPECT().GetWorkingTreeStatus("/test/repo").Return(&git.WorkingTreeStatus{Modified: 1}, nil)
+	mocks.git.EXPECT().StashPush("/test/repo", gomock.Any()).Return("abc123", nil)
+	mocks.git.EXPECT().StashApply(carryWorktreePath, "abc123").Return(errors.New("conflict"))
+	mocks.worktree.EXPECT().Delete(gomock.Any()).Return(nil)
+	mocks.git.EXPECT().StashApply("/test/repo", "abc123").Return(errors.New("conflict"))
+	mocks.git.EXPECT().StashDrop(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := repository.CreateWorktree("feature", CreateWorktreeOpts{CarryChanges: true})
+	assert.ErrorIs(t, err, ErrCarryChangesFailed)
+	assert.ErrorContains(t, err, "kept in stash abc123")
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/git"
+)
+
+// shortSHALength is the length of the commit SHAs naming detached worktrees not checked out on a tag.
+const shortSHALength = 7
+
+// CreateDetachedWorktree creates a worktree with its HEAD detached at a tag or commit, named after
+// the tag or the short commit SHA.
+func (r *realRepository) CreateDetachedWorktree(ref string, opts ...CreateDetachedWorktreeOpts) (string, error) {
+	r.deps.Logger.Logf("Creating detached worktree for single repository at ref: %s", ref)
+
+	// Validate repository
+	validationResult, err := r.ValidateRepository(ValidationParams{})
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve the ref before anything is created, so that unknown refs fail early
+	commit, name, err := r.resolveDetachedRef(validationResult.RepoPath, ref)
+	if err != nil {
+		return "", err
+	}
+
+	// Create and validate worktree instance
+	worktreeInstance, worktreePath, err := r.createAndValidateWorktreeInstance(
+		validationResult.RepoURL, name, DetachedRemote,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.deps.Git.CreateDetachedWorktree(validationResult.RepoPath, worktreePath, commit); err != nil {
+		r.cleanupWorktreeOnError(worktreeInstance, worktreePath, "creation failure")
+		return "", fmt.Errorf("failed to create detached worktree: %w", err)
+	}
+
+	// Add to status file with auto-repository handling
+	var options CreateDetachedWorktreeOpts
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if err := r.addWorktreeToStatusAndHandleCleanup(worktreeInstance, StatusParams{
+		RepoURL:      validationResult.RepoURL,
+		Branch:       name,
+		WorktreePath: worktreePath,
+		Remote:       DetachedRemote,
+		DetachedHead: true,
+		Commit:       commit,
+		Ephemeral:    options.Ephemeral,
+	}); err != nil {
+		return "", err
+	}
+
+	r.deps.Logger.Logf("Successfully created detached worktree for %s at %s", ref, worktreePath)
+
+	return worktreePath, nil
+}
+
+// resolveDetachedRef returns the commit the ref points to, and the name of its worktree:
+// the tag name for tags, the short commit SHA otherwise.
+func (r *realRepository) resolveDetachedRef(repoPath, ref string) (string, string, error) {
+	commit, err := r.deps.Git.ResolveRef(repoPath, ref)
+	if errors.Is(err, git.ErrReferenceNotFound) {
+		return "", "", fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve ref %s: %w", ref, err)
+	}
+
+	if _, err := r.deps.Git.ResolveRef(repoPath, "refs/tags/"+ref); err == nil {
+		return commit, ref, nil
+	}
+	if len(commit) > shortSHALength {
+		return commit, commit[:shortSHALength], nil
+	}
+	return commit, commit, nil
+}
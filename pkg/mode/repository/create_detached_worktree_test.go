@@ -0,0 +1,76 @@
+//go:build unit
+
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/git"
+	"github.com/lerenn/code-manager/pkg/status"
+	"github.com/lerenn/code-manager/pkg/worktree"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateDetachedWorktree_Tag(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectValidRepository(mocks)
+	worktreePath := "/test/repos/github.com/test/repo/detached/v2.3.1"
+	ephemeral := &status.Ephemeral{TTL: 4 * time.Hour}
+
+	// Tags name the worktree
+	mocks.git.EXPECT().ResolveRef("/test/repo", "v2.3.1").Return("1a2b3c4d5e6f", nil)
+	mocks.git.EXPECT().ResolveRef("/test/repo", "refs/tags/v2.3.1").Return("1a2b3c4d5e6f", nil)
+	mocks.worktree.EXPECT().BuildPath("github.com/test/repo", DetachedRemote, "v2.3.1").Return(worktreePath)
+	mocks.worktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+	mocks.git.EXPECT().CreateDetachedWorktree("/test/repo", worktreePath, "1a2b3c4d5e6f").Return(nil)
+	mocks.worktree.EXPECT().AddToStatus(worktree.AddToStatusParams{
+		RepoURL:      "github.com/test/repo",
+		Branch:       "v2.3.1",
+		WorktreePath: worktreePath,
+		Remote:       DetachedRemote,
+		DetachedHead: true,
+		Commit:       "1a2b3c4d5e6f",
+		Ephemeral:    ephemeral,
+	}).Return(nil)
+
+	result, err := repository.CreateDetachedWorktree("v2.3.1", CreateDetachedWorktreeOpts{Ephemeral: ephemeral})
+	assert.NoError(t, err)
+	assert.Equal(t, worktreePath, result)
+}
+
+func TestCreateDetachedWorktree_Commit(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectValidRepository(mocks)
+	worktreePath := "/test/repos/github.com/test/repo/detached/1a2b3c4"
+
+	// Other refs are named after the short commit SHA
+	mocks.git.EXPECT().ResolveRef("/test/repo", "HEAD~2").Return("1a2b3c4d5e6f", nil)
+	mocks.git.EXPECT().ResolveRef("/test/repo", "refs/tags/HEAD~2").
+		Return("", fmt.Errorf("%w: refs/tags/HEAD~2", git.ErrReferenceNotFound))
+	mocks.worktree.EXPECT().BuildPath("github.com/test/repo", DetachedRemote, "1a2b3c4").Return(worktreePath)
+	mocks.worktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+	mocks.git.EXPECT().CreateDetachedWorktree("/test/repo", worktreePath, "1a2b3c4d5e6f").Return(nil)
+	mocks.worktree.EXPECT().AddToStatus(gomock.Any()).DoAndReturn(func(params worktree.AddToStatusParams) error {
+		assert.Equal(t, "1a2b3c4", params.Branch)
+		assert.True(t, params.DetachedHead)
+		return nil
+	})
+
+	result, err := repository.CreateDetachedWorktree("HEAD~2")
+	assert.NoError(t, err)
+	assert.Equal(t, worktreePath, result)
+}
+
+func TestCreateDetachedWorktree_UnknownRef(t *testing.T) {
+	repository, mocks := newTestRepository(t)
+	expectValidRepository(mocks)
+
+	mocks.git.EXPECT().ResolveRef("/test/repo", "v9.9.9").
+		Return("", fmt.Errorf("%w: v9.9.9", git.ErrReferenceNotFound))
+
+	_, err := repository.CreateDetachedWorktree("v9.9.9")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/lerenn/code-manager/pkg/hooks"
 	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
 	"github.com/lerenn/code-manager/pkg/worktree"
 )
 
@@ -28,6 +30,12 @@ func (r *realRepository) CreateWorktree(branch string, opts ...CreateWorktreeOpt
 		return "", err
 	}
 
+	// Get the directories to check out, if restricted
+	sparse, err := r.resolveSparse(validationResult.RepoURL, opts)
+	if err != nil {
+		return "", err
+	}
+
 	// Get current directory
 	currentDir, err := filepath.Abs(r.repositoryPath)
 	if err != nil {
@@ -37,6 +45,12 @@ func (r *realRepository) CreateWorktree(branch string, opts ...CreateWorktreeOpt
 	// Get issue info from options
 	issueInfo := r.extractIssueInfo(opts)
 
+	// Resolve the base ref before anything is created, so that unknown refs fail early
+	baseRef, err := r.resolveBaseRef(worktreeInstance, currentDir, opts)
+	if err != nil {
+		return "", err
+	}
+
 	// Execute pre-worktree creation hooks (for devcontainer detection, etc.)
 	detached, err := r.executePreWorktreeCreationHooks(
 		validationResult.RepoURL, branch, worktreePath, currentDir, remote, issueInfo,
@@ -45,10 +59,32 @@ func (r *realRepository) CreateWorktree(branch string, opts ...CreateWorktreeOpt
 		return "", err
 	}
 
+	// Stashes are shared by worktrees only, standalone clones cannot receive them
+	carryChanges := len(opts) > 0 && opts[0].CarryChanges
+	if carryChanges && detached {
+		return "", ErrCarryChangesNotSupported
+	}
+
+	// Remember whether the branch is created with the worktree, so that it is deleted too if the carry fails
+	newBranch := false
+	if carryChanges {
+		if newBranch, err = r.isNewLocalBranch(currentDir, branch); err != nil {
+			return "", err
+		}
+	}
+
 	// Create the worktree (with --no-checkout)
-	if err := r.createWorktreeWithNoCheckout(
-		worktreeInstance, validationResult.RepoURL, branch, worktreePath, currentDir, issueInfo, remote, detached,
-	); err != nil {
+	if err := worktreeInstance.Create(worktree.CreateParams{
+		RepoURL:      validationResult.RepoURL,
+		Branch:       branch,
+		WorktreePath: worktreePath,
+		RepoPath:     currentDir,
+		Remote:       remote,
+		IssueInfo:    issueInfo,
+		Detached:     detached,
+		BaseRef:      baseRef,
+		Sparse:       sparse,
+	}); err != nil {
 		return "", err
 	}
 
@@ -67,12 +103,35 @@ func (r *realRepository) CreateWorktree(branch string, opts ...CreateWorktreeOpt
 	}
 
 	// Add to status file with auto-repository handling
-	if err := r.addWorktreeToStatusAndHandleCleanup(
-		worktreeInstance, validationResult.RepoURL, branch, worktreePath, issueInfo, remote, detached,
-	); err != nil {
+	if err := r.addWorktreeToStatusAndHandleCleanup(worktreeInstance, StatusParams{
+		RepoURL:      validationResult.RepoURL,
+		Branch:       branch,
+		WorktreePath: worktreePath,
+		Remote:       remote,
+		IssueInfo:    issueInfo,
+		PullRequest:  r.extractPullRequest(opts),
+		Detached:     detached,
+		BaseRef:      baseRef,
+		Ephemeral:    r.extractEphemeral(opts),
+		Sparse:       sparse,
+	}); err != nil {
 		return "", err
 	}
 
+	// Move the uncommitted changes into the new worktree, once it is checked out. On failure, the rollback
+	// removes the status entry as well as the worktree and its new branch.
+	if carryChanges {
+		if err := r.carryChanges(worktreeInstance, carryChangesParams{
+			RepoURL:      validationResult.RepoURL,
+			Branch:       branch,
+			RepoPath:     currentDir,
+			WorktreePath: worktreePath,
+			NewBranch:    newBranch,
+		}); err != nil {
+			return "", err
+		}
+	}
+
 	r.deps.Logger.Logf("Successfully created worktree for branch %s at %s", branch, worktreePath)
 
 	return worktreePath, nil
@@ -86,6 +145,36 @@ func (r *realRepository) extractIssueInfo(opts []CreateWorktreeOpts) *issue.Info
 	return nil
 }
 
+// extractPullRequest extracts pull request info from options if provided.
+func (r *realRepository) extractPullRequest(opts []CreateWorktreeOpts) *pullrequest.Info {
+	if len(opts) > 0 && opts[0].PullRequest != nil {
+		return opts[0].PullRequest
+	}
+	return nil
+}
+
+// extractEphemeral extracts the lifetime of an ephemeral worktree from options if provided.
+func (r *realRepository) extractEphemeral(opts []CreateWorktreeOpts) *status.Ephemeral {
+	if len(opts) > 0 && opts[0].Ephemeral != nil {
+		return opts[0].Ephemeral
+	}
+	return nil
+}
+
+// resolveSparse returns the sparse directories from options if provided,
+// otherwise the default sparse directories of the repository in config.
+func (r *realRepository) resolveSparse(repoURL string, opts []CreateWorktreeOpts) ([]string, error) {
+	if len(opts) > 0 && len(opts[0].Sparse) > 0 {
+		return opts[0].Sparse, nil
+	}
+
+	cfg, err := r.deps.Config.GetConfigWithFallback()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	return cfg.SparseFor(repoURL), nil
+}
+
 // extractRemote extracts remote name from options if provided, otherwise returns DefaultRemote.
 func (r *realRepository) extractRemote(opts []CreateWorktreeOpts) string {
 	if len(opts) > 0 && opts[0].Remote != "" {
@@ -94,6 +183,16 @@ func (r *realRepository) extractRemote(opts []CreateWorktreeOpts) string {
 	return DefaultRemote
 }
 
+// resolveBaseRef resolves the base ref from options if provided, otherwise returns an empty ref.
+func (r *realRepository) resolveBaseRef(
+	worktreeInstance worktree.Worktree, repoPath string, opts []CreateWorktreeOpts,
+) (string, error) {
+	if len(opts) == 0 || opts[0].BaseRef == "" {
+		return "", nil
+	}
+	return worktreeInstance.ResolveBaseRef(repoPath, opts[0].BaseRef)
+}
+
 // cleanupWorktreeOnError cleans up worktree directory on error with logging.
 func (r *realRepository) cleanupWorktreeOnError(worktreeInstance worktree.Worktree, worktreePath, context string) {
 	if cleanupErr := worktreeInstance.CleanupDirectory(worktreePath); cleanupErr != nil {
@@ -206,26 +305,6 @@ func (r *realRepository) createAndValidateWorktreeInstance(
 	return worktreeInstance, worktreePath, nil
 }
 
-// createWorktreeWithNoCheckout creates the worktree with --no-checkout flag.
-func (r *realRepository) createWorktreeWithNoCheckout(
-	worktreeInstance worktree.Worktree,
-	repoURL, branch, worktreePath, currentDir string,
-	issueInfo *issue.Info,
-	remote string,
-	detached bool,
-) error {
-	return worktreeInstance.Create(worktree.CreateParams{
-		RepoURL:      repoURL,
-		Branch:       branch,
-		WorktreePath: worktreePath,
-		RepoPath:     currentDir,
-		Remote:       remote,
-		IssueInfo:    issueInfo,
-		Force:        false,
-		Detached:     detached,
-	})
-}
-
 // checkoutBranchInWorktree checks out the branch in the worktree with proper error handling.
 func (r *realRepository) checkoutBranchInWorktree(
 	worktreeInstance worktree.Worktree,
@@ -246,24 +325,11 @@ func (r *realRepository) checkoutBranchInWorktree(
 // addWorktreeToStatusAndHandleCleanup adds the worktree to status and handles cleanup on failure.
 func (r *realRepository) addWorktreeToStatusAndHandleCleanup(
 	worktreeInstance worktree.Worktree,
-	repoURL string,
-	branch string,
-	worktreePath string,
-	issueInfo *issue.Info,
-	remote string,
-	detached bool,
+	params StatusParams,
 ) error {
-	if err := r.AddWorktreeToStatus(StatusParams{
-		RepoURL:       repoURL,
-		Branch:        branch,
-		WorktreePath:  worktreePath,
-		WorkspacePath: "",
-		Remote:        remote,
-		IssueInfo:     issueInfo,
-		Detached:      detached,
-	}); err != nil {
+	if err := r.AddWorktreeToStatus(params); err != nil {
 		// Clean up worktree on status failure
-		r.cleanupWorktreeOnError(worktreeInstance, worktreePath, "status failure")
+		r.cleanupWorktreeOnError(worktreeInstance, params.WorktreePath, "status failure")
 		return err
 	}
 	return nil
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/lerenn/code-manager/pkg/config"
+	configmocks "github.com/lerenn/code-manager/pkg/config/mocks"
 	"github.com/lerenn/code-manager/pkg/dependencies"
 	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
 	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
@@ -68,6 +69,111 @@ func TestCreateWorktree_Success(t *testing.T) {
 	assert.Equal(t, "/test/repos/github.com/test/repo/worktrees/origin/test-branch", result)
 }
 
+func TestCreateWorktree_FromBaseRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+	mockWorktree := worktreemocks.NewMockWorktree(ctrl)
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mockFS,
+			Git:              mockGit,
+			Config:           config.NewManager("/test/config.yaml"),
+			StatusManager:    mockStatus,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           promptmocks.NewMockPrompter(ctrl),
+			WorktreeProvider: func(params worktree.NewWorktreeParams) worktree.Worktree { return mockWorktree },
+		},
+		repositoryPath: "/test/repo",
+	}
+	worktreePath := "/test/repos/github.com/test/repo/worktrees/origin/hotfix"
+
+	mockFS.EXPECT().Exists("/test/repo/.git").Return(true, nil)
+	mockFS.EXPECT().IsDir("/test/repo/.git").Return(true, nil)
+	mockGit.EXPECT().GetRepositoryName("/test/repo").Return("github.com/test/repo", nil)
+	mockStatus.EXPECT().GetWorktree("github.com/test/repo", "hotfix").Return(nil, status.ErrWorktreeNotFound)
+	mockGit.EXPECT().IsClean("/test/repo").Return(true, nil)
+	mockWorktree.EXPECT().BuildPath("github.com/test/repo", "origin", "hotfix").Return(worktreePath)
+	mockWorktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+
+	// The base ref is resolved, used to create the branch and recorded in the status
+	mockWorktree.EXPECT().ResolveBaseRef("/test/repo", "release/1.4").Return("origin/release/1.4", nil)
+	mockWorktree.EXPECT().Create(gomock.Any()).DoAndReturn(func(params worktree.CreateParams) error {
+		assert.Equal(t, "origin/release/1.4", params.BaseRef)
+		return nil
+	})
+	mockWorktree.EXPECT().CheckoutBranch(worktreePath, "hotfix").Return(nil)
+	mockGit.EXPECT().SetUpstreamBranch(worktreePath, "origin", "hotfix").Return(nil)
+	mockWorktree.EXPECT().AddToStatus(gomock.Any()).DoAndReturn(func(params worktree.AddToStatusParams) error {
+		assert.Equal(t, "origin/release/1.4", params.BaseRef)
+		return nil
+	})
+
+	result, err := repository.CreateWorktree("hotfix", CreateWorktreeOpts{BaseRef: "release/1.4"})
+	assert.NoError(t, err)
+	assert.Equal(t, worktreePath, result)
+}
+
+func TestCreateWorktree_SparseFromConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+	mockConfig := configmocks.NewMockManager(ctrl)
+	mockWorktree := worktreemocks.NewMockWorktree(ctrl)
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mockFS,
+			Git:              mockGit,
+			Config:           mockConfig,
+			StatusManager:    mockStatus,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           promptmocks.NewMockPrompter(ctrl),
+			WorktreeProvider: func(params worktree.NewWorktreeParams) worktree.Worktree { return mockWorktree },
+		},
+		repositoryPath: "/test/repo",
+	}
+	worktreePath := "/test/repos/github.com/test/repo/worktrees/origin/feature"
+	sparse := []string{"services/api", "libs/common"}
+
+	mockConfig.EXPECT().GetConfigWithFallback().Return(config.Config{
+		RepositoriesDir: "/test/repos",
+		Repositories: map[string]config.RepositoryConfig{
+			"github.com/test/repo": {Sparse: sparse},
+		},
+	}, nil).AnyTimes()
+	mockFS.EXPECT().Exists("/test/repo/.git").Return(true, nil)
+	mockFS.EXPECT().IsDir("/test/repo/.git").Return(true, nil)
+	mockGit.EXPECT().GetRepositoryName("/test/repo").Return("github.com/test/repo", nil)
+	mockStatus.EXPECT().GetWorktree("github.com/test/repo", "feature").Return(nil, status.ErrWorktreeNotFound)
+	mockGit.EXPECT().IsClean("/test/repo").Return(true, nil)
+	mockWorktree.EXPECT().BuildPath("github.com/test/repo", "origin", "feature").Return(worktreePath)
+	mockWorktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+
+	// The default sparse directories of the repository are checked out and recorded in the status
+	mockWorktree.EXPECT().Create(gomock.Any()).DoAndReturn(func(params worktree.CreateParams) error {
+		assert.Equal(t, sparse, params.Sparse)
+		return nil
+	})
+	mockWorktree.EXPECT().CheckoutBranch(worktreePath, "feature").Return(nil)
+	mockGit.EXPECT().SetUpstreamBranch(worktreePath, "origin", "feature").Return(nil)
+	mockWorktree.EXPECT().AddToStatus(gomock.Any()).DoAndReturn(func(params worktree.AddToStatusParams) error {
+		assert.Equal(t, sparse, params.Sparse)
+		return nil
+	})
+
+	result, err := repository.CreateWorktree("feature")
+	assert.NoError(t, err)
+	assert.Equal(t, worktreePath, result)
+}
+
 func TestCreateWorktree_ValidationError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
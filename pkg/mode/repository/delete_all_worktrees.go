@@ -9,7 +9,7 @@ import (
 	"github.com/lerenn/code-manager/pkg/worktree"
 )
 
-// DeleteAllWorktrees deletes all worktrees for the repository.
+// DeleteAllWorktrees deletes all worktrees for the repository, except the locked ones.
 func (r *realRepository) DeleteAllWorktrees(force bool) error {
 	r.deps.Logger.Logf("Deleting all worktrees for single repository")
 
@@ -20,11 +20,21 @@ func (r *realRepository) DeleteAllWorktrees(force bool) error {
 	}
 
 	// Get all worktrees for this repository
-	worktrees, err := r.ListWorktrees()
+	allWorktrees, err := r.ListWorktrees()
 	if err != nil {
 		return fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
+	// Locked worktrees are kept, even when forced
+	var worktrees []status.WorktreeInfo
+	for _, worktreeInfo := range allWorktrees {
+		if worktreeInfo.Locked {
+			r.deps.Logger.Logf("Skipping locked worktree for branch: %s", worktreeInfo.Branch)
+			continue
+		}
+		worktrees = append(worktrees, worktreeInfo)
+	}
+
 	if len(worktrees) == 0 {
 		r.deps.Logger.Logf("No worktrees found to delete")
 		return nil
@@ -85,11 +95,16 @@ func (r *realRepository) deleteSingleWorktree(
 	r.deps.Logger.Logf("Deleting worktree for branch: %s", worktreeInfo.Branch)
 
 	var worktreePath string
-	if worktreeInfo.Detached {
+	switch {
+	case worktreeInfo.Detached:
 		// For detached worktrees, build the path (they're not in Git worktree list)
 		worktreePath = worktreeInstance.BuildPath(validationResult.RepoURL, "origin", worktreeInfo.Branch)
 		r.deps.Logger.Logf("Detached worktree detected, using built path: %s", worktreePath)
-	} else {
+	case worktreeInfo.DetachedHead:
+		// Worktrees on a detached HEAD have no branch to look them up in Git
+		worktreePath = worktreeInstance.BuildPath(validationResult.RepoURL, worktreeInfo.Remote, worktreeInfo.Branch)
+		r.deps.Logger.Logf("Detached HEAD worktree detected, using built path: %s", worktreePath)
+	default:
 		// For regular worktrees, get path from Git
 		var err error
 		worktreePath, err = r.deps.Git.GetWorktreePath(validationResult.RepoPath, worktreeInfo.Branch)
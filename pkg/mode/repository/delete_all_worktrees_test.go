@@ -74,6 +74,49 @@ func TestRealRepository_DeleteAllWorktrees_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRealRepository_DeleteAllWorktrees_SkipsLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusMocks.NewMockManager(ctrl)
+	mockWorktree := worktreeMocks.NewMockWorktree(ctrl)
+
+	repo := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mockFS,
+			Git:              mockGit,
+			Config:           config.NewManager("/test/config.yaml"),
+			StatusManager:    mockStatus,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           promptMocks.NewMockPrompter(ctrl),
+			WorktreeProvider: func(params worktree.NewWorktreeParams) worktree.Worktree { return mockWorktree },
+		},
+		repositoryPath: ".",
+	}
+
+	mockFS.EXPECT().Exists(".git").Return(true, nil)
+	mockFS.EXPECT().IsDir(".git").Return(true, nil)
+	mockGit.EXPECT().GetRepositoryName(gomock.Any()).Return("test-repo", nil).Times(2)
+	mockStatus.EXPECT().GetRepository("test-repo").Return(&status.Repository{
+		Worktrees: map[string]status.WorktreeInfo{
+			"feature/branch1": {Branch: "feature/branch1", Remote: "origin"},
+			"feature/locked":  {Branch: "feature/locked", Remote: "origin", Locked: true},
+		},
+	}, nil)
+
+	// Only the unlocked worktree is deleted, even when forced
+	mockGit.EXPECT().GetWorktreePath(gomock.Any(), "feature/branch1").Return("/test/repos/worktrees/test-repo/feature/branch1", nil)
+	mockWorktree.EXPECT().Delete(gomock.Any()).DoAndReturn(func(params worktree.DeleteParams) error {
+		assert.Equal(t, "feature/branch1", params.Branch)
+		return nil
+	})
+
+	err := repo.DeleteAllWorktrees(true)
+	assert.NoError(t, err)
+}
+
 func TestRealRepository_DeleteAllWorktrees_NoWorktrees(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -87,6 +87,12 @@ func (r *realRepository) getWorktreePath(
 		r.deps.Logger.Logf("Detached worktree detected, using built path: %s", worktreePath)
 		return worktreePath, false, nil
 	}
+	if worktreeInfo.DetachedHead {
+		// Worktrees on a detached HEAD have no branch to look them up in Git
+		worktreePath := worktreeInstance.BuildPath(validationResult.RepoURL, worktreeInfo.Remote, branch)
+		r.deps.Logger.Logf("Detached HEAD worktree detected, using built path: %s", worktreePath)
+		return worktreePath, false, nil
+	}
 
 	// For regular worktrees, get path from Git
 	worktreePath, err := r.deps.Git.GetWorktreePath(validationResult.RepoPath, branch)
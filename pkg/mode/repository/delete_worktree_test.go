@@ -72,6 +72,53 @@ func TestDeleteWorktree_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeleteWorktree_DetachedHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+	mockWorktree := worktreemocks.NewMockWorktree(ctrl)
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mockFS,
+			Git:              mockGit,
+			Config:           config.NewManager("/test/config.yaml"),
+			StatusManager:    mockStatus,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           promptmocks.NewMockPrompter(ctrl),
+			WorktreeProvider: func(params worktree.NewWorktreeParams) worktree.Worktree { return mockWorktree },
+		},
+		repositoryPath: "/test/repo",
+	}
+	worktreePath := "/test/repos/github.com/test/repo/detached/v2.3.1"
+
+	mockFS.EXPECT().Exists("/test/repo/.git").Return(true, nil)
+	mockFS.EXPECT().IsDir("/test/repo/.git").Return(true, nil)
+	mockGit.EXPECT().GetRepositoryName("/test/repo").Return("github.com/test/repo", nil).AnyTimes()
+	mockStatus.EXPECT().GetWorktree("github.com/test/repo", "v2.3.1").Return(&status.WorktreeInfo{
+		Remote:       DetachedRemote,
+		Branch:       "v2.3.1",
+		DetachedHead: true,
+		Commit:       "1a2b3c4d5e6f",
+	}, nil).Times(2)
+
+	// The path is built from the status entry, as git has no branch to find the worktree with
+	mockWorktree.EXPECT().BuildPath("github.com/test/repo", DetachedRemote, "v2.3.1").Return(worktreePath)
+	mockWorktree.EXPECT().Delete(worktree.DeleteParams{
+		RepoURL:      "github.com/test/repo",
+		Branch:       "v2.3.1",
+		WorktreePath: worktreePath,
+		RepoPath:     "/test/repo",
+		Force:        true,
+	}).Return(nil)
+
+	err := repository.DeleteWorktree("v2.3.1", true)
+	assert.NoError(t, err)
+}
+
 func TestDeleteWorktree_ValidationError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
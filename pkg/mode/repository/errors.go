@@ -13,11 +13,16 @@ var (
 	// Worktree errors.
 	ErrWorktreeExists      = errors.New("worktree already exists")
 	ErrWorktreeNotInStatus = errors.New("worktree not found in status file")
+	ErrRefNotFound         = errors.New("ref not found")
 
 	// Repository state errors.
 	ErrRepositoryNotClean = errors.New("repository is not clean")
 	ErrDirectoryExists    = errors.New("directory already exists")
 
+	// Carried changes errors.
+	ErrCarryChangesFailed       = errors.New("failed to carry changes into the new worktree")
+	ErrCarryChangesNotSupported = errors.New("carrying changes is not supported for standalone clones")
+
 	// User interaction errors.
 	ErrDeletionCancelled = errors.New("deletion cancelled by user")
 
@@ -8,7 +8,8 @@ import (
 )
 
 // HandleRemoteManagement handles remote addition if the remote doesn't exist.
-func (r *realRepository) HandleRemoteManagement(remoteSource string) error {
+// The remote URL is built from origin, unless the URLs of the remote repository are given.
+func (r *realRepository) HandleRemoteManagement(remoteSource string, opts ...HandleRemoteManagementOpts) error {
 	// If remote source is "origin", no need to add it
 	if remoteSource == "origin" {
 		r.deps.Logger.Logf("Using existing origin remote")
@@ -18,7 +19,7 @@ func (r *realRepository) HandleRemoteManagement(remoteSource string) error {
 	// Check if remote already exists and handle existing remote
 	if err := r.handleExistingRemote(remoteSource); err != nil {
 		// Remote doesn't exist, add it
-		return r.addNewRemote(remoteSource)
+		return r.addNewRemote(remoteSource, r.extractHandleRemoteManagementOptions(opts))
 	}
 
 	// Remote exists, no need to add it
@@ -47,27 +48,29 @@ func (r *realRepository) handleExistingRemote(remoteSource string) error {
 }
 
 // addNewRemote adds a new remote for the given remote source.
-func (r *realRepository) addNewRemote(remoteSource string) error {
+func (r *realRepository) addNewRemote(remoteSource string, options HandleRemoteManagementOpts) error {
 	r.deps.Logger.Logf("Adding new remote '%s'", remoteSource)
 
-	// Get repository information
-	repoName, err := r.deps.Git.GetRepositoryName(r.repositoryPath)
-	if err != nil {
-		return fmt.Errorf("failed to get repository name: %w", err)
-	}
-
 	originURL, err := r.deps.Git.GetRemoteURL(r.repositoryPath, "origin")
 	if err != nil {
 		return fmt.Errorf("failed to get origin remote URL: %w", err)
 	}
 
-	// Construct remote URL
-	remoteURL, err := r.ConstructRemoteURL(originURL, remoteSource, repoName)
-	if err != nil {
-		return err
-	}
+	remoteURL := r.selectRemoteURL(originURL, options)
+	if remoteURL == "" {
+		// Get repository information
+		repoName, err := r.deps.Git.GetRepositoryName(r.repositoryPath)
+		if err != nil {
+			return fmt.Errorf("failed to get repository name: %w", err)
+		}
 
-	r.deps.Logger.Logf("Constructed remote URL: %s", remoteURL)
+		// Construct remote URL
+		remoteURL, err = r.ConstructRemoteURL(originURL, remoteSource, repoName)
+		if err != nil {
+			return err
+		}
+		r.deps.Logger.Logf("Constructed remote URL: %s", remoteURL)
+	}
 
 	// Add the remote
 	if err := r.deps.Git.AddRemote(r.repositoryPath, remoteSource, remoteURL); err != nil {
@@ -77,6 +80,37 @@ func (r *realRepository) addNewRemote(remoteSource string) error {
 	return nil
 }
 
+// selectRemoteURL returns the given remote URL using the same protocol as origin, or the other
+// one if it is the only one given. It returns an empty string when no URL is given.
+func (r *realRepository) selectRemoteURL(originURL string, options HandleRemoteManagementOpts) string {
+	if r.DetermineProtocol(originURL) == "ssh" && options.SSHURL != "" {
+		return options.SSHURL
+	}
+	if options.CloneURL != "" {
+		return options.CloneURL
+	}
+	return options.SSHURL
+}
+
+// extractHandleRemoteManagementOptions extracts and merges options from the variadic parameter.
+func (r *realRepository) extractHandleRemoteManagementOptions(
+	opts []HandleRemoteManagementOpts,
+) HandleRemoteManagementOpts {
+	var result HandleRemoteManagementOpts
+
+	// Merge all provided options, with later options overriding earlier ones
+	for _, opt := range opts {
+		if opt.CloneURL != "" {
+			result.CloneURL = opt.CloneURL
+		}
+		if opt.SSHURL != "" {
+			result.SSHURL = opt.SSHURL
+		}
+	}
+
+	return result
+}
+
 // ConstructRemoteURL constructs the remote URL based on origin URL and remote source.
 func (r *realRepository) ConstructRemoteURL(originURL, remoteSource, repoName string) (string, error) {
 	protocol := r.DetermineProtocol(originURL)
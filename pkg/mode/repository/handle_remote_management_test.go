@@ -110,6 +110,33 @@ func TestHandleRemoteManagement_NewRemote(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestHandleRemoteManagement_NewRemoteWithURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGit := gitmocks.NewMockGit(ctrl)
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			Git:    mockGit,
+			Config: config.NewManager("/test/config.yaml"),
+			Logger: logger.NewNoopLogger(),
+		},
+		repositoryPath: "/test/repo",
+	}
+
+	// The given URLs are used instead of the one built from origin (renamed forks), with the protocol of origin
+	mockGit.EXPECT().RemoteExists("/test/repo", "contributor").Return(false, nil)
+	mockGit.EXPECT().GetRemoteURL("/test/repo", "origin").Return("git@github.com:octocat/Hello-World.git", nil)
+	mockGit.EXPECT().AddRemote("/test/repo", "contributor", "git@github.com:contributor/hello-fork.git").Return(nil)
+
+	err := repository.HandleRemoteManagement("contributor", HandleRemoteManagementOpts{
+		CloneURL: "https://github.com/contributor/hello-fork.git",
+		SSHURL:   "git@github.com:contributor/hello-fork.git",
+	})
+	assert.NoError(t, err)
+}
+
 func TestExtractHostFromURL_HTTPS(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
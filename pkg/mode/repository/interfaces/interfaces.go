@@ -8,6 +8,7 @@ package interfaces
 
 import (
 	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
 )
 
@@ -15,13 +16,32 @@ import (
 type CreateWorktreeOpts struct {
 	IDEName       string
 	IssueInfo     *issue.Info
+	PullRequest   *pullrequest.Info
 	WorkspaceName string
 	Remote        string // Remote name to use (defaults to DefaultRemote if empty)
+	BaseRef       string // Ref new branches are created from (branch, tag, remote branch or commit SHA)
+	CarryChanges  bool   // Move the uncommitted changes of the repository into the new worktree
+	Ephemeral     *status.Ephemeral
+	Sparse        []string // Directories checked out (defaults to the repository sparse patterns in config)
 }
 
 // LoadWorktreeOpts contains optional parameters for LoadWorktree.
 type LoadWorktreeOpts struct {
-	IssueInfo *issue.Info
+	IssueInfo   *issue.Info
+	PullRequest *pullrequest.Info
+	Ephemeral   *status.Ephemeral
+	Sparse      []string
+}
+
+// CreateDetachedWorktreeOpts contains optional parameters for CreateDetachedWorktree.
+type CreateDetachedWorktreeOpts struct {
+	Ephemeral *status.Ephemeral
+}
+
+// HandleRemoteManagementOpts contains optional parameters for HandleRemoteManagement.
+type HandleRemoteManagementOpts struct {
+	CloneURL string // HTTPS URL of the remote repository, instead of the one built from origin
+	SSHURL   string // SSH URL of the remote repository, instead of the one built from origin
 }
 
 // ValidationParams contains parameters for repository validation.
@@ -44,7 +64,13 @@ type StatusParams struct {
 	WorkspacePath string
 	Remote        string
 	IssueInfo     *issue.Info
+	PullRequest   *pullrequest.Info
 	Detached      bool
+	BaseRef       string
+	Ephemeral     *status.Ephemeral
+	Sparse        []string
+	DetachedHead  bool
+	Commit        string
 }
 
 // Repository defines the interface for repository operations.
@@ -52,17 +78,18 @@ type StatusParams struct {
 type Repository interface {
 	Validate() error
 	CreateWorktree(branch string, opts ...CreateWorktreeOpts) (string, error)
+	CreateDetachedWorktree(ref string, opts ...CreateDetachedWorktreeOpts) (string, error)
 	DeleteWorktree(branch string, force bool) error
 	DeleteAllWorktrees(force bool) error
 	ListWorktrees() ([]status.WorktreeInfo, error)
-	LoadWorktree(remoteSource, branchName string) (string, error)
+	LoadWorktree(remoteSource, branchName string, opts ...LoadWorktreeOpts) (string, error)
 	IsGitRepository() (bool, error)
 	ValidateGitConfiguration(workDir string) error
 	ValidateGitStatus() error
 	ValidateRepository(params ValidationParams) (*ValidationResult, error)
 	ValidateWorktreeExists(repoURL, branch string) error
 	ValidateOriginRemote() error
-	HandleRemoteManagement(repoURL string) error
+	HandleRemoteManagement(remoteSource string, opts ...HandleRemoteManagementOpts) error
 	ExtractHostFromURL(url string) string
 	DetermineProtocol(url string) string
 	ExtractRepoNameFromFullPath(fullPath string) string
@@ -7,7 +7,7 @@ import (
 )
 
 // LoadWorktree loads a branch from a remote source and creates a worktree.
-func (r *realRepository) LoadWorktree(remoteSource, branchName string) (string, error) {
+func (r *realRepository) LoadWorktree(remoteSource, branchName string, opts ...LoadWorktreeOpts) (string, error) {
 	r.deps.Logger.Logf("Loading branch: remote=%s, branch=%s", remoteSource, branchName)
 
 	// 1. Validate current directory is a Git repository
@@ -61,6 +61,13 @@ func (r *realRepository) LoadWorktree(remoteSource, branchName string) (string,
 
 	// 7. Create worktree for the branch (using existing worktree creation logic directly)
 	r.deps.Logger.Logf("Creating worktree for branch '%s'", branchName)
-	worktreePath, err := r.CreateWorktree(branchName, CreateWorktreeOpts{Remote: remoteSource})
+	createOpts := CreateWorktreeOpts{Remote: remoteSource}
+	if len(opts) > 0 {
+		createOpts.IssueInfo = opts[0].IssueInfo
+		createOpts.PullRequest = opts[0].PullRequest
+		createOpts.Ephemeral = opts[0].Ephemeral
+		createOpts.Sparse = opts[0].Sparse
+	}
+	worktreePath, err := r.CreateWorktree(branchName, createOpts)
 	return worktreePath, err
 }
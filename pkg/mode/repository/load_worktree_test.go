@@ -12,6 +12,7 @@ import (
 	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
 	"github.com/lerenn/code-manager/pkg/logger"
 	promptmocks "github.com/lerenn/code-manager/pkg/prompt/mocks"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/lerenn/code-manager/pkg/status"
 	statusmocks "github.com/lerenn/code-manager/pkg/status/mocks"
 	"github.com/lerenn/code-manager/pkg/worktree"
@@ -77,6 +78,77 @@ func TestLoadWorktree_Success(t *testing.T) {
 	assert.Equal(t, "/test/repos/github.com/test/repo/worktrees/origin/feature-branch", worktreePath)
 }
 
+func TestLoadWorktree_WithPullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+	mockPrompt := promptmocks.NewMockPrompter(ctrl)
+	mockWorktree := worktreemocks.NewMockWorktree(ctrl)
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mockFS,
+			Git:              mockGit,
+			Config:           config.NewManager("/test/config.yaml"),
+			StatusManager:    mockStatus,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           mockPrompt,
+			WorktreeProvider: func(params worktree.NewWorktreeParams) worktree.Worktree { return mockWorktree },
+		},
+		repositoryPath: "/test/repo",
+	}
+
+	prInfo := &pullrequest.Info{
+		Number:         42,
+		URL:            "https://github.com/test/repo/pull/42",
+		BaseBranch:     "main",
+		HeadBranch:     "fix-typo",
+		HeadOwner:      "contributor",
+		HeadRepository: "repo",
+		Owner:          "test",
+		Repository:     "repo",
+	}
+	worktreePath := "/test/repos/github.com/test/repo/contributor/fix-typo"
+
+	// Mock Git repository validation
+	mockFS.EXPECT().Exists("/test/repo/.git").Return(true, nil).AnyTimes()
+	mockFS.EXPECT().IsDir("/test/repo/.git").Return(true, nil).AnyTimes()
+
+	// Mock remote validation and management (fork remote already added)
+	mockGit.EXPECT().RemoteExists("/test/repo", "contributor").Return(true, nil).AnyTimes()
+	mockGit.EXPECT().GetRemoteURL("/test/repo", "contributor").Return("https://github.com/contributor/repo.git", nil).AnyTimes()
+	mockGit.EXPECT().FetchRemote("/test/repo", "contributor").Return(nil)
+	mockGit.EXPECT().BranchExistsOnRemote(git.BranchExistsOnRemoteParams{
+		RepoPath:   "/test/repo",
+		RemoteName: "contributor",
+		Branch:     "fix-typo",
+	}).Return(true, nil)
+
+	// Mock worktree creation (called by CreateWorktree)
+	mockGit.EXPECT().GetRepositoryName("/test/repo").Return("github.com/test/repo", nil)
+	mockStatus.EXPECT().GetWorktree("github.com/test/repo", "fix-typo").Return(nil, status.ErrWorktreeNotFound)
+	mockGit.EXPECT().IsClean("/test/repo").Return(true, nil)
+	mockWorktree.EXPECT().BuildPath("github.com/test/repo", "contributor", "fix-typo").Return(worktreePath)
+	mockWorktree.EXPECT().ValidateCreation(gomock.Any()).Return(nil)
+	mockWorktree.EXPECT().Create(gomock.Any()).Return(nil)
+	mockWorktree.EXPECT().CheckoutBranch(worktreePath, "fix-typo").Return(nil)
+	mockGit.EXPECT().SetUpstreamBranch(worktreePath, "contributor", "fix-typo").Return(nil)
+
+	// The pull request metadata must be recorded in the status file
+	mockWorktree.EXPECT().AddToStatus(gomock.Any()).DoAndReturn(func(params worktree.AddToStatusParams) error {
+		assert.Equal(t, "contributor", params.Remote)
+		assert.Equal(t, prInfo, params.PullRequest)
+		return nil
+	})
+
+	result, err := repository.LoadWorktree("contributor", "fix-typo", LoadWorktreeOpts{PullRequest: prInfo})
+	assert.NoError(t, err)
+	assert.Equal(t, worktreePath, result)
+}
+
 func TestLoadWorktree_NotGitRepository(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -84,6 +84,26 @@ func (mr *MockRepositoryMockRecorder) ConstructRemoteURL(originURL, remoteSource
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstructRemoteURL", reflect.TypeOf((*MockRepository)(nil).ConstructRemoteURL), originURL, remoteSource, repoName)
 }
 
+// CreateDetachedWorktree mocks base method.
+func (m *MockRepository) CreateDetachedWorktree(ref string, opts ...interfaces.CreateDetachedWorktreeOpts) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ref}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateDetachedWorktree", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDetachedWorktree indicates an expected call of CreateDetachedWorktree.
+func (mr *MockRepositoryMockRecorder) CreateDetachedWorktree(ref any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ref}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDetachedWorktree", reflect.TypeOf((*MockRepository)(nil).CreateDetachedWorktree), varargs...)
+}
+
 // CreateWorktree mocks base method.
 func (m *MockRepository) CreateWorktree(branch string, opts ...interfaces.CreateWorktreeOpts) (string, error) {
 	m.ctrl.T.Helper()
@@ -175,17 +195,22 @@ func (mr *MockRepositoryMockRecorder) ExtractRepoNameFromFullPath(fullPath any)
 }
 
 // HandleRemoteManagement mocks base method.
-func (m *MockRepository) HandleRemoteManagement(repoURL string) error {
+func (m *MockRepository) HandleRemoteManagement(remoteSource string, opts ...interfaces.HandleRemoteManagementOpts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRemoteManagement", repoURL)
+	varargs := []any{remoteSource}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HandleRemoteManagement", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleRemoteManagement indicates an expected call of HandleRemoteManagement.
-func (mr *MockRepositoryMockRecorder) HandleRemoteManagement(repoURL any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) HandleRemoteManagement(remoteSource any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRemoteManagement", reflect.TypeOf((*MockRepository)(nil).HandleRemoteManagement), repoURL)
+	varargs := append([]any{remoteSource}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRemoteManagement", reflect.TypeOf((*MockRepository)(nil).HandleRemoteManagement), varargs...)
 }
 
 // IsGitRepository mocks base method.
@@ -219,18 +244,23 @@ func (mr *MockRepositoryMockRecorder) ListWorktrees() *gomock.Call {
 }
 
 // LoadWorktree mocks base method.
-func (m *MockRepository) LoadWorktree(remoteSource, branchName string) (string, error) {
+func (m *MockRepository) LoadWorktree(remoteSource, branchName string, opts ...interfaces.LoadWorktreeOpts) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadWorktree", remoteSource, branchName)
+	varargs := []any{remoteSource, branchName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoadWorktree", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoadWorktree indicates an expected call of LoadWorktree.
-func (mr *MockRepositoryMockRecorder) LoadWorktree(remoteSource, branchName any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) LoadWorktree(remoteSource, branchName any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWorktree", reflect.TypeOf((*MockRepository)(nil).LoadWorktree), remoteSource, branchName)
+	varargs := append([]any{remoteSource, branchName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWorktree", reflect.TypeOf((*MockRepository)(nil).LoadWorktree), varargs...)
 }
 
 // Validate mocks base method.
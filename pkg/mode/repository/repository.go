@@ -15,6 +15,10 @@ import (
 // DefaultRemote is the default remote name used for Git operations.
 const DefaultRemote = "origin"
 
+// DetachedRemote replaces the remote name of worktrees checked out on a detached HEAD,
+// so that they live under <repositories_dir>/<repo_url>/detached/<tag-or-short-sha>.
+const DetachedRemote = "detached"
+
 // Repository interface provides repository management capabilities.
 // This interface is now defined in pkg/mode/repository/interfaces to avoid circular imports.
 type Repository = interfaces.Repository
@@ -25,6 +29,12 @@ type CreateWorktreeOpts = interfaces.CreateWorktreeOpts
 // LoadWorktreeOpts contains optional parameters for LoadWorktree.
 type LoadWorktreeOpts = interfaces.LoadWorktreeOpts
 
+// CreateDetachedWorktreeOpts contains optional parameters for CreateDetachedWorktree.
+type CreateDetachedWorktreeOpts = interfaces.CreateDetachedWorktreeOpts
+
+// HandleRemoteManagementOpts contains optional parameters for HandleRemoteManagement.
+type HandleRemoteManagementOpts = interfaces.HandleRemoteManagementOpts
+
 // ValidationParams contains parameters for repository validation.
 type ValidationParams = interfaces.ValidationParams
 
@@ -0,0 +1,59 @@
+//go:build unit
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/dependencies"
+	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/logger"
+	promptmocks "github.com/lerenn/code-manager/pkg/prompt/mocks"
+	statusmocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/lerenn/code-manager/pkg/worktree"
+	worktreemocks "github.com/lerenn/code-manager/pkg/worktree/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// testMocks holds the mocked dependencies of a repository created with newTestRepository.
+type testMocks struct {
+	fs       *fsmocks.MockFS
+	git      *gitmocks.MockGit
+	status   *statusmocks.MockManager
+	worktree *worktreemocks.MockWorktree
+}
+
+// newTestRepository creates a repository at /test/repo whose dependencies are mocks without any expectation.
+func newTestRepository(t *testing.T) (*realRepository, testMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := testMocks{
+		fs:       fsmocks.NewMockFS(ctrl),
+		git:      gitmocks.NewMockGit(ctrl),
+		status:   statusmocks.NewMockManager(ctrl),
+		worktree: worktreemocks.NewMockWorktree(ctrl),
+	}
+
+	repository := &realRepository{
+		deps: &dependencies.Dependencies{
+			FS:               mocks.fs,
+			Git:              mocks.git,
+			Config:           config.NewManager("/test/config.yaml"),
+			StatusManager:    mocks.status,
+			Logger:           logger.NewNoopLogger(),
+			Prompt:           promptmocks.NewMockPrompter(ctrl),
+			WorktreeProvider: func(_ worktree.NewWorktreeParams) worktree.Worktree { return mocks.worktree },
+		},
+		repositoryPath: "/test/repo",
+	}
+
+	return repository, mocks
+}
+
+// expectValidRepository expects /test/repo to be validated as the github.com/test/repo repository.
+func expectValidRepository(mocks testMocks) {
+	mocks.fs.EXPECT().Exists("/test/repo/.git").Return(true, nil)
+	mocks.fs.EXPECT().IsDir("/test/repo/.git").Return(true, nil)
+	mocks.git.EXPECT().GetRepositoryName("/test/repo").Return("github.com/test/repo", nil)
+}
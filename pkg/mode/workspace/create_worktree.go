@@ -26,7 +26,13 @@ func (w *realWorkspace) CreateWorktree(branch string, opts ...CreateWorktreeOpts
 		return "", err
 	}
 
-	return w.createWorkspaceWorktrees(workspaceName, branch, repositories)
+	var repoOpts repository.CreateWorktreeOpts
+	if len(opts) > 0 {
+		repoOpts.BaseRef = opts[0].BaseRef
+		repoOpts.Sparse = opts[0].Sparse
+	}
+
+	return w.createWorkspaceWorktrees(workspaceName, branch, repositories, repoOpts)
 }
 
 // extractWorkspaceName extracts and validates the workspace name from options.
@@ -54,7 +60,10 @@ func (w *realWorkspace) validateAndGetRepositories(workspaceName string) ([]stri
 }
 
 // createWorkspaceWorktrees creates worktrees for all repositories in the workspace.
-func (w *realWorkspace) createWorkspaceWorktrees(workspaceName, branch string, repositories []string) (string, error) {
+// The repository options (base ref, sparse directories) apply to every worktree.
+func (w *realWorkspace) createWorkspaceWorktrees(
+	workspaceName, branch string, repositories []string, repoOpts repository.CreateWorktreeOpts,
+) (string, error) {
 	var createdWorktrees []string
 	var createdWorkspaceFile string
 	var actualRepositoryURLs []string
@@ -70,7 +79,7 @@ func (w *realWorkspace) createWorkspaceWorktrees(workspaceName, branch string, r
 
 	// Create worktrees in each repository and collect actual repository URLs
 	for _, repoURL := range repositories {
-		worktreePath, actualRepoURL, err := w.createSingleRepositoryWorktreeWithURL(repoURL, workspaceName, branch)
+		worktreePath, actualRepoURL, err := w.createSingleRepositoryWorktreeWithURL(repoURL, workspaceName, branch, repoOpts)
 		if err != nil {
 			return "", err
 		}
@@ -97,7 +106,7 @@ func (w *realWorkspace) createWorkspaceWorktrees(workspaceName, branch string, r
 // createSingleRepositoryWorktreeWithURL creates a worktree for a single repository and returns both the
 // worktree path and actual repository URL.
 func (w *realWorkspace) createSingleRepositoryWorktreeWithURL(
-	repoURL, workspaceName, branch string,
+	repoURL, workspaceName, branch string, repoOpts repository.CreateWorktreeOpts,
 ) (string, string, error) {
 	w.deps.Logger.Logf("Creating worktree in repository: %s", repoURL)
 
@@ -129,7 +138,7 @@ func (w *realWorkspace) createSingleRepositoryWorktreeWithURL(
 
 	// Create worktree using worktree package directly
 	// Pass the actual repository path to the repository package
-	worktreePath, err := w.createWorktreeForRepositoryWithPath(actualRepoURL, repoPath, branch)
+	worktreePath, err := w.createWorktreeForRepositoryWithPath(actualRepoURL, repoPath, branch, repoOpts)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create worktree in repository '%s': %w", actualRepoURL, err)
 	}
@@ -259,7 +268,7 @@ func (w *realWorkspace) validateRepositoryPath(repoPath string) error {
 // createWorktreeForRepositoryWithPath creates a worktree for a specific repository using repositoryProvider
 // with explicit path.
 func (w *realWorkspace) createWorktreeForRepositoryWithPath(
-	repoURL, repoPath, branch string,
+	repoURL, repoPath, branch string, repoOpts repository.CreateWorktreeOpts,
 ) (string, error) {
 	// Create repository instance using repositoryProvider with explicit path
 	repositoryProvider := w.deps.RepositoryProvider
@@ -269,9 +278,8 @@ func (w *realWorkspace) createWorktreeForRepositoryWithPath(
 	})
 
 	// Use repository's CreateWorktree method
-	worktreePath, err := repoInstance.CreateWorktree(branch, repository.CreateWorktreeOpts{
-		Remote: "origin",
-	})
+	repoOpts.Remote = "origin"
+	worktreePath, err := repoInstance.CreateWorktree(branch, repoOpts)
 	if err != nil {
 		// Check if error is because worktree already exists and handle it gracefully
 		if existingPath := w.handleWorktreeExistsError(err, repoURL, branch); existingPath != "" {
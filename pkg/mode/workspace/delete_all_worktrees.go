@@ -6,7 +6,7 @@ import (
 	"github.com/lerenn/code-manager/pkg/status"
 )
 
-// DeleteAllWorktrees deletes all worktrees for the workspace.
+// DeleteAllWorktrees deletes all worktrees for the workspace, except the branches with a locked worktree.
 func (w *realWorkspace) DeleteAllWorktrees(force bool) error {
 	w.deps.Logger.Logf("Deleting all worktrees for workspace")
 
@@ -34,10 +34,19 @@ func (w *realWorkspace) DeleteAllWorktrees(force bool) error {
 		branchGroups[worktree.Branch] = append(branchGroups[worktree.Branch], worktree)
 	}
 
+	// Branches with a locked worktree are kept, even when forced
+	for branch := range branchGroups {
+		if lockedWorktree(allWorktrees, branch) != nil {
+			w.deps.Logger.Logf("Skipping locked worktrees for branch: %s", branch)
+			delete(branchGroups, branch)
+		}
+	}
+
 	var errors []error
 	for branch := range branchGroups {
 		w.deps.Logger.Logf("Deleting worktrees for branch: %s", branch)
 
+		// The branches left are not locked, forcing the deletion only affects uncommitted changes
 		if err := w.DeleteWorktree(branch, force); err != nil {
 			w.deps.Logger.Logf("Failed to delete worktrees for branch %s: %v", branch, err)
 			errors = append(errors, fmt.Errorf("failed to delete worktrees for branch %s: %w", branch, err))
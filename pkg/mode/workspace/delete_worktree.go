@@ -3,6 +3,8 @@ package workspace
 import (
 	"fmt"
 	"path/filepath"
+
+	"github.com/lerenn/code-manager/pkg/status"
 )
 
 // DeleteWorktree deletes worktrees for the workspace with the specified branch.
@@ -26,6 +28,13 @@ func (w *realWorkspace) DeleteWorktree(branch string, force bool) error {
 		return err
 	}
 
+	// Locked worktrees are only deleted when forced
+	if !force {
+		if locked := lockedWorktree(worktrees, branch); locked != nil {
+			return fmt.Errorf("%w: branch %s of remote %s", ErrWorktreeLocked, locked.Branch, locked.Remote)
+		}
+	}
+
 	// Delete worktrees for all repositories
 	if err := w.deleteWorktreeRepositories(worktrees, force); err != nil {
 		return err
@@ -45,6 +54,16 @@ func (w *realWorkspace) DeleteWorktree(branch string, force bool) error {
 	return nil
 }
 
+// lockedWorktree returns the first locked worktree of the branch, or nil when none is locked.
+func lockedWorktree(worktrees []status.WorktreeInfo, branch string) *status.WorktreeInfo {
+	for i := range worktrees {
+		if worktrees[i].Branch == branch && worktrees[i].Locked {
+			return &worktrees[i]
+		}
+	}
+	return nil
+}
+
 // cleanupEmptyWorkspaceDirectory removes the workspace directory if it's empty.
 func (w *realWorkspace) cleanupEmptyWorkspaceDirectory(workspaceDir string) error {
 	// Check if directory exists
@@ -11,6 +11,7 @@ var (
 	ErrWorktreeNotInStatus = errors.New("worktree not found in status")
 	ErrRepositoryNotClean  = errors.New("repository is not clean")
 	ErrDirectoryExists     = errors.New("directory already exists")
+	ErrWorktreeLocked      = errors.New("worktree is locked")
 
 	// User interaction errors.
 	ErrDeletionCancelled = errors.New("deletion cancelled by user")
@@ -17,6 +17,8 @@ type CreateWorktreeOpts struct {
 	IDEName       string
 	IssueInfo     *issue.Info
 	WorkspaceName string
+	BaseRef       string   // Ref new branches are created from in every repository of the workspace
+	Sparse        []string // Directories checked out in every repository (defaults to each repository config)
 }
 
 // Config represents the configuration of a workspace.
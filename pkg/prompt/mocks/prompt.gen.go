@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	issue "github.com/lerenn/code-manager/pkg/issue"
 	prompt "github.com/lerenn/code-manager/pkg/prompt"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -115,6 +116,21 @@ func (mr *MockPrompterMockRecorder) PromptForWorkspacesDir(defaultWorkspacesDir
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromptForWorkspacesDir", reflect.TypeOf((*MockPrompter)(nil).PromptForWorkspacesDir), defaultWorkspacesDir)
 }
 
+// PromptSelectIssue mocks base method.
+func (m *MockPrompter) PromptSelectIssue(issues []issue.Info) (issue.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromptSelectIssue", issues)
+	ret0, _ := ret[0].(issue.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromptSelectIssue indicates an expected call of PromptSelectIssue.
+func (mr *MockPrompterMockRecorder) PromptSelectIssue(issues any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromptSelectIssue", reflect.TypeOf((*MockPrompter)(nil).PromptSelectIssue), issues)
+}
+
 // PromptSelectTarget mocks base method.
 func (m *MockPrompter) PromptSelectTarget(choices []prompt.TargetChoice, showWorktreeLabel bool) (prompt.TargetChoice, error) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/lerenn/code-manager/pkg/issue"
 )
 
 //go:generate go run go.uber.org/mock/mockgen@latest  -source=prompt.go -destination=mocks/prompt.gen.go -package=mocks
@@ -15,13 +17,16 @@ const (
 	TargetRepository = "repository"
 	// TargetWorkspace is the string representation of workspace target type.
 	TargetWorkspace = "workspace"
+	// TargetIssue is the string representation of issue choices.
+	TargetIssue = "issue"
 )
 
 // TargetChoice represents a selectable target with optional worktree information.
 type TargetChoice struct {
 	Type     string
 	Name     string
-	Worktree string // optional label for display only
+	Worktree string   // optional label for display only
+	Labels   []string // optional worktree labels for display and filtering only
 }
 
 // Prompter interface provides user interaction functionality.
@@ -42,6 +47,9 @@ type Prompter interface {
 	// showWorktreeLabel controls rendering of ": worktree" suffix.
 	PromptSelectTarget(choices []TargetChoice, showWorktreeLabel bool) (TargetChoice, error)
 
+	// PromptSelectIssue prompts the user to select an issue from a list.
+	PromptSelectIssue(issues []issue.Info) (issue.Info, error)
+
 	// PromptForBranchName prompts the user for a branch name.
 	PromptForBranchName() (string, error)
 }
@@ -177,6 +185,16 @@ func (p *realPrompt) PromptSelectTarget(choices []TargetChoice, showWorktreeLabe
 	return promptSelectTargetBubbleTea(choices, showWorktreeLabel)
 }
 
+// PromptSelectIssue prompts the user to select an issue from a list.
+func (p *realPrompt) PromptSelectIssue(issues []issue.Info) (issue.Info, error) {
+	if len(issues) == 0 {
+		return issue.Info{}, fmt.Errorf("no issues available")
+	}
+
+	// Use Bubble Tea selector for interactive selection
+	return promptSelectIssueBubbleTea(issues)
+}
+
 // PromptForBranchName prompts the user for a branch name.
 func (p *realPrompt) PromptForBranchName() (string, error) {
 	fmt.Print("Enter branch name: ")
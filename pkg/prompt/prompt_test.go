@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -204,6 +205,16 @@ func TestFormatChoice(t *testing.T) {
 			showWorktreeLabel: false,
 			expected:          "[repository] my-repo",
 		},
+		{
+			name: "worktree with labels",
+			choice: TargetChoice{
+				Type:   TargetRepository,
+				Name:   "feature-branch",
+				Labels: []string{"review", "wip"},
+			},
+			showWorktreeLabel: false,
+			expected:          "[repository] feature-branch [review, wip]",
+		},
 		{
 			name: "workspace with empty worktree",
 			choice: TargetChoice{
@@ -224,11 +235,27 @@ func TestFormatChoice(t *testing.T) {
 	}
 }
 
+func TestFormatIssue(t *testing.T) {
+	assert.Equal(t, "#12 Fix login", formatIssue(issue.Info{Number: 12, Title: "Fix login"}))
+	assert.Equal(t, "#12 Fix login [bug, frontend]",
+		formatIssue(issue.Info{Number: 12, Title: "Fix login", Labels: []string{"bug", "frontend"}}))
+}
+
+func TestSelectModel_View_Header(t *testing.T) {
+	model := initialSelectModel([]TargetChoice{{Type: TargetIssue, Name: "#12 Fix login"}}, false)
+	assert.Contains(t, model.View(), "Choose repository or workspace:")
+
+	model.header = issueSelectHeader
+	view := model.View()
+	assert.Contains(t, view, "Choose issue:")
+	assert.Contains(t, view, "> #12 Fix login")
+}
+
 func TestSelectModel_UpdateFilteredChoices(t *testing.T) {
 	choices := []TargetChoice{
 		{Type: TargetRepository, Name: "alpha-repo"},
 		{Type: TargetWorkspace, Name: "beta-workspace"},
-		{Type: TargetRepository, Name: "gamma-repo"},
+		{Type: TargetRepository, Name: "gamma-repo", Labels: []string{"Review"}},
 		{Type: TargetWorkspace, Name: "delta-workspace"},
 	}
 
@@ -268,6 +295,12 @@ func TestSelectModel_UpdateFilteredChoices(t *testing.T) {
 			expectedNames:   []string{"alpha-repo"},
 			expectedIndices: []int{0},
 		},
+		{
+			name:            "filter by label",
+			filter:          "review",
+			expectedNames:   []string{"gamma-repo"},
+			expectedIndices: []int{2},
+		},
 		{
 			name:            "no matches",
 			filter:          "nonexistent",
@@ -5,10 +5,18 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lerenn/code-manager/pkg/issue"
+)
+
+// Selector headers.
+const (
+	targetSelectHeader = "Choose repository or workspace:"
+	issueSelectHeader  = "Choose issue:"
 )
 
 // selectModel represents the Bubble Tea model for target selection.
 type selectModel struct {
+	header            string
 	choices           []TargetChoice
 	filteredChoices   []TargetChoice
 	filteredIndices   []int // maps filtered index to original index
@@ -35,6 +43,7 @@ func initialSelectModel(choices []TargetChoice, showWorktreeLabel bool) selectMo
 	}
 
 	return selectModel{
+		header:            targetSelectHeader,
 		choices:           choices,
 		filteredChoices:   choices,
 		filteredIndices:   makeRange(len(choices)),
@@ -150,7 +159,7 @@ func (m selectModel) updateFilteredChoices() selectModel {
 
 		filterLower := strings.ToLower(m.filter)
 		for i, choice := range m.choices {
-			if strings.Contains(strings.ToLower(choice.Name), filterLower) {
+			if choiceMatches(choice, filterLower) {
 				m.filteredChoices = append(m.filteredChoices, choice)
 				m.filteredIndices = append(m.filteredIndices, i)
 			}
@@ -168,6 +177,19 @@ func (m selectModel) updateFilteredChoices() selectModel {
 	return m
 }
 
+// choiceMatches reports whether the name or one of the labels of the choice contains the lowercase filter.
+func choiceMatches(choice TargetChoice, filterLower string) bool {
+	if strings.Contains(strings.ToLower(choice.Name), filterLower) {
+		return true
+	}
+	for _, label := range choice.Labels {
+		if strings.Contains(strings.ToLower(label), filterLower) {
+			return true
+		}
+	}
+	return false
+}
+
 // View renders the UI.
 func (m selectModel) View() string {
 	if m.quitting {
@@ -177,7 +199,7 @@ func (m selectModel) View() string {
 	var s strings.Builder
 
 	// Header
-	s.WriteString("? Choose repository or workspace:  [Use arrows to move, type to filter]\n\n")
+	s.WriteString(fmt.Sprintf("? %s  [Use arrows to move, type to filter]\n\n", m.header))
 
 	// Show filter if active
 	if m.filter != "" {
@@ -227,13 +249,51 @@ func formatChoice(choice TargetChoice, showWorktreeLabel bool, showTypePrefix bo
 		result += fmt.Sprintf(" : %s", choice.Worktree)
 	}
 
+	if len(choice.Labels) > 0 {
+		result += fmt.Sprintf(" [%s]", strings.Join(choice.Labels, ", "))
+	}
+
 	return result
 }
 
 // promptSelectTargetBubbleTea runs the Bubble Tea program for target selection.
 func promptSelectTargetBubbleTea(choices []TargetChoice, showWorktreeLabel bool) (TargetChoice, error) {
+	return runSelectModel(initialSelectModel(choices, showWorktreeLabel))
+}
+
+// promptSelectIssueBubbleTea runs the Bubble Tea program for issue selection.
+func promptSelectIssueBubbleTea(issues []issue.Info) (issue.Info, error) {
+	choices := make([]TargetChoice, 0, len(issues))
+	issuesByName := make(map[string]issue.Info, len(issues))
+	for _, issueInfo := range issues {
+		choice := TargetChoice{Type: TargetIssue, Name: formatIssue(issueInfo)}
+		choices = append(choices, choice)
+		issuesByName[choice.Name] = issueInfo
+	}
+
+	model := initialSelectModel(choices, false)
+	model.header = issueSelectHeader
+
+	selected, err := runSelectModel(model)
+	if err != nil {
+		return issue.Info{}, err
+	}
+	return issuesByName[selected.Name], nil
+}
+
+// formatIssue formats an issue for display, e.g. "#12 Fix login [bug, frontend]".
+func formatIssue(issueInfo issue.Info) string {
+	result := fmt.Sprintf("#%d %s", issueInfo.Number, issueInfo.Title)
+	if len(issueInfo.Labels) > 0 {
+		result += fmt.Sprintf(" [%s]", strings.Join(issueInfo.Labels, ", "))
+	}
+	return result
+}
+
+// runSelectModel runs the Bubble Tea program for a select model and returns the selected choice.
+func runSelectModel(initialModel selectModel) (TargetChoice, error) {
 	// Create and run the program
-	p := tea.NewProgram(initialSelectModel(choices, showWorktreeLabel))
+	p := tea.NewProgram(initialModel)
 
 	// Run the program
 	finalModel, err := p.Run()
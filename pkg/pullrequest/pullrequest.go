@@ -0,0 +1,30 @@
+// Package pullrequest provides data structures for handling forge pull/merge requests.
+package pullrequest
+
+// Pull request states, normalized across forges.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// Info represents information about a forge pull request (merge request on GitLab).
+type Info struct {
+	Number         int    `yaml:"number"`
+	Title          string `yaml:"title"`
+	State          string `yaml:"state,omitempty"`
+	URL            string `yaml:"url,omitempty"`
+	BaseBranch     string `yaml:"base_branch"`
+	HeadBranch     string `yaml:"head_branch"`
+	HeadOwner      string `yaml:"head_owner,omitempty"`
+	HeadRepository string `yaml:"head_repository,omitempty"`
+	HeadCloneURL   string `yaml:"head_clone_url,omitempty"` // HTTPS clone URL of the head repository
+	HeadSSHURL     string `yaml:"head_ssh_url,omitempty"`   // SSH clone URL of the head repository
+	Repository     string `yaml:"repository,omitempty"`
+	Owner          string `yaml:"owner,omitempty"`
+}
+
+// IsFromFork returns true when the pull request head lives in another repository than its base.
+func (i *Info) IsFromFork() bool {
+	return i.HeadOwner != i.Owner || i.HeadRepository != i.Repository
+}
@@ -23,10 +23,16 @@ func (s *realManager) AddWorktree(params AddWorktreeParams) error {
 
 	// Create new worktree entry
 	worktreeInfo := WorktreeInfo{
-		Remote:   params.Remote,
-		Branch:   params.Branch,
-		Issue:    params.IssueInfo,
-		Detached: params.Detached,
+		Remote:       params.Remote,
+		Branch:       params.Branch,
+		Issue:        params.IssueInfo,
+		PullRequest:  params.PullRequest,
+		Detached:     params.Detached,
+		BaseRef:      params.BaseRef,
+		Ephemeral:    params.Ephemeral,
+		Sparse:       params.Sparse,
+		DetachedHead: params.DetachedHead,
+		Commit:       params.Commit,
 	}
 
 	// Add to repository's worktrees
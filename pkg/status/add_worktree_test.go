@@ -4,9 +4,12 @@ package status
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lerenn/code-manager/pkg/config"
 	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"gopkg.in/yaml.v3"
@@ -85,6 +88,179 @@ workspaces: {}`), nil)
 	assert.NoError(t, err)
 }
 
+func TestAddWorktree_WithPullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+
+	cfg := config.Config{
+		RepositoriesDir: "/home/user/.cm",
+		StatusFile:      "/home/user/.cmstatus.yaml",
+	}
+
+	manager := &realManager{
+		fs:     mockFS,
+		config: cfg,
+	}
+
+	// Test data
+	repoURL := "github.com/octocat/Hello-World"
+	prInfo := &pullrequest.Info{
+		Number:         42,
+		Title:          "Fix typo",
+		URL:            "https://github.com/octocat/Hello-World/pull/42",
+		BaseBranch:     "main",
+		HeadBranch:     "fix-typo",
+		HeadOwner:      "contributor",
+		HeadRepository: "Hello-World",
+	}
+
+	// Expected status file content
+	expectedStatus := &Status{
+		Repositories: map[string]Repository{
+			repoURL: {
+				Path: "/home/user/.cmrepos/github.com/octocat/Hello-World/origin/main",
+				Remotes: map[string]Remote{
+					"origin": {
+						DefaultBranch: "main",
+					},
+				},
+				Worktrees: map[string]WorktreeInfo{
+					"contributor:fix-typo": {
+						Remote:      "contributor",
+						Branch:      "fix-typo",
+						PullRequest: prInfo,
+					},
+				},
+			},
+		},
+		Workspaces: make(map[string]Workspace),
+	}
+
+	expectedData, _ := yaml.Marshal(expectedStatus)
+
+	// Mock expectations
+	mockFS.EXPECT().Exists("/home/user/.cmstatus.yaml").Return(true, nil)
+	mockFS.EXPECT().ReadFile("/home/user/.cmstatus.yaml").Return([]byte(`initialized: true
+repositories:
+  github.com/octocat/Hello-World:
+    path: /home/user/.cmrepos/github.com/octocat/Hello-World/origin/main
+    remotes:
+      origin:
+        default_branch: main
+    worktrees: {}
+workspaces: {}`), nil)
+	mockFS.EXPECT().FileLock("/home/user/.cmstatus.yaml").Return(func() {}, nil)
+	mockFS.EXPECT().WriteFileAtomic("/home/user/.cmstatus.yaml", expectedData, gomock.Any()).Return(nil)
+
+	// Execute
+	err := manager.AddWorktree(AddWorktreeParams{
+		RepoURL:      repoURL,
+		Branch:       "fix-typo",
+		WorktreePath: "/home/user/.cmrepos/github.com/octocat/Hello-World/contributor/fix-typo",
+		Remote:       "contributor",
+		PullRequest:  prInfo,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(expectedData), "base_branch: main")
+}
+
+func TestAddWorktree_WithIssueMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockFS := fsmocks.NewMockFS(ctrl)
+
+	manager := &realManager{
+		fs:     mockFS,
+		config: config.Config{StatusFile: "/home/user/.cmstatus.yaml"},
+	}
+
+	issueInfo := &issue.Info{
+		Number:    7,
+		Title:     "Dark mode",
+		Labels:    []string{"enhancement"},
+		Assignees: []string{"octocat"},
+		Milestone: "v1.2",
+		LinkedPullRequests: []issue.LinkedPullRequest{
+			{Number: 8, URL: "https://github.com/octocat/Hello-World/pull/8", State: "open"},
+		},
+	}
+
+	var written []byte
+	mockFS.EXPECT().Exists("/home/user/.cmstatus.yaml").Return(true, nil)
+	mockFS.EXPECT().ReadFile("/home/user/.cmstatus.yaml").Return([]byte(`initialized: true
+repositories:
+  github.com/octocat/Hello-World:
+    path: /home/user/.cmrepos/github.com/octocat/Hello-World/origin/main
+    worktrees: {}
+workspaces: {}`), nil)
+	mockFS.EXPECT().FileLock("/home/user/.cmstatus.yaml").Return(func() {}, nil)
+	mockFS.EXPECT().WriteFileAtomic("/home/user/.cmstatus.yaml", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ string, data []byte, _ interface{}) error {
+			written = data
+			return nil
+		})
+
+	err := manager.AddWorktree(AddWorktreeParams{
+		RepoURL:   "github.com/octocat/Hello-World",
+		Branch:    "7-dark-mode",
+		Remote:    "origin",
+		IssueInfo: issueInfo,
+	})
+	assert.NoError(t, err)
+
+	// The issue metadata is persisted and read back from the status file
+	var status Status
+	assert.NoError(t, yaml.Unmarshal(written, &status))
+	assert.Equal(t, issueInfo, status.Repositories["github.com/octocat/Hello-World"].Worktrees["origin:7-dark-mode"].Issue)
+	assert.Contains(t, string(written), "milestone: v1.2")
+}
+
+func TestAddWorktree_Ephemeral(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockFS := fsmocks.NewMockFS(ctrl)
+
+	manager := &realManager{
+		fs:     mockFS,
+		config: config.Config{StatusFile: "/home/user/.cmstatus.yaml"},
+	}
+
+	ephemeral := &Ephemeral{CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), TTL: 48 * time.Hour}
+
+	var written []byte
+	mockFS.EXPECT().Exists("/home/user/.cmstatus.yaml").Return(true, nil)
+	mockFS.EXPECT().ReadFile("/home/user/.cmstatus.yaml").Return([]byte(`initialized: true
+repositories:
+  github.com/octocat/Hello-World:
+    path: /home/user/.cmrepos/github.com/octocat/Hello-World/origin/main
+    worktrees: {}
+workspaces: {}`), nil)
+	mockFS.EXPECT().FileLock("/home/user/.cmstatus.yaml").Return(func() {}, nil)
+	mockFS.EXPECT().WriteFileAtomic("/home/user/.cmstatus.yaml", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ string, data []byte, _ interface{}) error {
+			written = data
+			return nil
+		})
+
+	err := manager.AddWorktree(AddWorktreeParams{
+		RepoURL:   "github.com/octocat/Hello-World",
+		Branch:    "experiment",
+		Remote:    "origin",
+		Ephemeral: ephemeral,
+	})
+	assert.NoError(t, err)
+
+	// The creation time and TTL are persisted and read back from the status file
+	var status Status
+	assert.NoError(t, yaml.Unmarshal(written, &status))
+	stored := status.Repositories["github.com/octocat/Hello-World"].Worktrees["origin:experiment"].Ephemeral
+	assert.Equal(t, ephemeral, stored)
+	assert.Equal(t, time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC), stored.ExpiresAt())
+	assert.Contains(t, string(written), "ttl: 48h0m0s")
+}
+
 func TestAddWorktree_Duplicate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
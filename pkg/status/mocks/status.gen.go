@@ -239,3 +239,17 @@ func (mr *MockManagerMockRecorder) UpdateWorkspace(workspaceName, workspace any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockManager)(nil).UpdateWorkspace), workspaceName, workspace)
 }
+
+// UpdateWorktree mocks base method.
+func (m *MockManager) UpdateWorktree(repoURL, branch string, worktree status.WorktreeInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorktree", repoURL, branch, worktree)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorktree indicates an expected call of UpdateWorktree.
+func (mr *MockManagerMockRecorder) UpdateWorktree(repoURL, branch, worktree any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorktree", reflect.TypeOf((*MockManager)(nil).UpdateWorktree), repoURL, branch, worktree)
+}
@@ -3,12 +3,15 @@ package status
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/lerenn/code-manager/pkg/config"
 	"github.com/lerenn/code-manager/pkg/fs"
 	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
 	"gopkg.in/yaml.v3"
 )
 
@@ -50,10 +53,41 @@ func (w *Workspace) HasRepository(repositoryName string) bool {
 
 // WorktreeInfo represents worktree information.
 type WorktreeInfo struct {
-	Remote   string      `yaml:"remote"`
-	Branch   string      `yaml:"branch"`
-	Issue    *issue.Info `yaml:"issue,omitempty"`
-	Detached bool        `yaml:"detached,omitempty"` // When true, indicates this is a standalone clone
+	Remote      string            `yaml:"remote"`
+	Branch      string            `yaml:"branch"`
+	Issue       *issue.Info       `yaml:"issue,omitempty"`
+	PullRequest *pullrequest.Info `yaml:"pull_request,omitempty"`
+	Detached    bool              `yaml:"detached,omitempty"`  // When true, indicates this is a standalone clone
+	BaseRef     string            `yaml:"base_ref,omitempty"`  // Ref the branch was created from (e.g. release/1.4)
+	Ephemeral   *Ephemeral        `yaml:"ephemeral,omitempty"` // Set for worktrees that are garbage collected
+	Locked      bool              `yaml:"locked,omitempty"`    // Locked worktrees are kept by bulk deletions
+	LockReason  string            `yaml:"lock_reason,omitempty"`
+	Labels      []string          `yaml:"labels,omitempty"` // Free-form labels set with cm worktree annotate
+	Note        string            `yaml:"note,omitempty"`
+	Sparse      []string          `yaml:"sparse,omitempty"` // Directories checked out with sparse-checkout
+	// DetachedHead is set for worktrees checked out on a tag or commit instead of a branch (Branch holds
+	// the tag or short commit SHA, and Remote is "detached")
+	DetachedHead bool   `yaml:"detached_head,omitempty"`
+	Commit       string `yaml:"commit,omitempty"` // Commit checked out by detached HEAD worktrees
+}
+
+// HasLabel reports whether the worktree or its linked issue has the label, ignoring case.
+func (w WorktreeInfo) HasLabel(label string) bool {
+	if slices.ContainsFunc(w.Labels, func(l string) bool { return strings.EqualFold(l, label) }) {
+		return true
+	}
+	return w.Issue != nil && w.Issue.HasLabel(label)
+}
+
+// Ephemeral contains the lifetime of an ephemeral worktree.
+type Ephemeral struct {
+	CreatedAt time.Time     `yaml:"created_at"`
+	TTL       time.Duration `yaml:"ttl"`
+}
+
+// ExpiresAt returns when the ephemeral worktree expires.
+func (e Ephemeral) ExpiresAt() time.Time {
+	return e.CreatedAt.Add(e.TTL)
 }
 
 // Manager interface provides status file management functionality.
@@ -64,6 +98,8 @@ type Manager interface {
 	RemoveWorktree(repoURL, branch string) error
 	// GetWorktree retrieves the status of a specific worktree.
 	GetWorktree(repoURL, branch string) (*WorktreeInfo, error)
+	// UpdateWorktree updates an existing worktree entry in the status file.
+	UpdateWorktree(repoURL, branch string, worktree WorktreeInfo) error
 	// CreateInitialStatus creates the initial status file structure.
 	CreateInitialStatus() error
 	// AddRepository adds a repository entry to the status file.
@@ -128,8 +164,14 @@ type AddWorktreeParams struct {
 	WorktreePath  string
 	WorkspacePath string
 	IssueInfo     *issue.Info
+	PullRequest   *pullrequest.Info
 	Remote        string
 	Detached      bool
+	BaseRef       string
+	Ephemeral     *Ephemeral
+	Sparse        []string
+	DetachedHead  bool
+	Commit        string
 }
 
 // AddRepositoryParams contains parameters for AddRepository.
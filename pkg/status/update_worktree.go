@@ -0,0 +1,35 @@
+package status
+
+import (
+	"fmt"
+)
+
+// UpdateWorktree updates an existing worktree entry in the status file.
+func (s *realManager) UpdateWorktree(repoURL, branch string, worktree WorktreeInfo) error {
+	// Load current status
+	status, err := s.loadStatus()
+	if err != nil {
+		return fmt.Errorf("failed to load status: %w", err)
+	}
+
+	repo, err := s.validateRepository(status, repoURL)
+	if err != nil {
+		return err
+	}
+
+	// Find the worktree entry and replace it, keeping its key
+	for worktreeKey, existing := range repo.Worktrees {
+		if existing.Branch == branch {
+			repo.Worktrees[worktreeKey] = worktree
+			status.Repositories[repoURL] = repo
+
+			// Save updated status
+			if err := s.saveStatus(status); err != nil {
+				return fmt.Errorf("failed to save status: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w for repository %s branch %s", ErrWorktreeNotFound, repoURL, branch)
+}
@@ -0,0 +1,122 @@
+//go:build unit
+
+package status
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpdateWorktree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+
+	cfg := config.Config{
+		RepositoriesDir: "/home/user/.cm",
+		StatusFile:      "/home/user/.cmstatus.yaml",
+	}
+
+	manager := &realManager{
+		fs:     mockFS,
+		config: cfg,
+	}
+
+	// Test data
+	repoURL := "github.com/octocat/Hello-World"
+	existingStatus := &Status{
+		Repositories: map[string]Repository{
+			repoURL: {
+				Path: "/home/user/.cmrepos/github.com/octocat/Hello-World/origin/main",
+				Worktrees: map[string]WorktreeInfo{
+					"origin:feature-a": {
+						Remote: "origin",
+						Branch: "feature-a",
+					},
+				},
+			},
+		},
+		Workspaces: make(map[string]Workspace),
+	}
+	existingData, _ := yaml.Marshal(existingStatus)
+
+	updatedWorktree := WorktreeInfo{
+		Remote: "origin",
+		Branch: "feature-a",
+		PullRequest: &pullrequest.Info{
+			Number: 12,
+			URL:    "https://github.com/octocat/Hello-World/pull/12",
+		},
+	}
+	expectedStatus := &Status{
+		Repositories: map[string]Repository{
+			repoURL: {
+				Path: "/home/user/.cmrepos/github.com/octocat/Hello-World/origin/main",
+				Worktrees: map[string]WorktreeInfo{
+					"origin:feature-a": updatedWorktree,
+				},
+			},
+		},
+		Workspaces: make(map[string]Workspace),
+	}
+	expectedData, _ := yaml.Marshal(expectedStatus)
+
+	// Mock expectations
+	mockFS.EXPECT().Exists("/home/user/.cmstatus.yaml").Return(true, nil)
+	mockFS.EXPECT().ReadFile("/home/user/.cmstatus.yaml").Return(existingData, nil)
+	mockFS.EXPECT().FileLock("/home/user/.cmstatus.yaml").Return(func() {}, nil)
+	mockFS.EXPECT().WriteFileAtomic("/home/user/.cmstatus.yaml", expectedData, gomock.Any()).Return(nil)
+
+	// Execute
+	err := manager.UpdateWorktree(repoURL, "feature-a", updatedWorktree)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestUpdateWorktree_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+
+	cfg := config.Config{
+		RepositoriesDir: "/home/user/.cm",
+		StatusFile:      "/home/user/.cmstatus.yaml",
+	}
+
+	manager := &realManager{
+		fs:     mockFS,
+		config: cfg,
+	}
+
+	// Existing status without the requested worktree
+	repoURL := "github.com/octocat/Hello-World"
+	existingStatus := &Status{
+		Repositories: map[string]Repository{
+			repoURL: {
+				Path:      "/home/user/.cmrepos/github.com/octocat/Hello-World/origin/main",
+				Worktrees: map[string]WorktreeInfo{},
+			},
+		},
+		Workspaces: make(map[string]Workspace),
+	}
+	existingData, _ := yaml.Marshal(existingStatus)
+
+	// Mock expectations
+	mockFS.EXPECT().Exists("/home/user/.cmstatus.yaml").Return(true, nil)
+	mockFS.EXPECT().ReadFile("/home/user/.cmstatus.yaml").Return(existingData, nil)
+
+	// Execute
+	err := manager.UpdateWorktree(repoURL, "feature-a", WorktreeInfo{Remote: "origin", Branch: "feature-a"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrWorktreeNotFound)
+}
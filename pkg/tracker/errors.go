@@ -0,0 +1,9 @@
+package tracker
+
+import "errors"
+
+// Issue tracker errors.
+var (
+	ErrNoTrackerForIssue = errors.New("no issue tracker handles the issue")
+	ErrIssueNotHandled   = errors.New("issue reference not handled by the issue tracker")
+)
@@ -0,0 +1,254 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/issue"
+)
+
+const (
+	// JiraName is the name identifier for Jira issue trackers.
+	JiraName = "jira"
+	// JiraTokenEnv is the environment variable holding the Jira API token.
+	JiraTokenEnv = "JIRA_API_TOKEN"
+	// JiraUserEnv is the environment variable holding the Jira user, enabling basic authentication.
+	JiraUserEnv = "JIRA_USER"
+	// jiraIssueFields are the issue fields requested from the Jira API.
+	jiraIssueFields = "summary,description,status,labels,issuetype,assignee,fixVersions"
+	// jiraDoneCategory is the status category of resolved Jira issues.
+	jiraDoneCategory = "done"
+)
+
+// jiraKeyRegexp matches Jira issue keys (e.g. PROJ-123).
+var jiraKeyRegexp = regexp.MustCompile(`^([A-Z][A-Z0-9_]+)-(\d+)$`)
+
+// Jira represents a Jira-compatible issue tracker, queried through the REST API v2.
+type Jira struct {
+	name       string
+	baseURL    string
+	projects   []string
+	tokenEnv   string
+	userEnv    string
+	httpClient *http.Client
+}
+
+// NewJiraOpts contains optional parameters for NewJira.
+type NewJiraOpts struct {
+	Projects   []string     // Project keys handled by the tracker (all projects if empty)
+	TokenEnv   string       // Environment variable holding the API token (defaults to JIRA_API_TOKEN)
+	UserEnv    string       // Environment variable holding the user for basic authentication (defaults to JIRA_USER)
+	HTTPClient *http.Client // HTTP client used for API calls (defaults to http.DefaultClient)
+}
+
+// jiraIssue represents the subset of the Jira issue payload used by CM.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels"`
+		Status      struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		FixVersions []struct {
+			Name string `json:"name"`
+		} `json:"fixVersions"`
+	} `json:"fields"`
+}
+
+// NewJira creates a new Jira issue tracker for the instance at baseURL.
+func NewJira(name, baseURL string, opts ...NewJiraOpts) *Jira {
+	j := &Jira{
+		name:       name,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		tokenEnv:   JiraTokenEnv,
+		userEnv:    JiraUserEnv,
+		httpClient: http.DefaultClient,
+	}
+
+	if len(opts) > 0 {
+		j.projects = opts[0].Projects
+		if opts[0].TokenEnv != "" {
+			j.tokenEnv = opts[0].TokenEnv
+		}
+		if opts[0].UserEnv != "" {
+			j.userEnv = opts[0].UserEnv
+		}
+		if opts[0].HTTPClient != nil {
+			j.httpClient = opts[0].HTTPClient
+		}
+	}
+
+	return j
+}
+
+// Name returns the name of the issue tracker.
+func (j *Jira) Name() string {
+	return j.name
+}
+
+// ParseIssueReference parses Jira issue keys (PROJ-123) and browse URLs (<base URL>/browse/PROJ-123).
+// References that are not Jira issues of the tracker projects are rejected with ErrIssueNotHandled.
+func (j *Jira) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	key := strings.TrimSpace(issueRef)
+	if browsePrefix := j.baseURL + "/browse/"; strings.HasPrefix(key, browsePrefix) {
+		key = strings.TrimSuffix(strings.TrimPrefix(key, browsePrefix), "/")
+	}
+
+	matches := jiraKeyRegexp.FindStringSubmatch(key)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: %s", ErrIssueNotHandled, issueRef)
+	}
+	if len(j.projects) > 0 && !slices.Contains(j.projects, matches[1]) {
+		return nil, fmt.Errorf("%w: project %s is not handled by %s", ErrIssueNotHandled, matches[1], j.name)
+	}
+
+	number, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", forge.ErrInvalidIssueRef, issueRef)
+	}
+
+	return &issue.Reference{
+		Repository:  matches[1],
+		IssueNumber: number,
+		URL:         j.browseURL(key),
+		Key:         key,
+	}, nil
+}
+
+// GetIssueInfo fetches issue information from the Jira REST API.
+func (j *Jira) GetIssueInfo(issueRef string, opts ...forge.GetIssueInfoOpts) (*issue.Info, error) {
+	ref, err := j.ParseIssueReference(issueRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=%s", j.baseURL, url.PathEscape(ref.Key), jiraIssueFields)
+	var jiraIssue jiraIssue
+	if err := j.getJSON(ctx, endpoint, ref.Key, &jiraIssue); err != nil {
+		return nil, err
+	}
+
+	info := jiraIssue.toInfo(ref, j.browseURL(jiraIssue.Key))
+	if info.State != issue.StateOpen && (len(opts) == 0 || !opts[0].AllowClosed) {
+		return nil, fmt.Errorf("%w: issue %s", forge.ErrIssueClosed, info.Key)
+	}
+
+	return &info, nil
+}
+
+// toInfo converts a Jira issue to issue information.
+// The issue type is added to the labels so that branch types can be derived from it.
+func (i jiraIssue) toInfo(ref *issue.Reference, browseURL string) issue.Info {
+	state := issue.StateOpen
+	if i.Fields.Status.StatusCategory.Key == jiraDoneCategory {
+		state = issue.StateClosed
+	}
+
+	labels := slices.Clone(i.Fields.Labels)
+	if issueType := strings.ToLower(i.Fields.IssueType.Name); issueType != "" && !slices.Contains(labels, issueType) {
+		labels = append(labels, issueType)
+	}
+
+	info := issue.Info{
+		Number:      ref.IssueNumber,
+		Title:       i.Fields.Summary,
+		Description: i.Fields.Description,
+		State:       state,
+		URL:         browseURL,
+		Repository:  ref.Repository,
+		Labels:      labels,
+		Key:         i.Key,
+	}
+
+	if i.Fields.Assignee != nil && i.Fields.Assignee.DisplayName != "" {
+		info.Assignees = []string{i.Fields.Assignee.DisplayName}
+	}
+	// The first fix version plays the role of the forge milestones
+	if len(i.Fields.FixVersions) > 0 {
+		info.Milestone = i.Fields.FixVersions[0].Name
+	}
+
+	return info
+}
+
+// GenerateBranchName generates branch name from issue information.
+func (j *Jira) GenerateBranchName(issueInfo *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error) {
+	return forge.GenerateBranchName(issueInfo, opts...)
+}
+
+// browseURL returns the URL of the issue in the Jira web interface.
+func (j *Jira) browseURL(key string) string {
+	return j.baseURL + "/browse/" + key
+}
+
+// getJSON performs an authenticated GET request on the Jira API and decodes the JSON response.
+func (j *Jira) getJSON(ctx context.Context, endpoint, key string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Jira request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Jira Cloud authenticates a user with an API token, Jira Data Center with a personal access token
+	if token := os.Getenv(j.tokenEnv); token != "" {
+		if user := os.Getenv(j.userEnv); user != "" {
+			req.SetBasicAuth(user, token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Jira API request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: issue %s", forge.ErrIssueNotFound, key)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: set the %s environment variable (and %s for basic authentication) for %s",
+			forge.ErrUnauthorized, j.tokenEnv, j.userEnv, j.baseURL)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: access forbidden", forge.ErrUnauthorized)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: Jira API rate limit exceeded", forge.ErrRateLimited)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Jira API request failed: unexpected status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Jira response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+//go:build unit
+
+package tracker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJiraTestServer creates an httptest server that serves a single Jira issue.
+func newJiraTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/PROJ-123", r.URL.EscapedPath())
+		assert.Equal(t, jiraIssueFields, r.URL.Query().Get("fields"))
+		user, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "dev@example.com", user)
+		assert.Equal(t, "test-token", token)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestJira_ParseIssueReference(t *testing.T) {
+	jira := NewJira("jira", "https://example.atlassian.net/", NewJiraOpts{Projects: []string{"PROJ"}})
+
+	tests := []struct {
+		name     string
+		issueRef string
+		expected *issue.Reference
+		wantErr  error
+	}{
+		{
+			name:     "key",
+			issueRef: "PROJ-123",
+			expected: &issue.Reference{
+				Repository: "PROJ", IssueNumber: 123, Key: "PROJ-123",
+				URL: "https://example.atlassian.net/browse/PROJ-123",
+			},
+		},
+		{
+			name:     "browse URL",
+			issueRef: "https://example.atlassian.net/browse/PROJ-7",
+			expected: &issue.Reference{
+				Repository: "PROJ", IssueNumber: 7, Key: "PROJ-7",
+				URL: "https://example.atlassian.net/browse/PROJ-7",
+			},
+		},
+		{name: "other project", issueRef: "OPS-1", wantErr: ErrIssueNotHandled},
+		{name: "forge issue", issueRef: "owner/repo#123", wantErr: ErrIssueNotHandled},
+		{name: "issue number", issueRef: "123", wantErr: ErrIssueNotHandled},
+		{name: "other host", issueRef: "https://jira.example.com/browse/PROJ-1", wantErr: ErrIssueNotHandled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := jira.ParseIssueReference(tt.issueRef)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestJira_GetIssueInfo(t *testing.T) {
+	t.Setenv(JiraUserEnv, "dev@example.com")
+	t.Setenv(JiraTokenEnv, "test-token")
+
+	server := newJiraTestServer(t, http.StatusOK, `{
+		"key": "PROJ-123",
+		"fields": {
+			"summary": "Add Dark Mode",
+			"description": "Users want a dark theme",
+			"labels": ["ui"],
+			"status": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}},
+			"issuetype": {"name": "Story"},
+			"assignee": {"displayName": "Jane Doe"},
+			"fixVersions": [{"name": "2024.1"}]
+		}
+	}`)
+	jira := NewJira("jira", server.URL)
+
+	info, err := jira.GetIssueInfo("PROJ-123")
+	require.NoError(t, err)
+	assert.Equal(t, &issue.Info{
+		Number:      123,
+		Title:       "Add Dark Mode",
+		Description: "Users want a dark theme",
+		State:       issue.StateOpen,
+		URL:         server.URL + "/browse/PROJ-123",
+		Repository:  "PROJ",
+		Labels:      []string{"ui", "story"},
+		Assignees:   []string{"Jane Doe"},
+		Milestone:   "2024.1",
+		Key:         "PROJ-123",
+	}, info)
+
+	branchName, err := jira.GenerateBranchName(info, forge.GenerateBranchNameOpts{
+		Template: "{{.Type}}/{{.ID}}-{{.Slug}}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "feat/PROJ-123-add-dark-mode", branchName)
+}
+
+func TestJira_GetIssueInfo_Errors(t *testing.T) {
+	t.Setenv(JiraUserEnv, "dev@example.com")
+	t.Setenv(JiraTokenEnv, "test-token")
+
+	doneIssue := `{"key": "PROJ-123", "fields": {"summary": "Done",
+		"status": {"name": "Done", "statusCategory": {"key": "done"}}}}`
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		opts    []forge.GetIssueInfoOpts
+		wantErr error
+	}{
+		{name: "not found", status: http.StatusNotFound, wantErr: forge.ErrIssueNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: forge.ErrUnauthorized},
+		{name: "done issue", status: http.StatusOK, body: doneIssue, wantErr: forge.ErrIssueClosed},
+		{name: "done issue allowed", status: http.StatusOK, body: doneIssue,
+			opts: []forge.GetIssueInfoOpts{{AllowClosed: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJiraTestServer(t, tt.status, tt.body)
+			jira := NewJira("jira", server.URL)
+
+			info, err := jira.GetIssueInfo("PROJ-123", tt.opts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, issue.StateClosed, info.State)
+		})
+	}
+}
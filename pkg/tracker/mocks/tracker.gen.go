@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tracker.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tracker.go -destination=mocks/tracker.gen.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	forge "github.com/lerenn/code-manager/pkg/forge"
+	issue "github.com/lerenn/code-manager/pkg/issue"
+	tracker "github.com/lerenn/code-manager/pkg/tracker"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIssueTracker is a mock of IssueTracker interface.
+type MockIssueTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockIssueTrackerMockRecorder is the mock recorder for MockIssueTracker.
+type MockIssueTrackerMockRecorder struct {
+	mock *MockIssueTracker
+}
+
+// NewMockIssueTracker creates a new mock instance.
+func NewMockIssueTracker(ctrl *gomock.Controller) *MockIssueTracker {
+	mock := &MockIssueTracker{ctrl: ctrl}
+	mock.recorder = &MockIssueTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueTracker) EXPECT() *MockIssueTrackerMockRecorder {
+	return m.recorder
+}
+
+// GenerateBranchName mocks base method.
+func (m *MockIssueTracker) GenerateBranchName(issueInfo *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{issueInfo}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateBranchName", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateBranchName indicates an expected call of GenerateBranchName.
+func (mr *MockIssueTrackerMockRecorder) GenerateBranchName(issueInfo any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{issueInfo}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBranchName", reflect.TypeOf((*MockIssueTracker)(nil).GenerateBranchName), varargs...)
+}
+
+// GetIssueInfo mocks base method.
+func (m *MockIssueTracker) GetIssueInfo(issueRef string, opts ...forge.GetIssueInfoOpts) (*issue.Info, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{issueRef}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetIssueInfo", varargs...)
+	ret0, _ := ret[0].(*issue.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssueInfo indicates an expected call of GetIssueInfo.
+func (mr *MockIssueTrackerMockRecorder) GetIssueInfo(issueRef any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{issueRef}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueInfo", reflect.TypeOf((*MockIssueTracker)(nil).GetIssueInfo), varargs...)
+}
+
+// Name mocks base method.
+func (m *MockIssueTracker) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIssueTrackerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIssueTracker)(nil).Name))
+}
+
+// ParseIssueReference mocks base method.
+func (m *MockIssueTracker) ParseIssueReference(issueRef string) (*issue.Reference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIssueReference", issueRef)
+	ret0, _ := ret[0].(*issue.Reference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIssueReference indicates an expected call of ParseIssueReference.
+func (mr *MockIssueTrackerMockRecorder) ParseIssueReference(issueRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIssueReference", reflect.TypeOf((*MockIssueTracker)(nil).ParseIssueReference), issueRef)
+}
+
+// MockManagerInterface is a mock of ManagerInterface interface.
+type MockManagerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockManagerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockManagerInterfaceMockRecorder is the mock recorder for MockManagerInterface.
+type MockManagerInterfaceMockRecorder struct {
+	mock *MockManagerInterface
+}
+
+// NewMockManagerInterface creates a new mock instance.
+func NewMockManagerInterface(ctrl *gomock.Controller) *MockManagerInterface {
+	mock := &MockManagerInterface{ctrl: ctrl}
+	mock.recorder = &MockManagerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManagerInterface) EXPECT() *MockManagerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetTrackerForIssue mocks base method.
+func (m *MockManagerInterface) GetTrackerForIssue(issueRef string) (tracker.IssueTracker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackerForIssue", issueRef)
+	ret0, _ := ret[0].(tracker.IssueTracker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackerForIssue indicates an expected call of GetTrackerForIssue.
+func (mr *MockManagerInterfaceMockRecorder) GetTrackerForIssue(issueRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackerForIssue", reflect.TypeOf((*MockManagerInterface)(nil).GetTrackerForIssue), issueRef)
+}
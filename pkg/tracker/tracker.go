@@ -0,0 +1,90 @@
+// Package tracker provides interfaces and implementations for interacting with issue trackers
+// that are independent of the forge hosting the code, such as Jira.
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/forge"
+	"github.com/lerenn/code-manager/pkg/issue"
+	"github.com/lerenn/code-manager/pkg/logger"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@latest  -source=tracker.go -destination=mocks/tracker.gen.go -package=mocks
+
+// IssueTracker interface defines the methods that all issue tracker implementations must provide.
+type IssueTracker interface {
+	// Name returns the name of the issue tracker
+	Name() string
+
+	// ParseIssueReference parses the issue keys and URLs handled by the issue tracker
+	ParseIssueReference(issueRef string) (*issue.Reference, error)
+
+	// GetIssueInfo fetches issue information from the issue tracker
+	GetIssueInfo(issueRef string, opts ...forge.GetIssueInfoOpts) (*issue.Info, error)
+
+	// GenerateBranchName generates branch name from issue information
+	GenerateBranchName(issueInfo *issue.Info, opts ...forge.GenerateBranchNameOpts) (string, error)
+}
+
+// ManagerInterface defines the interface for issue tracker management.
+type ManagerInterface interface {
+	// GetTrackerForIssue returns the issue tracker handling the issue reference,
+	// or ErrNoTrackerForIssue when the issue is not handled by any configured tracker
+	GetTrackerForIssue(issueRef string) (IssueTracker, error)
+}
+
+// ManagerProvider creates issue tracker managers, allowing callers to substitute them in tests.
+type ManagerProvider func(logger logger.Logger, cfg config.Config) ManagerInterface
+
+// Manager manages the issue trackers declared in configuration.
+type Manager struct {
+	trackers map[string]IssueTracker // configuration name -> tracker
+	logger   logger.Logger
+}
+
+// NewManager creates a new issue tracker manager with the issue trackers declared in configuration.
+func NewManager(logger logger.Logger, cfg config.Config) *Manager {
+	m := &Manager{
+		trackers: make(map[string]IssueTracker),
+		logger:   logger,
+	}
+
+	for name, trackerConfig := range cfg.IssueTrackers {
+		switch trackerConfig.Type {
+		case JiraName:
+			m.trackers[name] = NewJira(name, trackerConfig.URL, NewJiraOpts{
+				Projects: trackerConfig.Projects,
+				TokenEnv: trackerConfig.TokenEnv,
+				UserEnv:  trackerConfig.UserEnv,
+			})
+		default:
+			m.logger.Logf("Warning: unsupported issue tracker type '%s' for %s", trackerConfig.Type, name)
+		}
+	}
+
+	return m
+}
+
+// GetTrackerForIssue returns the first issue tracker, by name, that handles the issue reference.
+func (m *Manager) GetTrackerForIssue(issueRef string) (IssueTracker, error) {
+	names := make([]string, 0, len(m.trackers))
+	for name := range m.trackers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := m.trackers[name].ParseIssueReference(issueRef); err == nil {
+			m.logger.Logf("Resolved issue %s with issue tracker %s", issueRef, name)
+			return m.trackers[name], nil
+		} else if !errors.Is(err, ErrIssueNotHandled) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNoTrackerForIssue, issueRef)
+}
@@ -0,0 +1,35 @@
+//go:build unit
+
+package tracker
+
+import (
+	"testing"
+
+	"github.com/lerenn/code-manager/pkg/config"
+	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_GetTrackerForIssue(t *testing.T) {
+	manager := NewManager(logger.NewNoopLogger(), config.Config{
+		IssueTrackers: map[string]config.IssueTrackerConfig{
+			"jira":     {Type: JiraName, URL: "https://example.atlassian.net", Projects: []string{"PROJ"}},
+			"ops-jira": {Type: JiraName, URL: "https://ops.example.com", Projects: []string{"OPS"}},
+			"youtrack": {Type: "youtrack", URL: "https://youtrack.example.com"},
+		},
+	})
+
+	tracker, err := manager.GetTrackerForIssue("PROJ-123")
+	require.NoError(t, err)
+	assert.Equal(t, "jira", tracker.Name())
+
+	tracker, err = manager.GetTrackerForIssue("https://ops.example.com/browse/OPS-4")
+	require.NoError(t, err)
+	assert.Equal(t, "ops-jira", tracker.Name())
+
+	for _, issueRef := range []string{"OTHER-1", "owner/repo#1", "42"} {
+		_, err = manager.GetTrackerForIssue(issueRef)
+		assert.ErrorIs(t, err, ErrNoTrackerForIssue, issueRef)
+	}
+}
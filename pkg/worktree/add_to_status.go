@@ -16,7 +16,13 @@ func (w *realWorktree) AddToStatus(params AddToStatusParams) error {
 		WorkspacePath: params.WorkspacePath,
 		Remote:        params.Remote,
 		IssueInfo:     params.IssueInfo,
+		PullRequest:   params.PullRequest,
 		Detached:      params.Detached,
+		BaseRef:       params.BaseRef,
+		Ephemeral:     params.Ephemeral,
+		Sparse:        params.Sparse,
+		DetachedHead:  params.DetachedHead,
+		Commit:        params.Commit,
 	}); err != nil {
 		return fmt.Errorf("failed to add worktree to status: %w", err)
 	}
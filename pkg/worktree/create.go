@@ -27,6 +27,13 @@ func (w *realWorktree) Create(params CreateParams) error {
 		return err
 	}
 
+	// Create the branch from the base ref, so that detached clones can also start from it
+	if params.BaseRef != "" {
+		if err := w.createBranchFromBaseRef(params.RepoPath, params.Branch, params.BaseRef); err != nil {
+			return err
+		}
+	}
+
 	// Create worktree directory
 	if err := w.createWorktreeDirectory(params.WorktreePath); err != nil {
 		return err
@@ -38,8 +45,10 @@ func (w *realWorktree) Create(params CreateParams) error {
 	}
 
 	// Ensure branch exists (only for regular worktrees)
-	if err := w.EnsureBranchExists(params.RepoPath, params.Branch); err != nil {
-		return err
+	if params.BaseRef == "" {
+		if err := w.EnsureBranchExists(params.RepoPath, params.Branch); err != nil {
+			return err
+		}
 	}
 
 	return w.createRegularWorktree(params)
@@ -67,6 +76,10 @@ func (w *realWorktree) createDetachedCloneFromLocal(params CreateParams) error {
 		w.cleanupOnError(params.WorktreePath)
 		return fmt.Errorf("failed to create detached clone: %w", err)
 	}
+	if err := w.setSparseCheckout(params); err != nil {
+		w.cleanupOnError(params.WorktreePath)
+		return err
+	}
 	w.logger.Logf("✓ Detached clone created successfully for %s:%s", params.Remote, params.Branch)
 	return nil
 }
@@ -80,16 +93,23 @@ func (w *realWorktree) createDetachedCloneFromRemote(params CreateParams) error
 
 	w.logger.Logf("Branch doesn't exist locally, cloning from remote URL: %s", remoteURL)
 
-	// Clone from the remote URL (this will fetch all branches)
+	// Clone from the remote URL (this will fetch all branches) without checking out the default branch
 	if err := w.git.Clone(git.CloneParams{
 		RepoURL:    remoteURL,
 		TargetPath: params.WorktreePath,
 		Recursive:  true,
+		NoCheckout: true,
 	}); err != nil {
 		w.cleanupOnError(params.WorktreePath)
 		return fmt.Errorf("failed to clone from remote: %w", err)
 	}
 
+	// Sparse-checkout is set up before the branch is checked out, so that the other directories are never written
+	if err := w.setSparseCheckout(params); err != nil {
+		w.cleanupOnError(params.WorktreePath)
+		return err
+	}
+
 	// Checkout the specific branch
 	if err := w.git.CheckoutBranch(params.WorktreePath, params.Branch); err != nil {
 		w.cleanupOnError(params.WorktreePath)
@@ -116,6 +136,28 @@ func (w *realWorktree) createRegularWorktree(params CreateParams) error {
 		}
 		return fmt.Errorf("failed to create Git worktree: %w", err)
 	}
+
+	// Sparse-checkout is set up before the branch is checked out, so that the other directories are never written
+	if err := w.setSparseCheckout(params); err != nil {
+		if removeErr := w.git.RemoveWorktree(params.RepoPath, params.WorktreePath, true); removeErr != nil {
+			w.logger.Logf("Warning: failed to remove Git worktree: %v", removeErr)
+		}
+		return err
+	}
+
 	w.logger.Logf("✓ Worktree created successfully for %s:%s", params.Remote, params.Branch)
 	return nil
 }
+
+// setSparseCheckout restricts the worktree to the sparse directories, if any.
+func (w *realWorktree) setSparseCheckout(params CreateParams) error {
+	if len(params.Sparse) == 0 {
+		return nil
+	}
+
+	w.logger.Logf("Setting sparse-checkout of %s to %v", params.WorktreePath, params.Sparse)
+	if err := w.git.SparseCheckoutSet(params.WorktreePath, params.Sparse); err != nil {
+		return fmt.Errorf("failed to set sparse-checkout: %w", err)
+	}
+	return nil
+}
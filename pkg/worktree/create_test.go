@@ -181,6 +181,94 @@ func TestWorktree_Create_BranchDoesNotExist(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWorktree_Create_FromBaseRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+
+	worktree := &realWorktree{
+		fs: mockFS, git: mockGit, statusManager: mockStatus, logger: logger.NewNoopLogger(),
+		prompt: promptmocks.NewMockPrompter(ctrl), repositoriesDir: "/test/base",
+	}
+
+	params := CreateParams{
+		RepoURL:      "github.com/octocat/Hello-World",
+		Branch:       "hotfix/login",
+		WorktreePath: "/test/base/github.com/octocat/Hello-World/origin/hotfix/login",
+		RepoPath:     "/test/repo",
+		Remote:       "origin",
+		BaseRef:      "origin/release/1.4",
+	}
+
+	// The branch is created from the base ref instead of the default branch
+	mockFS.EXPECT().Exists(params.WorktreePath).Return(false, nil)
+	mockStatus.EXPECT().GetWorktree(params.RepoURL, params.Branch).Return(nil, errors.New("not found"))
+	mockGit.EXPECT().CheckReferenceConflict(params.RepoPath, params.Branch).Return(nil)
+	mockGit.EXPECT().BranchExists(params.RepoPath, params.Branch).Return(false, nil)
+	mockGit.EXPECT().CreateBranchFrom(git.CreateBranchFromParams{
+		RepoPath:   params.RepoPath,
+		NewBranch:  params.Branch,
+		FromBranch: "origin/release/1.4",
+	}).Return(nil)
+	mockFS.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockGit.EXPECT().CreateWorktreeWithNoCheckout(params.RepoPath, params.WorktreePath, params.Branch).Return(nil)
+
+	assert.NoError(t, worktree.Create(params))
+
+	// Existing branches cannot be created from a base ref
+	mockFS.EXPECT().Exists(params.WorktreePath).Return(false, nil)
+	mockStatus.EXPECT().GetWorktree(params.RepoURL, params.Branch).Return(nil, errors.New("not found"))
+	mockGit.EXPECT().CheckReferenceConflict(params.RepoPath, params.Branch).Return(nil)
+	mockGit.EXPECT().BranchExists(params.RepoPath, params.Branch).Return(true, nil)
+
+	assert.ErrorIs(t, worktree.Create(params), ErrBranchExistsWithBaseRef)
+}
+
+func TestWorktree_Create_Sparse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+
+	worktree := &realWorktree{
+		fs: mockFS, git: mockGit, statusManager: mockStatus, logger: logger.NewNoopLogger(),
+		prompt: promptmocks.NewMockPrompter(ctrl), repositoriesDir: "/test/base",
+	}
+
+	params := CreateParams{
+		RepoURL:      "github.com/octocat/Hello-World",
+		Branch:       "feature-branch",
+		WorktreePath: "/test/base/github.com/octocat/Hello-World/origin/feature-branch",
+		RepoPath:     "/test/repo",
+		Remote:       "origin",
+		Sparse:       []string{"services/api", "libs/common"},
+	}
+
+	mockFS.EXPECT().Exists(params.WorktreePath).Return(false, nil).Times(2)
+	mockStatus.EXPECT().GetWorktree(params.RepoURL, params.Branch).Return(nil, errors.New("not found")).Times(2)
+	mockGit.EXPECT().CheckReferenceConflict(params.RepoPath, params.Branch).Return(nil).Times(2)
+	mockGit.EXPECT().BranchExists(params.RepoPath, params.Branch).Return(true, nil).Times(2)
+	mockFS.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// Sparse-checkout is set up right after the worktree is created without checkout
+	gomock.InOrder(
+		mockGit.EXPECT().CreateWorktreeWithNoCheckout(params.RepoPath, params.WorktreePath, params.Branch).Return(nil),
+		mockGit.EXPECT().SparseCheckoutSet(params.WorktreePath, params.Sparse).Return(nil),
+	)
+	assert.NoError(t, worktree.Create(params))
+
+	// The worktree is removed when sparse-checkout cannot be set up
+	mockGit.EXPECT().CreateWorktreeWithNoCheckout(params.RepoPath, params.WorktreePath, params.Branch).Return(nil)
+	mockGit.EXPECT().SparseCheckoutSet(params.WorktreePath, params.Sparse).Return(errors.New("sparse failed"))
+	mockGit.EXPECT().RemoveWorktree(params.RepoPath, params.WorktreePath, true).Return(nil)
+	assert.ErrorContains(t, worktree.Create(params), "failed to set sparse-checkout")
+}
+
 func TestWorktree_Create_DetachedMode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -220,6 +308,7 @@ func TestWorktree_Create_DetachedMode(t *testing.T) {
 		assert.Equal(t, remoteURL, cloneParams.RepoURL)
 		assert.Equal(t, params.WorktreePath, cloneParams.TargetPath)
 		assert.True(t, cloneParams.Recursive)
+		assert.True(t, cloneParams.NoCheckout)
 		return nil
 	})
 	mockGit.EXPECT().CheckoutBranch(params.WorktreePath, params.Branch).Return(nil)
@@ -227,3 +316,45 @@ func TestWorktree_Create_DetachedMode(t *testing.T) {
 	err := worktree.Create(params)
 	assert.NoError(t, err)
 }
+
+func TestWorktree_Create_DetachedModeSparse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := fsmocks.NewMockFS(ctrl)
+	mockGit := gitmocks.NewMockGit(ctrl)
+	mockStatus := statusmocks.NewMockManager(ctrl)
+
+	worktree := &realWorktree{
+		fs: mockFS, git: mockGit, statusManager: mockStatus, logger: logger.NewNoopLogger(),
+		prompt: promptmocks.NewMockPrompter(ctrl), repositoriesDir: "/test/base",
+	}
+
+	params := CreateParams{
+		RepoURL:      "github.com/octocat/Hello-World",
+		Branch:       "feature-branch",
+		WorktreePath: "/test/base/github.com/octocat/Hello-World/origin/feature-branch",
+		RepoPath:     "/test/repo",
+		Remote:       "origin",
+		Detached:     true,
+		Sparse:       []string{"services/api"},
+	}
+
+	mockFS.EXPECT().Exists(params.WorktreePath).Return(false, nil)
+	mockStatus.EXPECT().GetWorktree(params.RepoURL, params.Branch).Return(nil, errors.New("not found"))
+	mockFS.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockGit.EXPECT().BranchExists(params.RepoPath, params.Branch).Return(false, nil)
+	mockGit.EXPECT().GetRemoteURL(params.RepoPath, params.Remote).Return("https://github.com/octocat/Hello-World.git", nil)
+
+	// Sparse-checkout is set up between the clone without checkout and the checkout of the branch
+	gomock.InOrder(
+		mockGit.EXPECT().Clone(gomock.Any()).DoAndReturn(func(cloneParams git.CloneParams) error {
+			assert.True(t, cloneParams.NoCheckout)
+			return nil
+		}),
+		mockGit.EXPECT().SparseCheckoutSet(params.WorktreePath, params.Sparse).Return(nil),
+		mockGit.EXPECT().CheckoutBranch(params.WorktreePath, params.Branch).Return(nil),
+	)
+
+	assert.NoError(t, worktree.Create(params))
+}
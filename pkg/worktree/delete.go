@@ -13,6 +13,7 @@ func (w *realWorktree) Delete(params DeleteParams) error {
 	if err := w.ValidateDeletion(ValidateDeletionParams{
 		RepoURL: params.RepoURL,
 		Branch:  params.Branch,
+		Force:   params.Force,
 	}); err != nil {
 		return err
 	}
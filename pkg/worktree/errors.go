@@ -8,6 +8,11 @@ var (
 	// Worktree errors.
 	ErrWorktreeExists      = errors.New("worktree already exists")
 	ErrWorktreeNotInStatus = errors.New("worktree not found in status file")
+	ErrWorktreeLocked      = errors.New("worktree is locked")
+
+	// Branch errors.
+	ErrBranchExistsWithBaseRef = errors.New("branch already exists, a base ref only applies to new branches")
+	ErrBaseRefNotFound         = errors.New("base ref not found")
 
 	// Directory errors.
 	ErrDirectoryExists = errors.New("directory already exists")
@@ -9,6 +9,8 @@ package interfaces
 import (
 	"github.com/lerenn/code-manager/pkg/issue"
 	"github.com/lerenn/code-manager/pkg/logger"
+	"github.com/lerenn/code-manager/pkg/pullrequest"
+	"github.com/lerenn/code-manager/pkg/status"
 )
 
 // Worktree defines the interface for worktree operations.
@@ -35,6 +37,9 @@ type Worktree interface {
 	// EnsureBranchExists ensures the specified branch exists, creating it if necessary.
 	EnsureBranchExists(repoPath, branch string) error
 
+	// ResolveBaseRef resolves the ref a new branch is created from, after fetching the remote.
+	ResolveBaseRef(repoPath, ref string) (string, error)
+
 	// AddToStatus adds the worktree to the status file.
 	AddToStatus(params AddToStatusParams) error
 
@@ -60,7 +65,9 @@ type CreateParams struct {
 	Remote       string
 	IssueInfo    *issue.Info
 	Force        bool
-	Detached     bool // When true, creates standalone clone instead of worktree
+	Detached     bool     // When true, creates standalone clone instead of worktree
+	BaseRef      string   // Ref the new branch is created from (defaults to the remote or default branch)
+	Sparse       []string // Directories checked out with sparse-checkout (full checkout when empty)
 }
 
 // DeleteParams contains parameters for worktree deletion.
@@ -84,6 +91,7 @@ type ValidateCreationParams struct {
 type ValidateDeletionParams struct {
 	RepoURL string
 	Branch  string
+	Force   bool // Allow deleting locked worktrees
 }
 
 // AddToStatusParams contains parameters for adding worktree to status.
@@ -94,7 +102,13 @@ type AddToStatusParams struct {
 	WorkspacePath string
 	Remote        string
 	IssueInfo     *issue.Info
+	PullRequest   *pullrequest.Info
 	Detached      bool
+	BaseRef       string
+	Ephemeral     *status.Ephemeral
+	Sparse        []string
+	DetachedHead  bool
+	Commit        string
 }
 
 // WorktreeProvider defines the function signature for creating worktree instances.
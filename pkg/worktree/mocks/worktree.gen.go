@@ -168,6 +168,21 @@ func (mr *MockWorktreeMockRecorder) RemoveFromStatus(repoURL, branch any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromStatus", reflect.TypeOf((*MockWorktree)(nil).RemoveFromStatus), repoURL, branch)
 }
 
+// ResolveBaseRef mocks base method.
+func (m *MockWorktree) ResolveBaseRef(repoPath, ref string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveBaseRef", repoPath, ref)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveBaseRef indicates an expected call of ResolveBaseRef.
+func (mr *MockWorktreeMockRecorder) ResolveBaseRef(repoPath, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveBaseRef", reflect.TypeOf((*MockWorktree)(nil).ResolveBaseRef), repoPath, ref)
+}
+
 // SetLogger mocks base method.
 func (m *MockWorktree) SetLogger(arg0 logger.Logger) {
 	m.ctrl.T.Helper()
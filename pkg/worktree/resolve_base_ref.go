@@ -0,0 +1,55 @@
+// Package worktree provides worktree management functionality for CM.
+package worktree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lerenn/code-manager/pkg/git"
+)
+
+// ResolveBaseRef resolves the ref a new branch is created from, after fetching the remote.
+// Branches only known by the remote (e.g. release/1.4) resolve to their remote-tracking branch.
+func (w *realWorktree) ResolveBaseRef(repoPath, ref string) (string, error) {
+	// Fetch so that remote branches and tags are up to date
+	if err := w.git.FetchRemote(repoPath, "origin"); err != nil {
+		w.logger.Logf("Warning: failed to fetch from origin, resolving %s locally: %v", ref, err)
+	}
+
+	for _, candidate := range []string{ref, "origin/" + ref} {
+		_, err := w.git.ResolveRef(repoPath, candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, git.ErrReferenceNotFound) {
+			return "", fmt.Errorf("failed to resolve base ref %s: %w", ref, err)
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrBaseRefNotFound, ref)
+}
+
+// createBranchFromBaseRef creates a new branch from the base ref.
+func (w *realWorktree) createBranchFromBaseRef(repoPath, branch, baseRef string) error {
+	if err := w.git.CheckReferenceConflict(repoPath, branch); err != nil {
+		return err
+	}
+
+	branchExists, err := w.git.BranchExists(repoPath, branch)
+	if err != nil {
+		return fmt.Errorf("failed to check if branch exists: %w", err)
+	}
+	if branchExists {
+		return fmt.Errorf("%w: %s", ErrBranchExistsWithBaseRef, branch)
+	}
+
+	w.logger.Logf("Creating branch %s from %s", branch, baseRef)
+	if err := w.git.CreateBranchFrom(git.CreateBranchFromParams{
+		RepoPath:   repoPath,
+		NewBranch:  branch,
+		FromBranch: baseRef,
+	}); err != nil {
+		return fmt.Errorf("failed to create branch %s from %s: %w", branch, baseRef, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+//go:build unit
+
+package worktree
+
+import (
+	"errors"
+	"testing"
+
+	fsmocks "github.com/lerenn/code-manager/pkg/fs/mocks"
+	"github.com/lerenn/code-manager/pkg/git"
+	gitmocks "github.com/lerenn/code-manager/pkg/git/mocks"
+	"github.com/lerenn/code-manager/pkg/logger"
+	promptmocks "github.com/lerenn/code-manager/pkg/prompt/mocks"
+	statusmocks "github.com/lerenn/code-manager/pkg/status/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorktree_ResolveBaseRef(t *testing.T) {
+	repoPath := "/test/repo"
+
+	tests := []struct {
+		name     string
+		ref      string
+		setup    func(mockGit *gitmocks.MockGit)
+		expected string
+		wantErr  error
+	}{
+		{
+			name: "local ref",
+			ref:  "v1.4.0",
+			setup: func(mockGit *gitmocks.MockGit) {
+				mockGit.EXPECT().FetchRemote(repoPath, "origin").Return(nil)
+				mockGit.EXPECT().ResolveRef(repoPath, "v1.4.0").Return("abc123", nil)
+			},
+			expected: "v1.4.0",
+		},
+		{
+			name: "remote branch",
+			ref:  "release/1.4",
+			setup: func(mockGit *gitmocks.MockGit) {
+				mockGit.EXPECT().FetchRemote(repoPath, "origin").Return(nil)
+				mockGit.EXPECT().ResolveRef(repoPath, "release/1.4").Return("", git.ErrReferenceNotFound)
+				mockGit.EXPECT().ResolveRef(repoPath, "origin/release/1.4").Return("abc123", nil)
+			},
+			expected: "origin/release/1.4",
+		},
+		{
+			name: "offline",
+			ref:  "abc123",
+			setup: func(mockGit *gitmocks.MockGit) {
+				mockGit.EXPECT().FetchRemote(repoPath, "origin").Return(errors.New("network unreachable"))
+				mockGit.EXPECT().ResolveRef(repoPath, "abc123").Return("abc123def", nil)
+			},
+			expected: "abc123",
+		},
+		{
+			name: "unknown ref",
+			ref:  "unknown",
+			setup: func(mockGit *gitmocks.MockGit) {
+				mockGit.EXPECT().FetchRemote(repoPath, "origin").Return(nil)
+				mockGit.EXPECT().ResolveRef(repoPath, gomock.Any()).Return("", git.ErrReferenceNotFound).Times(2)
+			},
+			wantErr: ErrBaseRefNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := gitmocks.NewMockGit(ctrl)
+			worktree := &realWorktree{
+				fs:              fsmocks.NewMockFS(ctrl),
+				git:             mockGit,
+				statusManager:   statusmocks.NewMockManager(ctrl),
+				logger:          logger.NewNoopLogger(),
+				prompt:          promptmocks.NewMockPrompter(ctrl),
+				repositoriesDir: "/test/base",
+			}
+			tt.setup(mockGit)
+
+			baseRef, err := worktree.ResolveBaseRef(repoPath, tt.ref)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, baseRef)
+		})
+	}
+}
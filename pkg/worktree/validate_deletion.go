@@ -13,5 +13,10 @@ func (w *realWorktree) ValidateDeletion(params ValidateDeletionParams) error {
 		return fmt.Errorf("%w for repository %s branch %s", ErrWorktreeNotInStatus, params.RepoURL, params.Branch)
 	}
 
+	// Locked worktrees are only deleted when forced
+	if existingWorktree.Locked && !params.Force {
+		return fmt.Errorf("%w: branch %s of repository %s", ErrWorktreeLocked, params.Branch, params.RepoURL)
+	}
+
 	return nil
 }
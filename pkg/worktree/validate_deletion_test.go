@@ -77,3 +77,31 @@ func TestWorktree_ValidateDeletion_WorktreeNotInStatus(t *testing.T) {
 	err := worktree.ValidateDeletion(params)
 	assert.ErrorIs(t, err, ErrWorktreeNotInStatus)
 }
+
+func TestWorktree_ValidateDeletion_Locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatus := statusmocks.NewMockManager(ctrl)
+
+	worktree := &realWorktree{
+		statusManager:   mockStatus,
+		repositoriesDir: "/test/base",
+	}
+
+	params := ValidateDeletionParams{
+		RepoURL: "github.com/octocat/Hello-World",
+		Branch:  "release",
+	}
+
+	mockStatus.EXPECT().GetWorktree(params.RepoURL, params.Branch).
+		Return(&status.WorktreeInfo{Branch: params.Branch, Remote: "origin", Locked: true}, nil).Times(2)
+
+	// Locked worktrees are only deleted when forced
+	err := worktree.ValidateDeletion(params)
+	assert.ErrorIs(t, err, ErrWorktreeLocked)
+
+	params.Force = true
+	err = worktree.ValidateDeletion(params)
+	assert.NoError(t, err)
+}